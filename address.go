@@ -0,0 +1,313 @@
+package ledger
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/cloudstruct/go-cardano-ledger/cbor"
+)
+
+const (
+	ADDRESS_HEADER_TYPE_MASK    = 0xF0
+	ADDRESS_HEADER_NETWORK_MASK = 0x0F
+
+	ADDRESS_TYPE_KEY_KEY         = 0b0000
+	ADDRESS_TYPE_SCRIPT_KEY      = 0b0001
+	ADDRESS_TYPE_KEY_SCRIPT      = 0b0010
+	ADDRESS_TYPE_SCRIPT_SCRIPT   = 0b0011
+	ADDRESS_TYPE_KEY_POINTER     = 0b0100
+	ADDRESS_TYPE_SCRIPT_POINTER  = 0b0101
+	ADDRESS_TYPE_KEY_NONE        = 0b0110
+	ADDRESS_TYPE_SCRIPT_NONE     = 0b0111
+	ADDRESS_TYPE_BYRON           = 0b1000
+	ADDRESS_TYPE_NONE_KEY        = 0b1110
+	ADDRESS_TYPE_NONE_SCRIPT     = 0b1111
+	ADDRESS_NETWORK_ID_TESTNET   = 0
+	ADDRESS_NETWORK_ID_MAINNET   = 1
+	ADDRESS_HASH_SIZE            = 28
+	ADDRESS_HRP_PAYMENT_MAINNET  = "addr"
+	ADDRESS_HRP_PAYMENT_TESTNET  = "addr_test"
+	ADDRESS_HRP_STAKE_MAINNET    = "stake"
+	ADDRESS_HRP_STAKE_TESTNET    = "stake_test"
+	byronAddressAttributeNetwork = 2
+)
+
+// Address represents a Shelley-era or Byron address. The raw address bytes are stored as a
+// string, which keeps the original encoding intact and allows addresses to be compared and
+// used as map keys
+type Address struct {
+	data string
+}
+
+// NewAddressFromBytes returns an Address from the raw address bytes
+func NewAddressFromBytes(data []byte) (Address, error) {
+	if len(data) == 0 {
+		return Address{}, fmt.Errorf("empty address")
+	}
+	addrType := data[0] >> 4
+	switch addrType {
+	case ADDRESS_TYPE_KEY_KEY, ADDRESS_TYPE_SCRIPT_KEY, ADDRESS_TYPE_KEY_SCRIPT, ADDRESS_TYPE_SCRIPT_SCRIPT:
+		if len(data) != 1+(2*ADDRESS_HASH_SIZE) {
+			return Address{}, fmt.Errorf("invalid base address length: %d", len(data))
+		}
+	case ADDRESS_TYPE_KEY_POINTER, ADDRESS_TYPE_SCRIPT_POINTER:
+		if len(data) < 1+ADDRESS_HASH_SIZE+3 {
+			return Address{}, fmt.Errorf("invalid pointer address length: %d", len(data))
+		}
+	case ADDRESS_TYPE_KEY_NONE, ADDRESS_TYPE_SCRIPT_NONE, ADDRESS_TYPE_NONE_KEY, ADDRESS_TYPE_NONE_SCRIPT:
+		if len(data) != 1+ADDRESS_HASH_SIZE {
+			return Address{}, fmt.Errorf("invalid address length: %d", len(data))
+		}
+	case ADDRESS_TYPE_BYRON:
+		var byronAddr ByronAddress
+		if _, err := cbor.Decode(data, &byronAddr); err != nil {
+			return Address{}, fmt.Errorf("invalid Byron address: %s", err)
+		}
+	default:
+		return Address{}, fmt.Errorf("unknown address type: %d", addrType)
+	}
+	return Address{data: string(data)}, nil
+}
+
+// NewAddress returns an Address from its string representation, which is bech32 for
+// Shelley-era addresses and base58 for Byron addresses
+func NewAddress(addr string) (Address, error) {
+	if _, data, err := bech32Decode(addr); err == nil {
+		return NewAddressFromBytes(data)
+	}
+	data, err := base58Decode(addr)
+	if err != nil {
+		return Address{}, fmt.Errorf("invalid address: %s", addr)
+	}
+	return NewAddressFromBytes(data)
+}
+
+// NewAddressFromParts returns a Shelley-era address of the specified type, built from the
+// specified payment and staking payloads. The staking payload should be nil for enterprise
+// addresses and the payment payload should be nil for reward addresses
+func NewAddressFromParts(addrType uint8, networkId uint8, paymentPayload []byte, stakingPayload []byte) (Address, error) {
+	data := []byte{(addrType << 4) | (networkId & ADDRESS_HEADER_NETWORK_MASK)}
+	data = append(data, paymentPayload...)
+	data = append(data, stakingPayload...)
+	return NewAddressFromBytes(data)
+}
+
+func (a *Address) UnmarshalCBOR(data []byte) error {
+	var tmpData []byte
+	if _, err := cbor.Decode(data, &tmpData); err != nil {
+		return err
+	}
+	tmpAddr, err := NewAddressFromBytes(tmpData)
+	if err != nil {
+		return err
+	}
+	*a = tmpAddr
+	return nil
+}
+
+func (a Address) MarshalCBOR() ([]byte, error) {
+	return cbor.Encode(a.Bytes())
+}
+
+func (a Address) MarshalJSON() ([]byte, error) {
+	return json.Marshal(a.String())
+}
+
+// Bytes returns the raw address bytes
+func (a Address) Bytes() []byte {
+	return []byte(a.data)
+}
+
+// Type returns the address type from the address header
+func (a Address) Type() uint8 {
+	if len(a.data) == 0 {
+		return 0
+	}
+	return a.data[0] >> 4
+}
+
+// NetworkId returns the network ID of the address. For Byron addresses, this is determined by
+// the presence of the network magic attribute
+func (a Address) NetworkId() uint8 {
+	if len(a.data) == 0 {
+		return 0
+	}
+	if a.Type() == ADDRESS_TYPE_BYRON {
+		var byronAddr ByronAddress
+		if _, err := cbor.Decode(a.Bytes(), &byronAddr); err == nil {
+			if attrs, ok := byronAddr.Attributes.Value.(map[interface{}]interface{}); ok {
+				if _, ok := attrs[uint64(byronAddressAttributeNetwork)]; ok {
+					return ADDRESS_NETWORK_ID_TESTNET
+				}
+			}
+		}
+		return ADDRESS_NETWORK_ID_MAINNET
+	}
+	return a.data[0] & ADDRESS_HEADER_NETWORK_MASK
+}
+
+// PaymentCredential returns the payment credential for the address, or nil for Byron and
+// reward addresses
+func (a Address) PaymentCredential() *Credential {
+	switch a.Type() {
+	case ADDRESS_TYPE_BYRON, ADDRESS_TYPE_NONE_KEY, ADDRESS_TYPE_NONE_SCRIPT:
+		return nil
+	}
+	credType := uint(CREDENTIAL_TYPE_ADDR_KEY_HASH)
+	if a.Type()&0b0001 != 0 {
+		credType = CREDENTIAL_TYPE_SCRIPT_HASH
+	}
+	ret := &Credential{Type: credType}
+	copy(ret.Hash[:], a.data[1:1+ADDRESS_HASH_SIZE])
+	return ret
+}
+
+// StakeCredential returns the staking credential for the address, or nil if the address does
+// not contain a staking credential
+func (a Address) StakeCredential() *Credential {
+	var credType uint
+	var offset int
+	switch a.Type() {
+	case ADDRESS_TYPE_KEY_KEY, ADDRESS_TYPE_SCRIPT_KEY:
+		credType, offset = CREDENTIAL_TYPE_ADDR_KEY_HASH, 1+ADDRESS_HASH_SIZE
+	case ADDRESS_TYPE_KEY_SCRIPT, ADDRESS_TYPE_SCRIPT_SCRIPT:
+		credType, offset = CREDENTIAL_TYPE_SCRIPT_HASH, 1+ADDRESS_HASH_SIZE
+	case ADDRESS_TYPE_NONE_KEY:
+		credType, offset = CREDENTIAL_TYPE_ADDR_KEY_HASH, 1
+	case ADDRESS_TYPE_NONE_SCRIPT:
+		credType, offset = CREDENTIAL_TYPE_SCRIPT_HASH, 1
+	default:
+		return nil
+	}
+	ret := &Credential{Type: credType}
+	copy(ret.Hash[:], a.data[offset:offset+ADDRESS_HASH_SIZE])
+	return ret
+}
+
+// String returns the bech32 encoding of the address, or the base58 encoding for Byron addresses
+func (a Address) String() string {
+	if len(a.data) == 0 {
+		return ""
+	}
+	if a.Type() == ADDRESS_TYPE_BYRON {
+		return base58Encode(a.Bytes())
+	}
+	var hrp string
+	switch a.Type() {
+	case ADDRESS_TYPE_NONE_KEY, ADDRESS_TYPE_NONE_SCRIPT:
+		hrp = ADDRESS_HRP_STAKE_MAINNET
+		if a.NetworkId() != ADDRESS_NETWORK_ID_MAINNET {
+			hrp = ADDRESS_HRP_STAKE_TESTNET
+		}
+	default:
+		hrp = ADDRESS_HRP_PAYMENT_MAINNET
+		if a.NetworkId() != ADDRESS_NETWORK_ID_MAINNET {
+			hrp = ADDRESS_HRP_PAYMENT_TESTNET
+		}
+	}
+	return bech32Encode(hrp, a.Bytes())
+}
+
+const bech32Charset = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+
+func bech32Polymod(values []byte) uint32 {
+	gen := []uint32{0x3b6a57b2, 0x26508e6d, 0x1ea119fa, 0x3d4233dd, 0x2a1462b3}
+	chk := uint32(1)
+	for _, v := range values {
+		top := chk >> 25
+		chk = (chk&0x1ffffff)<<5 ^ uint32(v)
+		for i := 0; i < 5; i++ {
+			if (top>>i)&1 == 1 {
+				chk ^= gen[i]
+			}
+		}
+	}
+	return chk
+}
+
+func bech32HrpExpand(hrp string) []byte {
+	ret := make([]byte, 0, len(hrp)*2+1)
+	for i := 0; i < len(hrp); i++ {
+		ret = append(ret, hrp[i]>>5)
+	}
+	ret = append(ret, 0)
+	for i := 0; i < len(hrp); i++ {
+		ret = append(ret, hrp[i]&31)
+	}
+	return ret
+}
+
+// Helper function to regroup bits between 8-bit bytes and 5-bit bech32 words
+func bech32ConvertBits(data []byte, fromBits uint, toBits uint, pad bool) ([]byte, error) {
+	acc := uint32(0)
+	bits := uint(0)
+	maxv := uint32(1<<toBits) - 1
+	ret := []byte{}
+	for _, v := range data {
+		if uint32(v)>>fromBits != 0 {
+			return nil, fmt.Errorf("invalid data value: %d", v)
+		}
+		acc = (acc << fromBits) | uint32(v)
+		bits += fromBits
+		for bits >= toBits {
+			bits -= toBits
+			ret = append(ret, byte((acc>>bits)&maxv))
+		}
+	}
+	if pad {
+		if bits > 0 {
+			ret = append(ret, byte((acc<<(toBits-bits))&maxv))
+		}
+	} else if bits >= fromBits || (acc<<(toBits-bits))&maxv != 0 {
+		return nil, fmt.Errorf("invalid padding")
+	}
+	return ret, nil
+}
+
+// Helper function to encode data as bech32. Unlike BIP-0173, Cardano does not limit the
+// length of the encoded string
+func bech32Encode(hrp string, data []byte) string {
+	words, _ := bech32ConvertBits(data, 8, 5, true)
+	values := append(bech32HrpExpand(hrp), words...)
+	polymod := bech32Polymod(append(values, 0, 0, 0, 0, 0, 0)) ^ 1
+	var sb strings.Builder
+	sb.WriteString(hrp)
+	sb.WriteByte('1')
+	for _, w := range words {
+		sb.WriteByte(bech32Charset[w])
+	}
+	for i := 0; i < 6; i++ {
+		sb.WriteByte(bech32Charset[(polymod>>(5*(5-i)))&31])
+	}
+	return sb.String()
+}
+
+// Helper function to decode a bech32 string, returning the HRP and data
+func bech32Decode(value string) (string, []byte, error) {
+	if strings.ToLower(value) != value && strings.ToUpper(value) != value {
+		return "", nil, fmt.Errorf("mixed case in bech32 string")
+	}
+	value = strings.ToLower(value)
+	sepIdx := strings.LastIndexByte(value, '1')
+	if sepIdx < 1 || sepIdx+7 > len(value) {
+		return "", nil, fmt.Errorf("invalid bech32 separator position")
+	}
+	hrp := value[:sepIdx]
+	words := make([]byte, 0, len(value)-sepIdx-1)
+	for i := sepIdx + 1; i < len(value); i++ {
+		idx := strings.IndexByte(bech32Charset, value[i])
+		if idx < 0 {
+			return "", nil, fmt.Errorf("invalid bech32 character: %c", value[i])
+		}
+		words = append(words, byte(idx))
+	}
+	if bech32Polymod(append(bech32HrpExpand(hrp), words...)) != 1 {
+		return "", nil, fmt.Errorf("invalid bech32 checksum")
+	}
+	data, err := bech32ConvertBits(words[:len(words)-6], 5, 8, false)
+	if err != nil {
+		return "", nil, err
+	}
+	return hrp, data, nil
+}
@@ -0,0 +1,99 @@
+package ledger_test
+
+import (
+	"testing"
+
+	"github.com/cloudstruct/go-cardano-ledger"
+)
+
+type addressTestDefinition struct {
+	Address        string
+	Type           uint8
+	NetworkId      uint8
+	PaymentKeyHash string
+	StakeKeyHash   string
+}
+
+// Test vectors from CIP-0019
+var addressTests = []addressTestDefinition{
+	{
+		Address:        "addr1qx2fxv2umyhttkxyxp8x0dlpdt3k6cwng5pxj3jhsydzer3n0d3vllmyqwsx5wktcd8cc3sq835lu7drv2xwl2wywfgse35a3x",
+		Type:           ledger.ADDRESS_TYPE_KEY_KEY,
+		NetworkId:      ledger.ADDRESS_NETWORK_ID_MAINNET,
+		PaymentKeyHash: "9493315cd92eb5d8c4304e67b7e16ae36d61d34502694657811a2c8e",
+		StakeKeyHash:   "337b62cfff6403a06a3acbc34f8c46003c69fe79a3628cefa9c47251",
+	},
+	{
+		Address:        "addr_test1qz2fxv2umyhttkxyxp8x0dlpdt3k6cwng5pxj3jhsydzer3n0d3vllmyqwsx5wktcd8cc3sq835lu7drv2xwl2wywfgs68faae",
+		Type:           ledger.ADDRESS_TYPE_KEY_KEY,
+		NetworkId:      ledger.ADDRESS_NETWORK_ID_TESTNET,
+		PaymentKeyHash: "9493315cd92eb5d8c4304e67b7e16ae36d61d34502694657811a2c8e",
+		StakeKeyHash:   "337b62cfff6403a06a3acbc34f8c46003c69fe79a3628cefa9c47251",
+	},
+	{
+		Address:        "addr1gx2fxv2umyhttkxyxp8x0dlpdt3k6cwng5pxj3jhsydzer5pnz75xxcrzqf96k",
+		Type:           ledger.ADDRESS_TYPE_KEY_POINTER,
+		NetworkId:      ledger.ADDRESS_NETWORK_ID_MAINNET,
+		PaymentKeyHash: "9493315cd92eb5d8c4304e67b7e16ae36d61d34502694657811a2c8e",
+	},
+	{
+		Address:        "addr1vx2fxv2umyhttkxyxp8x0dlpdt3k6cwng5pxj3jhsydzers66hrl8",
+		Type:           ledger.ADDRESS_TYPE_KEY_NONE,
+		NetworkId:      ledger.ADDRESS_NETWORK_ID_MAINNET,
+		PaymentKeyHash: "9493315cd92eb5d8c4304e67b7e16ae36d61d34502694657811a2c8e",
+	},
+	{
+		Address:      "stake1uyehkck0lajq8gr28t9uxnuvgcqrc6070x3k9r8048z8y5gh6ffgw",
+		Type:         ledger.ADDRESS_TYPE_NONE_KEY,
+		NetworkId:    ledger.ADDRESS_NETWORK_ID_MAINNET,
+		StakeKeyHash: "337b62cfff6403a06a3acbc34f8c46003c69fe79a3628cefa9c47251",
+	},
+	{
+		Address:   "Ae2tdPwUPEZFRbyhz3cpfC2CumGzNkFBN2L42rcUc2yjQpEkxDbkPodpMAi",
+		Type:      ledger.ADDRESS_TYPE_BYRON,
+		NetworkId: ledger.ADDRESS_NETWORK_ID_MAINNET,
+	},
+	{
+		Address:   "37btjrVyb4KDXBNC4haBVPCrro8AQPHwvCMp3RFhhSVWwfFmZ6wwzSK6JK1hY6wHNmtrpTf1kdbva8TCneM2YsiXT7mrzT21EacHnPpz5YyUdj64na",
+		Type:      ledger.ADDRESS_TYPE_BYRON,
+		NetworkId: ledger.ADDRESS_NETWORK_ID_TESTNET,
+	},
+}
+
+func TestAddress(t *testing.T) {
+	for _, test := range addressTests {
+		addr, err := ledger.NewAddress(test.Address)
+		if err != nil {
+			t.Fatalf("failed to decode address %s: %s", test.Address, err)
+		}
+		if addr.String() != test.Address {
+			t.Fatalf("address did not round-trip: got %s, expected %s", addr.String(), test.Address)
+		}
+		if addr.Type() != test.Type {
+			t.Fatalf("address %s: got type %d, expected %d", test.Address, addr.Type(), test.Type)
+		}
+		if addr.NetworkId() != test.NetworkId {
+			t.Fatalf("address %s: got network ID %d, expected %d", test.Address, addr.NetworkId(), test.NetworkId)
+		}
+		var paymentKeyHash, stakeKeyHash string
+		if cred := addr.PaymentCredential(); cred != nil {
+			paymentKeyHash = cred.Hash.String()
+		}
+		if cred := addr.StakeCredential(); cred != nil {
+			stakeKeyHash = cred.Hash.String()
+		}
+		if paymentKeyHash != test.PaymentKeyHash {
+			t.Fatalf("address %s: got payment key hash %s, expected %s", test.Address, paymentKeyHash, test.PaymentKeyHash)
+		}
+		if stakeKeyHash != test.StakeKeyHash {
+			t.Fatalf("address %s: got stake key hash %s, expected %s", test.Address, stakeKeyHash, test.StakeKeyHash)
+		}
+		tmpAddr, err := ledger.NewAddressFromBytes(addr.Bytes())
+		if err != nil {
+			t.Fatalf("failed to decode address bytes for %s: %s", test.Address, err)
+		}
+		if tmpAddr != addr {
+			t.Fatalf("address bytes did not round-trip for %s", test.Address)
+		}
+	}
+}
@@ -29,7 +29,7 @@ func (b *AllegraBlock) UnmarshalCBOR(cborData []byte) error {
 	return b.UnmarshalCborGeneric(cborData, b)
 }
 
-func (b *AllegraBlock) Hash() string {
+func (b *AllegraBlock) Hash() Blake2b256 {
 	return b.Header.Hash()
 }
 
@@ -47,8 +47,8 @@ func (b *AllegraBlock) Era() Era {
 
 func (b *AllegraBlock) Transactions() []TransactionBody {
 	ret := []TransactionBody{}
-	for _, v := range b.TransactionBodies {
-		ret = append(ret, &v)
+	for idx := range b.TransactionBodies {
+		ret = append(ret, &b.TransactionBodies[idx])
 	}
 	return ret
 }
@@ -72,11 +72,16 @@ func (b *AllegraTransactionBody) UnmarshalCBOR(cborData []byte) error {
 
 type AllegraTransaction struct {
 	cbor.StructAsArray
+	cbor.DecodeStoreCbor
 	Body       AllegraTransactionBody
 	WitnessSet ShelleyTransactionWitnessSet
 	Metadata   cbor.Value
 }
 
+func (t *AllegraTransaction) UnmarshalCBOR(cborData []byte) error {
+	return t.UnmarshalCborGeneric(cborData, t)
+}
+
 func NewAllegraBlockFromCbor(data []byte) (*AllegraBlock, error) {
 	var allegraBlock AllegraBlock
 	if _, err := cbor.Decode(data, &allegraBlock); err != nil {
@@ -30,7 +30,7 @@ func (b *AlonzoBlock) UnmarshalCBOR(cborData []byte) error {
 	return b.UnmarshalCborGeneric(cborData, b)
 }
 
-func (b *AlonzoBlock) Hash() string {
+func (b *AlonzoBlock) Hash() Blake2b256 {
 	return b.Header.Hash()
 }
 
@@ -48,8 +48,8 @@ func (b *AlonzoBlock) Era() Era {
 
 func (b *AlonzoBlock) Transactions() []TransactionBody {
 	ret := []TransactionBody{}
-	for _, v := range b.TransactionBodies {
-		ret = append(ret, &v)
+	for idx := range b.TransactionBodies {
+		ret = append(ret, &b.TransactionBodies[idx])
 	}
 	return ret
 }
@@ -78,17 +78,55 @@ type AlonzoTransactionWitnessSet struct {
 	ShelleyTransactionWitnessSet
 	PlutusScripts interface{}  `cbor:"3,keyasint,omitempty"`
 	PlutusData    []cbor.Value `cbor:"4,keyasint,omitempty"`
-	Redeemers     []cbor.Value `cbor:"5,keyasint,omitempty"`
+	Redeemers     []Redeemer   `cbor:"5,keyasint,omitempty"`
+}
+
+const (
+	REDEEMER_TAG_SPEND     = 0
+	REDEEMER_TAG_MINT      = 1
+	REDEEMER_TAG_CERT      = 2
+	REDEEMER_TAG_REWARD    = 3
+	REDEEMER_TAG_VOTING    = 4
+	REDEEMER_TAG_PROPOSING = 5
+)
+
+// Redeemer represents the data and execution units supplied for a Plutus script. The tag and
+// index identify the script purpose, such as the index of the input being spent
+type Redeemer struct {
+	cbor.StructAsArray
+	Tag     uint8
+	Index   uint32
+	Data    cbor.RawMessage
+	ExUnits ExUnits
+}
+
+// ExUnits represents the execution units for a Plutus script
+type ExUnits struct {
+	cbor.StructAsArray
+	Memory uint64
+	Steps  uint64
+}
+
+// ExUnitPrices represents the prices for execution units
+type ExUnitPrices struct {
+	cbor.StructAsArray
+	MemPrice  UnitInterval
+	StepPrice UnitInterval
 }
 
 type AlonzoTransaction struct {
 	cbor.StructAsArray
+	cbor.DecodeStoreCbor
 	Body       AlonzoTransactionBody
 	WitnessSet AlonzoTransactionWitnessSet
 	IsValid    bool
 	Metadata   cbor.Value
 }
 
+func (t *AlonzoTransaction) UnmarshalCBOR(cborData []byte) error {
+	return t.UnmarshalCborGeneric(cborData, t)
+}
+
 func NewAlonzoBlockFromCbor(data []byte) (*AlonzoBlock, error) {
 	var alonzoBlock AlonzoBlock
 	if _, err := cbor.Decode(data, &alonzoBlock); err != nil {
@@ -1,6 +1,8 @@
 package ledger
 
 import (
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
 
 	"github.com/cloudstruct/go-cardano-ledger/cbor"
@@ -21,7 +23,7 @@ type BabbageBlock struct {
 	cbor.DecodeStoreCbor
 	Header                 *BabbageBlockHeader
 	TransactionBodies      []BabbageTransactionBody
-	TransactionWitnessSets []AlonzoTransactionWitnessSet
+	TransactionWitnessSets []BabbageTransactionWitnessSet
 	TransactionMetadataSet map[uint]cbor.Value
 	InvalidTransactions    []uint
 }
@@ -30,7 +32,7 @@ func (b *BabbageBlock) UnmarshalCBOR(cborData []byte) error {
 	return b.UnmarshalCborGeneric(cborData, b)
 }
 
-func (b *BabbageBlock) Hash() string {
+func (b *BabbageBlock) Hash() Blake2b256 {
 	return b.Header.Hash()
 }
 
@@ -48,8 +50,8 @@ func (b *BabbageBlock) Era() Era {
 
 func (b *BabbageBlock) Transactions() []TransactionBody {
 	ret := []TransactionBody{}
-	for _, v := range b.TransactionBodies {
-		ret = append(ret, &v)
+	for idx := range b.TransactionBodies {
+		ret = append(ret, &b.TransactionBodies[idx])
 	}
 	return ret
 }
@@ -57,7 +59,7 @@ func (b *BabbageBlock) Transactions() []TransactionBody {
 type BabbageBlockHeader struct {
 	cbor.StructAsArray
 	cbor.DecodeStoreCbor
-	hash string
+	hash *Blake2b256
 	Body struct {
 		cbor.StructAsArray
 		BlockNumber   uint64
@@ -88,11 +90,12 @@ func (h *BabbageBlockHeader) UnmarshalCBOR(cborData []byte) error {
 	return h.UnmarshalCborGeneric(cborData, h)
 }
 
-func (h *BabbageBlockHeader) Hash() string {
-	if h.hash == "" {
-		h.hash = generateBlockHeaderHash(h.Cbor(), nil)
+func (h *BabbageBlockHeader) Hash() Blake2b256 {
+	if h.hash == nil {
+		tmpHash := generateBlockHeaderHash(h.Cbor(), nil)
+		h.hash = &tmpHash
 	}
-	return h.hash
+	return *h.hash
 }
 
 func (h *BabbageBlockHeader) BlockNumber() uint64 {
@@ -109,23 +112,269 @@ func (h *BabbageBlockHeader) Era() Era {
 
 type BabbageTransactionBody struct {
 	AlonzoTransactionBody
-	CollateralReturn ShelleyTransactionOutput  `cbor:"16,keyasint,omitempty"`
-	TotalCollateral  uint64                    `cbor:"17,keyasint,omitempty"`
-	ReferenceInputs  []ShelleyTransactionInput `cbor:"18,keyasint,omitempty"`
+	Outputs          []BabbageTransactionOutput `cbor:"1,keyasint,omitempty"`
+	CollateralReturn *BabbageTransactionOutput  `cbor:"16,keyasint,omitempty"`
+	TotalCollateral  uint64                     `cbor:"17,keyasint,omitempty"`
+	ReferenceInputs  []ShelleyTransactionInput  `cbor:"18,keyasint,omitempty"`
 }
 
 func (b *BabbageTransactionBody) UnmarshalCBOR(cborData []byte) error {
 	return b.UnmarshalCborGeneric(cborData, b)
 }
 
+// MarshalCBOR returns the original CBOR for a decoded transaction body, or otherwise encodes the
+// populated fields of the transaction body
+func (b *BabbageTransactionBody) MarshalCBOR() ([]byte, error) {
+	if b.Cbor() != nil {
+		return b.Cbor(), nil
+	}
+	return cbor.Encode(b.cborMap())
+}
+
+// Helper function to build the map of populated transaction body fields for encoding. Optional
+// fields are only included when set
+func (b *BabbageTransactionBody) cborMap() map[uint]interface{} {
+	inputs := b.Inputs
+	if inputs == nil {
+		inputs = []ShelleyTransactionInput{}
+	}
+	outputs := b.Outputs
+	if outputs == nil {
+		outputs = []BabbageTransactionOutput{}
+	}
+	ret := map[uint]interface{}{
+		0: inputs,
+		1: outputs,
+		2: b.Fee,
+	}
+	if b.Ttl > 0 {
+		ret[3] = b.Ttl
+	}
+	if len(b.Certificates) > 0 {
+		ret[4] = b.Certificates
+	}
+	if len(b.Withdrawals) > 0 {
+		ret[5] = b.Withdrawals
+	}
+	if b.Update.ProtocolParamUpdates.Value != nil {
+		ret[6] = b.Update
+	}
+	if b.MetadataHash != (Blake2b256{}) {
+		ret[7] = b.MetadataHash
+	}
+	if b.ValidityIntervalStart > 0 {
+		ret[8] = b.ValidityIntervalStart
+	}
+	if len(b.Mint) > 0 {
+		ret[9] = b.Mint
+	}
+	if b.ScriptDataHash != (Blake2b256{}) {
+		ret[11] = b.ScriptDataHash
+	}
+	if len(b.Collateral) > 0 {
+		ret[13] = b.Collateral
+	}
+	if len(b.RequiredSigners) > 0 {
+		ret[14] = b.RequiredSigners
+	}
+	if b.NetworkId > 0 {
+		ret[15] = b.NetworkId
+	}
+	if b.CollateralReturn != nil {
+		ret[16] = b.CollateralReturn
+	}
+	if b.TotalCollateral > 0 {
+		ret[17] = b.TotalCollateral
+	}
+	if len(b.ReferenceInputs) > 0 {
+		ret[18] = b.ReferenceInputs
+	}
+	return ret
+}
+
+const (
+	BABBAGE_DATUM_OPTION_TYPE_HASH   = 0
+	BABBAGE_DATUM_OPTION_TYPE_INLINE = 1
+)
+
+// BabbageTransactionOutput represents a transaction output in any Shelley-based era. Outputs
+// are decoded from both the legacy array format and the map format introduced in Babbage
+type BabbageTransactionOutput struct {
+	cbor.DecodeStoreCbor
+	Address   Address
+	Amount    MaryValue
+	DatumHash *Blake2b256
+	// The inline datum, as raw CBOR
+	Datum *cbor.WrappedCbor
+	// The reference script, as raw CBOR
+	ScriptRef *cbor.WrappedCbor
+}
+
+func (o *BabbageTransactionOutput) UnmarshalCBOR(cborData []byte) error {
+	if len(cborData) > 0 && (cborData[0]&cbor.CBOR_TYPE_MASK) == cbor.CBOR_TYPE_ARRAY {
+		// Legacy format: [address, amount, ? datum_hash]
+		var tmpData []cbor.RawMessage
+		if _, err := cbor.Decode(cborData, &tmpData); err != nil {
+			return err
+		}
+		if len(tmpData) < 2 || len(tmpData) > 3 {
+			return fmt.Errorf("invalid transaction output length: %d", len(tmpData))
+		}
+		if _, err := cbor.Decode(tmpData[0], &o.Address); err != nil {
+			return err
+		}
+		if _, err := cbor.Decode(tmpData[1], &o.Amount); err != nil {
+			return err
+		}
+		if len(tmpData) == 3 {
+			var tmpDatumHash Blake2b256
+			if _, err := cbor.Decode(tmpData[2], &tmpDatumHash); err != nil {
+				return err
+			}
+			o.DatumHash = &tmpDatumHash
+		}
+	} else {
+		var tmpData struct {
+			Address     Address           `cbor:"0,keyasint"`
+			Amount      MaryValue         `cbor:"1,keyasint"`
+			DatumOption *cbor.RawMessage  `cbor:"2,keyasint,omitempty"`
+			ScriptRef   *cbor.WrappedCbor `cbor:"3,keyasint,omitempty"`
+		}
+		if _, err := cbor.Decode(cborData, &tmpData); err != nil {
+			return err
+		}
+		o.Address = tmpData.Address
+		o.Amount = tmpData.Amount
+		o.ScriptRef = tmpData.ScriptRef
+		if tmpData.DatumOption != nil {
+			var datumOption struct {
+				cbor.StructAsArray
+				Type  uint
+				Value cbor.RawMessage
+			}
+			if _, err := cbor.Decode(*tmpData.DatumOption, &datumOption); err != nil {
+				return err
+			}
+			switch datumOption.Type {
+			case BABBAGE_DATUM_OPTION_TYPE_HASH:
+				var tmpDatumHash Blake2b256
+				if _, err := cbor.Decode(datumOption.Value, &tmpDatumHash); err != nil {
+					return err
+				}
+				o.DatumHash = &tmpDatumHash
+			case BABBAGE_DATUM_OPTION_TYPE_INLINE:
+				var tmpDatum cbor.WrappedCbor
+				if _, err := cbor.Decode(datumOption.Value, &tmpDatum); err != nil {
+					return err
+				}
+				o.Datum = &tmpDatum
+			default:
+				return fmt.Errorf("unknown datum option type: %d", datumOption.Type)
+			}
+		}
+	}
+	o.SetCbor(cborData)
+	return nil
+}
+
+// MarshalCBOR returns the original CBOR for a decoded output, or otherwise the map format encoding
+func (o BabbageTransactionOutput) MarshalCBOR() ([]byte, error) {
+	if o.Cbor() != nil {
+		return o.Cbor(), nil
+	}
+	tmpData := map[uint]interface{}{
+		0: o.Address,
+		1: o.Amount,
+	}
+	if o.DatumHash != nil {
+		tmpData[2] = []interface{}{BABBAGE_DATUM_OPTION_TYPE_HASH, o.DatumHash[:]}
+	} else if o.Datum != nil {
+		tmpData[2] = []interface{}{BABBAGE_DATUM_OPTION_TYPE_INLINE, *o.Datum}
+	}
+	if o.ScriptRef != nil {
+		tmpData[3] = *o.ScriptRef
+	}
+	return cbor.Encode(tmpData)
+}
+
+func (o BabbageTransactionOutput) MarshalJSON() ([]byte, error) {
+	ret := map[string]interface{}{
+		"address": o.Address,
+		"value":   o.Amount,
+	}
+	if o.DatumHash != nil {
+		ret["datumHash"] = o.DatumHash.String()
+	}
+	if o.Datum != nil {
+		ret["inlineDatum"] = hex.EncodeToString(o.Datum.Bytes())
+	}
+	if o.ScriptRef != nil {
+		ret["referenceScript"] = hex.EncodeToString(o.ScriptRef.Bytes())
+	}
+	return json.Marshal(ret)
+}
+
+func (o BabbageTransactionOutput) String() string {
+	ret := fmt.Sprintf("TxOut (%s) (%s)", o.Address.String(), o.Amount.String())
+	if o.DatumHash != nil {
+		ret = fmt.Sprintf("%s (DatumHash %s)", ret, o.DatumHash.String())
+	} else if o.Datum != nil {
+		ret = fmt.Sprintf("%s (Datum %x)", ret, o.Datum.Bytes())
+	}
+	if o.ScriptRef != nil {
+		ret = fmt.Sprintf("%s (ScriptRef %x)", ret, o.ScriptRef.Bytes())
+	}
+	return ret
+}
+
+type BabbageTransactionWitnessSet struct {
+	AlonzoTransactionWitnessSet
+	PlutusV2Scripts [][]byte `cbor:"6,keyasint,omitempty"`
+}
+
 type BabbageTransaction struct {
 	cbor.StructAsArray
+	cbor.DecodeStoreCbor
 	Body       BabbageTransactionBody
-	WitnessSet AlonzoTransactionWitnessSet
+	WitnessSet BabbageTransactionWitnessSet
 	IsValid    bool
 	Metadata   cbor.Value
 }
 
+func (t *BabbageTransaction) UnmarshalCBOR(cborData []byte) error {
+	return t.UnmarshalCborGeneric(cborData, t)
+}
+
+func (t *BabbageTransaction) MarshalCBOR() ([]byte, error) {
+	// Use the original CBOR for decoded transactions, so that they are re-encoded as-is
+	if cborData := t.Cbor(); cborData != nil {
+		return cborData, nil
+	}
+	return cbor.Encode([]interface{}{&t.Body, &t.WitnessSet, t.IsValid, t.Metadata})
+}
+
+// BabbageProtocolParameters contains the protocol parameters needed to build transactions
+type BabbageProtocolParameters struct {
+	MinFeeA              uint64
+	MinFeeB              uint64
+	MaxTxSize            uint32
+	KeyDeposit           uint64
+	PoolDeposit          uint64
+	AdaPerUtxoByte       uint64
+	MaxValueSize         uint32
+	CollateralPercentage uint32
+	MaxCollateralInputs  uint32
+	ExecutionCosts       ExUnitPrices
+	MaxTxExUnits         ExUnits
+	// MinUtxoValue is only used from Shelley through Mary, and CoinsPerUtxoWord is only used in
+	// Alonzo. They should be left empty for later eras
+	MinUtxoValue     uint64
+	CoinsPerUtxoWord uint64
+	// MinFeeRefScriptCostPerByte is only used from Conway onward, and should be left empty for
+	// earlier eras
+	MinFeeRefScriptCostPerByte UnitInterval
+}
+
 func NewBabbageBlockFromCbor(data []byte) (*BabbageBlock, error) {
 	var babbageBlock BabbageBlock
 	if _, err := cbor.Decode(data, &babbageBlock); err != nil {
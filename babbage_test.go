@@ -0,0 +1,84 @@
+package ledger_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/cloudstruct/go-cardano-ledger"
+	"github.com/cloudstruct/go-cardano-ledger/cbor"
+)
+
+// Mainnet Babbage transaction 5d212dcf471aa03d0b0ca800df01d90f17fe163ab2a50fcea4a13162cb8b6600, which
+// spends a script output with a redeemer and datum
+const babbageMainnetTxHex = "84a900828258205477eec55b285bda9ce39cfaa9517143b3b332c6890db73d1b9df2f06956387c008258205477eec55b285bda9ce39cfaa9517143b3b332c6890db73d1b9df2f06956387c01018282583901ed95fed4f4ea2b013914d78f0f4748387f7a196644a7b42d99a7d0e9a5af014bfee1d5518e209e99179137d8ed75838aa83e50d5985900fe1a03473bc082583901ed95fed4f4ea2b013914d78f0f4748387f7a196644a7b42d99a7d0e9a5af014bfee1d5518e209e99179137d8ed75838aa83e50d5985900fe1a04f1c21a021a0003f9a9031a048ad587081a048acafb0b5820abaee9e437b93b02f9f1db924f5f49e8f7d6e50ee3ec747947defcc2d0955aaa0d8182582059f121845027638f775d5e154cd1cb1f99c49037765f2b070a342f1a7e2de056000e81581ced95fed4f4ea2b013914d78f0f4748387f7a196644a7b42d99a7d0e912818258209a32459bd4ef6bbafdeb8cf3b909d0e3e2ec806e4cc6268529280b0fc1d06f5b00a3008182582089a11758ef06dc94ba31c6c3f48983caaadabdc63099f0d984ceb71930a6b1e7584038dd182cb56158f65f55d2b346dfec22dbd104dd23eff0235a4f40cd2e19575179fc7bdd0d834a5f20394a68c433a27699e2be5b3a6f771d5b58ac92db73fd04049fd8799f581ced95fed4f4ea2b013914d78f0f4748387f7a196644a7b42d99a7d0e99fd8799fd8799fd8799f581c881614f4fa425081c473f18b5054ce9246575f82fdfb6472fd3bf98bffd8799fd8799fd8799f581c722578fdf29d210c4b7a172ec49e06950de9ab26cc954e7660db14a7ffffffffa140d8799f00a1401a00233f70ffffd8799fd8799fd8799f581c70e60f3b5ea7153e0acc7a803e4401d44b8ed1bae1c7baaad1a62a72ffd8799fd8799fd8799f581c1e78aae7c90cc36d624f7b3bb6d86b52696dc84e490f343eba89005fffffffffa140d8799f00a1401a0010c8e0ffffd8799fd8799fd8799f581ced95fed4f4ea2b013914d78f0f4748387f7a196644a7b42d99a7d0e9ffd8799fd8799fd8799f581ca5af014bfee1d5518e209e99179137d8ed75838aa83e50d5985900feffffffffa1581ceaa972045049185981aca9f4aaad38bc307776c593e4a849d3802a87d8799f00a14e536d6f6f7468596574693331323601ffffffffff0581840000d87980821a000c83921a10270770f5f6"
+
+// Plutus V2 script that always succeeds
+var testPlutusV2Script = []byte{0x4e, 0x4d, 0x01, 0x00, 0x00, 0x33, 0x22, 0x22, 0x20, 0x05, 0x12, 0x00, 0x12, 0x00, 0x11}
+
+func TestBabbageTransactionRoundTrip(t *testing.T) {
+	txCbor, err := hex.DecodeString(babbageMainnetTxHex)
+	if err != nil {
+		t.Fatalf("failed to decode CBOR hex: %s", err)
+	}
+	tx, err := ledger.NewBabbageTransactionFromCbor(txCbor)
+	if err != nil {
+		t.Fatalf("failed to decode transaction: %s", err)
+	}
+	if tx.Body.Hash() != "5d212dcf471aa03d0b0ca800df01d90f17fe163ab2a50fcea4a13162cb8b6600" {
+		t.Fatalf("did not get expected transaction hash, got: %s", tx.Body.Hash())
+	}
+	if len(tx.WitnessSet.Redeemers) != 1 || len(tx.WitnessSet.PlutusData) != 1 {
+		t.Fatalf("did not get expected redeemers and datums: %#v", tx.WitnessSet)
+	}
+	// The transaction is re-encoded exactly as it was decoded
+	encoded, err := cbor.Encode(tx)
+	if err != nil {
+		t.Fatalf("failed to encode transaction: %s", err)
+	}
+	if !bytes.Equal(encoded, txCbor) {
+		t.Fatalf("did not get original CBOR\n  got:    %x\n  wanted: %x", encoded, txCbor)
+	}
+}
+
+func TestBabbageTransactionPlutusV2Scripts(t *testing.T) {
+	addr := testAddress(t, "addr1vx2fxv2umyhttkxyxp8x0dlpdt3k6cwng5pxj3jhsydzers66hrl8")
+	utxo := testUtxo(addr, 0x01, 0, ledger.MaryValue{Coin: 10000000})
+	tmpTx := ledger.BabbageTransaction{IsValid: true}
+	tmpTx.Body.Inputs = []ledger.ShelleyTransactionInput{utxo.Input}
+	tmpTx.Body.Outputs = []ledger.BabbageTransactionOutput{{Address: addr, Amount: ledger.MaryValue{Coin: 9000000}}}
+	tmpTx.Body.Fee = 1000000
+	tmpTx.WitnessSet.PlutusV2Scripts = [][]byte{testPlutusV2Script}
+	tmpTx.WitnessSet.Redeemers = []ledger.Redeemer{
+		{
+			Tag:     ledger.REDEEMER_TAG_SPEND,
+			Index:   0,
+			Data:    cbor.RawMessage{0x00},
+			ExUnits: ledger.ExUnits{Memory: 1000000, Steps: 500000000},
+		},
+	}
+	txCbor, err := cbor.Encode(&tmpTx)
+	if err != nil {
+		t.Fatalf("failed to encode transaction: %s", err)
+	}
+	tx, err := ledger.NewBabbageTransactionFromCbor(txCbor)
+	if err != nil {
+		t.Fatalf("failed to decode transaction: %s", err)
+	}
+	if len(tx.WitnessSet.PlutusV2Scripts) != 1 || !bytes.Equal(tx.WitnessSet.PlutusV2Scripts[0], testPlutusV2Script) {
+		t.Fatalf("did not get expected Plutus V2 scripts: %x", tx.WitnessSet.PlutusV2Scripts)
+	}
+	// The scripts are in the witness set under key 6
+	var txItems []cbor.RawMessage
+	if _, err := cbor.Decode(txCbor, &txItems); err != nil {
+		t.Fatalf("failed to decode transaction: %s", err)
+	}
+	var witnessSet map[uint64]cbor.RawMessage
+	if _, err := cbor.Decode(txItems[1], &witnessSet); err != nil {
+		t.Fatalf("failed to decode witness set: %s", err)
+	}
+	var scripts [][]byte
+	if _, err := cbor.Decode(witnessSet[6], &scripts); err != nil || len(scripts) != 1 {
+		t.Fatalf("did not find Plutus V2 scripts in witness set: %x (%v)", witnessSet[6], err)
+	}
+}
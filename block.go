@@ -13,7 +13,7 @@ type Block interface {
 }
 
 type BlockHeader interface {
-	Hash() string
+	Hash() Blake2b256
 	BlockNumber() uint64
 	SlotNumber() uint64
 	Era() Era
@@ -32,6 +32,17 @@ func (b Blake2b224) String() string {
 	return hex.EncodeToString([]byte(b[:]))
 }
 
+// NewBlake2b224Hash returns the Blake2b-224 hash of the data, as used for key and script hashes
+func NewBlake2b224Hash(data []byte) Blake2b224 {
+	// We can ignore the error return here because our fixed size/key arguments will
+	// never trigger an error
+	tmpHash, _ := blake2b.New(28, nil)
+	tmpHash.Write(data)
+	var ret Blake2b224
+	copy(ret[:], tmpHash.Sum(nil))
+	return ret
+}
+
 func NewBlockFromCbor(blockType uint, data []byte) (Block, error) {
 	switch blockType {
 	case BLOCK_TYPE_BYRON_EBB:
@@ -48,6 +59,8 @@ func NewBlockFromCbor(blockType uint, data []byte) (Block, error) {
 		return NewAlonzoBlockFromCbor(data)
 	case BLOCK_TYPE_BABBAGE:
 		return NewBabbageBlockFromCbor(data)
+	case BLOCK_TYPE_CONWAY:
+		return NewConwayBlockFromCbor(data)
 	}
 	return nil, fmt.Errorf("unknown node-to-client block type: %d", blockType)
 }
@@ -64,11 +77,13 @@ func NewBlockHeaderFromCbor(blockType uint, data []byte) (BlockHeader, error) {
 		return NewShelleyBlockHeaderFromCbor(data)
 	case BLOCK_TYPE_BABBAGE:
 		return NewBabbageBlockHeaderFromCbor(data)
+	case BLOCK_TYPE_CONWAY:
+		return NewConwayBlockHeaderFromCbor(data)
 	}
 	return nil, fmt.Errorf("unknown node-to-node block type: %d", blockType)
 }
 
-func generateBlockHeaderHash(data []byte, prefix []byte) string {
+func generateBlockHeaderHash(data []byte, prefix []byte) Blake2b256 {
 	// We can ignore the error return here because our fixed size/key arguments will
 	// never trigger an error
 	tmpHash, _ := blake2b.New256(nil)
@@ -76,5 +91,7 @@ func generateBlockHeaderHash(data []byte, prefix []byte) string {
 		tmpHash.Write(prefix)
 	}
 	tmpHash.Write(data)
-	return hex.EncodeToString(tmpHash.Sum(nil))
+	var ret Blake2b256
+	copy(ret[:], tmpHash.Sum(nil))
+	return ret
 }
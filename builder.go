@@ -0,0 +1,547 @@
+package ledger
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+
+	"github.com/cloudstruct/go-cardano-ledger/cbor"
+	"golang.org/x/crypto/blake2b"
+)
+
+const (
+	// Maximum number of fee calculation rounds when building a transaction
+	txBuilderMaxIterations = 100
+
+	vkeyWitnessKeySize       = 32
+	vkeyWitnessSignatureSize = 64
+	bootstrapChainCodeSize   = 32
+)
+
+// UnspentOutput is a transaction output along with the input that references it
+type UnspentOutput struct {
+	Input  ShelleyTransactionInput
+	Output BabbageTransactionOutput
+}
+
+// TxBuilder builds balanced Babbage-era transactions. Inputs are selected from the available UTxOs
+// as needed to cover the outputs, fee and deposits, and any remaining value is sent to the change
+// address. The resulting transaction contains no signatures
+type TxBuilder struct {
+	protocolParams     BabbageProtocolParameters
+	availableUtxos     []UnspentOutput
+	inputs             []UnspentOutput
+	outputs            []BabbageTransactionOutput
+	changeAddress      *Address
+	certificates       []Certificate
+	withdrawals        map[cbor.ByteString]uint64
+	mint               MintAssets
+	nativeScripts      []cbor.RawMessage
+	metadata           map[uint64]interface{}
+	ttl                uint64
+	validityStart      uint64
+	extraWitnessCount  int
+	coinSelector       CoinSelector
+	maxInputs          int
+	buildErrors        []error
+	withdrawalAccounts []Address
+}
+
+// NewTxBuilder returns a TxBuilder using the specified protocol parameters
+func NewTxBuilder(protocolParams BabbageProtocolParameters) *TxBuilder {
+	return &TxBuilder{
+		protocolParams: protocolParams,
+		withdrawals:    map[cbor.ByteString]uint64{},
+	}
+}
+
+// AddUtxos adds UTxOs which are available to be selected as inputs
+func (b *TxBuilder) AddUtxos(utxos ...UnspentOutput) *TxBuilder {
+	b.availableUtxos = append(b.availableUtxos, utxos...)
+	return b
+}
+
+// AddInputs adds UTxOs which are always spent by the transaction
+func (b *TxBuilder) AddInputs(utxos ...UnspentOutput) *TxBuilder {
+	b.inputs = append(b.inputs, utxos...)
+	return b
+}
+
+// AddOutputs adds transaction outputs. Outputs with no lovelace are given the minimum amount of
+// lovelace required by the protocol
+func (b *TxBuilder) AddOutputs(outputs ...BabbageTransactionOutput) *TxBuilder {
+	b.outputs = append(b.outputs, outputs...)
+	return b
+}
+
+// SetChangeAddress sets the address that receives any value not sent to the outputs
+func (b *TxBuilder) SetChangeAddress(addr Address) *TxBuilder {
+	b.changeAddress = &addr
+	return b
+}
+
+// AddCertificates adds certificates to the transaction. Deposits for stake key and pool
+// registrations are included when balancing the transaction, and refunds for stake key
+// deregistrations are added to the change
+func (b *TxBuilder) AddCertificates(certs ...Certificate) *TxBuilder {
+	b.certificates = append(b.certificates, certs...)
+	return b
+}
+
+// AddWithdrawal adds a withdrawal of the specified amount from a reward account
+func (b *TxBuilder) AddWithdrawal(rewardAddr Address, amount uint64) *TxBuilder {
+	switch rewardAddr.Type() {
+	case ADDRESS_TYPE_NONE_KEY, ADDRESS_TYPE_NONE_SCRIPT:
+	default:
+		b.buildErrors = append(b.buildErrors, fmt.Errorf("withdrawal address is not a reward address: %s", rewardAddr.String()))
+		return b
+	}
+	b.withdrawals[cbor.NewByteString(rewardAddr.Bytes())] = amount
+	b.withdrawalAccounts = append(b.withdrawalAccounts, rewardAddr)
+	return b
+}
+
+// SetMint sets the native assets to mint (positive quantity) or burn (negative quantity). The
+// corresponding minting policy scripts must also be provided
+func (b *TxBuilder) SetMint(mint MintAssets) *TxBuilder {
+	b.mint = mint
+	return b
+}
+
+// AddNativeScripts adds native scripts, as raw CBOR, to the transaction witness set
+func (b *TxBuilder) AddNativeScripts(scripts ...[]byte) *TxBuilder {
+	for _, script := range scripts {
+		b.nativeScripts = append(b.nativeScripts, cbor.RawMessage(script))
+	}
+	return b
+}
+
+// SetMetadata sets the transaction metadata, keyed by metadata label
+func (b *TxBuilder) SetMetadata(metadata map[uint64]interface{}) *TxBuilder {
+	b.metadata = metadata
+	return b
+}
+
+// SetTtl sets the slot at which the transaction expires
+func (b *TxBuilder) SetTtl(slot uint64) *TxBuilder {
+	b.ttl = slot
+	return b
+}
+
+// SetValidityStart sets the first slot at which the transaction is valid
+func (b *TxBuilder) SetValidityStart(slot uint64) *TxBuilder {
+	b.validityStart = slot
+	return b
+}
+
+// SetCoinSelector sets the strategy used to select inputs from the available UTxOs. The
+// LargestFirstCoinSelector is used by default
+func (b *TxBuilder) SetCoinSelector(selector CoinSelector) *TxBuilder {
+	b.coinSelector = selector
+	return b
+}
+
+// SetMaxInputs sets the maximum number of inputs for the transaction, including those added
+// with AddInputs
+func (b *TxBuilder) SetMaxInputs(maxInputs int) *TxBuilder {
+	b.maxInputs = maxInputs
+	return b
+}
+
+// SetExtraWitnessCount sets the number of signatures required beyond those for the inputs and
+// withdrawals, such as for native scripts or certificates, so that they are included in the fee
+func (b *TxBuilder) SetExtraWitnessCount(count int) *TxBuilder {
+	b.extraWitnessCount = count
+	return b
+}
+
+// Build selects inputs, calculates the fee and change, and returns the resulting unsigned
+// transaction
+func (b *TxBuilder) Build() (*BabbageTransaction, error) {
+	if len(b.buildErrors) > 0 {
+		return nil, b.buildErrors[0]
+	}
+	if b.changeAddress == nil {
+		return nil, fmt.Errorf("no change address specified")
+	}
+	// Populate the minimum lovelace for outputs that don't specify an amount
+	outputs := make([]BabbageTransactionOutput, 0, len(b.outputs))
+	for idx, output := range b.outputs {
+		minCoin, err := MinUTxO(output, b.protocolParams)
+		if err != nil {
+			return nil, err
+		}
+		if output.Amount.Coin == 0 {
+			output.Amount.Coin = minCoin
+		} else if output.Amount.Coin < minCoin {
+			return nil, fmt.Errorf("output %d contains %d lovelace, but requires at least %d lovelace", idx, output.Amount.Coin, minCoin)
+		}
+		outputs = append(outputs, output)
+	}
+	deposit, refund := certificateDeposits(b.certificates, b.protocolParams)
+	// Determine the fixed value consumed and produced by the transaction, not including inputs
+	// and fee
+	var produced MaryValue
+	produced.Coin = deposit
+	for _, output := range outputs {
+		produced = valueAdd(produced, output.Amount)
+	}
+	var consumed MaryValue
+	consumed.Coin = refund
+	for _, amount := range b.withdrawals {
+		consumed.Coin += amount
+	}
+	minted, burned := splitMint(b.mint)
+	consumed = valueAdd(consumed, MaryValue{Assets: minted})
+	produced = valueAdd(produced, MaryValue{Assets: burned})
+	// Determine the value provided by the inputs that are always spent
+	fixedAvailable := consumed
+	for _, utxo := range b.inputs {
+		fixedAvailable = valueAdd(fixedAvailable, utxo.Output.Amount)
+	}
+	remaining := b.remainingUtxos()
+	selector := b.coinSelector
+	if selector == nil {
+		selector = &LargestFirstCoinSelector{}
+	}
+	maxInputs := 0
+	if b.maxInputs > 0 {
+		maxInputs = b.maxInputs - len(b.inputs)
+		if maxInputs <= 0 {
+			// Prevent selecting any additional inputs
+			remaining = nil
+		}
+	}
+	var fee uint64
+	// Extra lovelace to select to cover the minimum lovelace for the change output
+	var changeCushion uint64
+	noChangeCushion := false
+	for feeRounds := 0; feeRounds < txBuilderMaxIterations; {
+		required := produced
+		required.Coin += fee
+		// Select inputs to cover any value not provided by the fixed inputs
+		target := MaryValue{
+			Assets: valueMissing(fixedAvailable, required),
+		}
+		if required.Coin+changeCushion > fixedAvailable.Coin {
+			target.Coin = required.Coin + changeCushion - fixedAvailable.Coin
+		}
+		selected := make([]UnspentOutput, len(b.inputs))
+		copy(selected, b.inputs)
+		// The ledger rejects transactions without inputs, so always select at least one input,
+		// even when withdrawals or refunds already cover the outputs and fee
+		if len(selected) == 0 && target.Coin == 0 && len(target.Assets) == 0 {
+			target.Coin = 1
+		}
+		available := fixedAvailable
+		if target.Coin > 0 || len(target.Assets) > 0 {
+			var tmpSelected []UnspentOutput
+			var err error
+			if len(remaining) == 0 {
+				err = ErrInsufficientFunds
+			} else {
+				tmpSelected, err = selector.Select(remaining, target, maxInputs)
+			}
+			if err != nil {
+				// Try again without covering the minimum lovelace for the change
+				if changeCushion > 0 {
+					changeCushion = 0
+					noChangeCushion = true
+					continue
+				}
+				return nil, fmt.Errorf("failed to select inputs: available (%s), required (%s): %w", fixedAvailable.String(), required.String(), err)
+			}
+			for _, utxo := range tmpSelected {
+				selected = append(selected, utxo)
+				available = valueAdd(available, utxo.Output.Amount)
+			}
+		}
+		change := valueSubtract(available, required)
+		// Add change output if there is leftover value
+		txFee := fee
+		txOutputs := outputs
+		if change.Coin > 0 || len(change.Assets) > 0 {
+			changeOutput := BabbageTransactionOutput{
+				Address: *b.changeAddress,
+				Amount:  change,
+			}
+			minCoin, err := MinUTxO(changeOutput, b.protocolParams)
+			if err != nil {
+				return nil, err
+			}
+			if change.Coin < minCoin {
+				// Select more inputs to cover the minimum lovelace for the change, if possible
+				if !noChangeCushion && changeCushion < minCoin {
+					changeCushion = minCoin
+					continue
+				}
+				if len(change.Assets) > 0 {
+					return nil, fmt.Errorf("insufficient funds: change output contains %d lovelace, but requires at least %d lovelace", change.Coin, minCoin)
+				}
+				// Add leftover lovelace to the fee when it is too small to create a change output
+				txFee += change.Coin
+			} else {
+				txOutputs = append(txOutputs[:len(txOutputs):len(txOutputs)], changeOutput)
+			}
+		}
+		tx, err := b.buildTransaction(selected, txOutputs, txFee)
+		if err != nil {
+			return nil, err
+		}
+		signedTx := b.addDummyWitnesses(tx, selected)
+		txSize, err := transactionSize(signedTx)
+		if err != nil {
+			return nil, err
+		}
+		if b.protocolParams.MaxTxSize > 0 && txSize > uint64(b.protocolParams.MaxTxSize) {
+			return nil, fmt.Errorf("transaction size %d exceeds maximum size %d", txSize, b.protocolParams.MaxTxSize)
+		}
+		minFee, err := CalculateMinFee(signedTx, b.protocolParams, selected...)
+		if err != nil {
+			return nil, err
+		}
+		if minFee <= txFee {
+			// Decode the final transaction from its CBOR to populate the original CBOR for the body
+			txCbor, err := cbor.Encode(tx)
+			if err != nil {
+				return nil, err
+			}
+			return NewBabbageTransactionFromCbor(txCbor)
+		}
+		fee = minFee
+		feeRounds++
+	}
+	return nil, fmt.Errorf("failed to balance transaction after %d iterations", txBuilderMaxIterations)
+}
+
+// Helper function to build the transaction from the selected inputs and outputs
+func (b *TxBuilder) buildTransaction(selected []UnspentOutput, outputs []BabbageTransactionOutput, fee uint64) (*BabbageTransaction, error) {
+	tx := &BabbageTransaction{
+		IsValid: true,
+	}
+	inputs := make([]ShelleyTransactionInput, 0, len(selected))
+	for _, utxo := range selected {
+		inputs = append(inputs, utxo.Input)
+	}
+	// Inputs are a set, which the ledger orders by transaction ID and index
+	sort.Slice(inputs, func(i, j int) bool {
+		if cmp := bytes.Compare(inputs[i].Id[:], inputs[j].Id[:]); cmp != 0 {
+			return cmp < 0
+		}
+		return inputs[i].Index < inputs[j].Index
+	})
+	tx.Body.Inputs = inputs
+	tx.Body.Outputs = outputs
+	tx.Body.Fee = fee
+	tx.Body.Ttl = b.ttl
+	tx.Body.ValidityIntervalStart = b.validityStart
+	for _, cert := range b.certificates {
+		tx.Body.Certificates = append(tx.Body.Certificates, CertificateWrapper{Certificate: cert})
+	}
+	if len(b.withdrawals) > 0 {
+		tx.Body.Withdrawals = b.withdrawals
+	}
+	if len(b.mint) > 0 {
+		tx.Body.Mint = b.mint
+	}
+	if b.metadata != nil {
+		tx.Metadata = cbor.Value{Value: b.metadata}
+		metadataCbor, err := cbor.Encode(b.metadata)
+		if err != nil {
+			return nil, err
+		}
+		tx.Body.MetadataHash = blake2b.Sum256(metadataCbor)
+	}
+	for _, script := range b.nativeScripts {
+		tx.WitnessSet.MultisigScripts = append(tx.WitnessSet.MultisigScripts, script)
+	}
+	return tx, nil
+}
+
+// Helper function to return a copy of the transaction with placeholder witnesses for each key that
+// needs to sign it, which is used to calculate the fee for the signed transaction
+func (b *TxBuilder) addDummyWitnesses(tx *BabbageTransaction, selected []UnspentOutput) *BabbageTransaction {
+	tmpTx := *tx
+	keyHashes := map[Blake2b224]bool{}
+	bootstrapCount := 0
+	for _, utxo := range selected {
+		if utxo.Output.Address.Type() == ADDRESS_TYPE_BYRON {
+			bootstrapCount++
+			continue
+		}
+		if cred := utxo.Output.Address.PaymentCredential(); cred != nil && cred.Type == CREDENTIAL_TYPE_ADDR_KEY_HASH {
+			keyHashes[cred.Hash] = true
+		}
+	}
+	for _, addr := range b.withdrawalAccounts {
+		if cred := addr.StakeCredential(); cred != nil && cred.Type == CREDENTIAL_TYPE_ADDR_KEY_HASH {
+			keyHashes[cred.Hash] = true
+		}
+	}
+	for _, cert := range b.certificates {
+		for _, keyHash := range certificateWitnessKeyHashes(cert) {
+			keyHashes[keyHash] = true
+		}
+	}
+	vkeyWitnesses := []VkeyWitness{}
+	for i := 0; i < len(keyHashes)+b.extraWitnessCount; i++ {
+		vkeyWitnesses = append(
+			vkeyWitnesses,
+			VkeyWitness{
+				Vkey:      make([]byte, vkeyWitnessKeySize),
+				Signature: make([]byte, vkeyWitnessSignatureSize),
+			},
+		)
+	}
+	tmpTx.WitnessSet.VkeyWitnesses = vkeyWitnesses
+	for i := 0; i < bootstrapCount; i++ {
+		tmpTx.WitnessSet.BootstrapWitnesses = append(
+			tmpTx.WitnessSet.BootstrapWitnesses,
+			[]interface{}{
+				make([]byte, vkeyWitnessKeySize),
+				make([]byte, vkeyWitnessSignatureSize),
+				make([]byte, bootstrapChainCodeSize),
+				// Empty address attributes
+				[]byte{0xa0},
+			},
+		)
+	}
+	return &tmpTx
+}
+
+// Helper function to calculate the deposits paid and refunded by certificates. Pool registrations
+// are assumed to be for new pools, since re-registering an existing pool does not require a deposit
+func certificateDeposits(certs []Certificate, params BabbageProtocolParameters) (uint64, uint64) {
+	var deposit, refund uint64
+	for _, cert := range certs {
+		switch c := cert.(type) {
+		case *StakeRegistrationCertificate:
+			deposit += params.KeyDeposit
+		case *StakeDeregistrationCertificate:
+			refund += params.KeyDeposit
+		case *RegistrationCertificate:
+			deposit += c.Amount
+		case *DeregistrationCertificate:
+			refund += c.Amount
+		case *StakeRegistrationDelegationCertificate:
+			deposit += c.Amount
+		case *VoteRegistrationDelegationCertificate:
+			deposit += c.Amount
+		case *StakeVoteRegistrationDelegationCertificate:
+			deposit += c.Amount
+		case *RegistrationDrepCertificate:
+			deposit += c.Amount
+		case *DeregistrationDrepCertificate:
+			refund += c.Amount
+		case *PoolRegistrationCertificate:
+			deposit += params.PoolDeposit
+		}
+	}
+	return deposit, refund
+}
+
+// Helper function to return the available UTxOs that have not already been added as inputs
+func (b *TxBuilder) remainingUtxos() []UnspentOutput {
+	used := map[ShelleyTransactionInput]bool{}
+	for _, utxo := range b.inputs {
+		used[utxo.Input] = true
+	}
+	ret := []UnspentOutput{}
+	for _, utxo := range b.availableUtxos {
+		if used[utxo.Input] {
+			continue
+		}
+		used[utxo.Input] = true
+		ret = append(ret, utxo)
+	}
+	return ret
+}
+
+// Helper function to split mint into the minted and burned assets
+func splitMint(mint MintAssets) (MultiAsset, MultiAsset) {
+	minted := MultiAsset{}
+	burned := MultiAsset{}
+	for policyId, assets := range mint {
+		for assetName, amount := range assets {
+			if amount > 0 {
+				if minted[policyId] == nil {
+					minted[policyId] = map[cbor.ByteString]uint64{}
+				}
+				minted[policyId][assetName] = uint64(amount)
+			} else if amount < 0 {
+				if burned[policyId] == nil {
+					burned[policyId] = map[cbor.ByteString]uint64{}
+				}
+				burned[policyId][assetName] = uint64(-amount)
+			}
+		}
+	}
+	return minted, burned
+}
+
+// Helper function to add two values
+func valueAdd(a MaryValue, b MaryValue) MaryValue {
+	ret := MaryValue{Coin: a.Coin + b.Coin}
+	for _, tmpValue := range []MaryValue{a, b} {
+		for policyId, assets := range tmpValue.Assets {
+			for assetName, amount := range assets {
+				if amount == 0 {
+					continue
+				}
+				if ret.Assets == nil {
+					ret.Assets = MultiAsset{}
+				}
+				if ret.Assets[policyId] == nil {
+					ret.Assets[policyId] = map[cbor.ByteString]uint64{}
+				}
+				ret.Assets[policyId][assetName] += amount
+			}
+		}
+	}
+	return ret
+}
+
+// Helper function to subtract one value from another. The first value must cover the second
+func valueSubtract(a MaryValue, b MaryValue) MaryValue {
+	ret := MaryValue{Coin: a.Coin - b.Coin}
+	for policyId, assets := range a.Assets {
+		for assetName, amount := range assets {
+			tmpAmount := amount - b.Assets[policyId][assetName]
+			if tmpAmount == 0 {
+				continue
+			}
+			if ret.Assets == nil {
+				ret.Assets = MultiAsset{}
+			}
+			if ret.Assets[policyId] == nil {
+				ret.Assets[policyId] = map[cbor.ByteString]uint64{}
+			}
+			ret.Assets[policyId][assetName] = tmpAmount
+		}
+	}
+	return ret
+}
+
+// Helper function to determine whether a value covers another value
+func valueCovers(a MaryValue, b MaryValue) bool {
+	if a.Coin < b.Coin {
+		return false
+	}
+	return len(valueMissing(a, b)) == 0
+}
+
+// Helper function to return the assets in the second value that are not covered by the first value
+func valueMissing(a MaryValue, b MaryValue) MultiAsset {
+	ret := MultiAsset{}
+	for policyId, assets := range b.Assets {
+		for assetName, amount := range assets {
+			if a.Assets[policyId][assetName] < amount {
+				if ret[policyId] == nil {
+					ret[policyId] = map[cbor.ByteString]uint64{}
+				}
+				ret[policyId][assetName] = amount - a.Assets[policyId][assetName]
+			}
+		}
+	}
+	return ret
+}
@@ -0,0 +1,260 @@
+package ledger_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/cloudstruct/go-cardano-ledger"
+	"github.com/cloudstruct/go-cardano-ledger/cbor"
+)
+
+var testProtocolParams = ledger.BabbageProtocolParameters{
+	MinFeeA:        44,
+	MinFeeB:        155381,
+	MaxTxSize:      16384,
+	KeyDeposit:     2000000,
+	PoolDeposit:    500000000,
+	AdaPerUtxoByte: 4310,
+	MaxValueSize:   5000,
+}
+
+func testAddress(t *testing.T, addr string) ledger.Address {
+	ret, err := ledger.NewAddress(addr)
+	if err != nil {
+		t.Fatalf("failed to decode address %s: %s", addr, err)
+	}
+	return ret
+}
+
+func testUtxo(addr ledger.Address, txIdByte byte, index uint32, amount ledger.MaryValue) ledger.UnspentOutput {
+	var txId ledger.Blake2b256
+	copy(txId[:], bytes.Repeat([]byte{txIdByte}, len(txId)))
+	return ledger.UnspentOutput{
+		Input: ledger.ShelleyTransactionInput{
+			Id:    txId,
+			Index: index,
+		},
+		Output: ledger.BabbageTransactionOutput{
+			Address: addr,
+			Amount:  amount,
+		},
+	}
+}
+
+// Helper function to check that the value consumed by a transaction matches the value produced
+func checkTxBalance(t *testing.T, tx *ledger.BabbageTransaction, utxos []ledger.UnspentOutput) {
+	inputAmounts := map[ledger.ShelleyTransactionInput]ledger.MaryValue{}
+	for _, utxo := range utxos {
+		inputAmounts[utxo.Input] = utxo.Output.Amount
+	}
+	var consumedCoin, producedCoin uint64
+	consumedAssets := map[string]uint64{}
+	producedAssets := map[string]uint64{}
+	for _, input := range tx.Body.Inputs {
+		amount, ok := inputAmounts[input]
+		if !ok {
+			t.Fatalf("transaction contains unknown input: %x#%d", input.Id, input.Index)
+		}
+		consumedCoin += amount.Coin
+		for policyId, assets := range amount.Assets {
+			for assetName, qty := range assets {
+				consumedAssets[policyId.String()+assetName.String()] += qty
+			}
+		}
+	}
+	for _, amount := range tx.Body.Withdrawals {
+		consumedCoin += amount
+	}
+	producedCoin += tx.Body.Fee
+	for _, output := range tx.Body.Outputs {
+		producedCoin += output.Amount.Coin
+		for policyId, assets := range output.Amount.Assets {
+			for assetName, qty := range assets {
+				producedAssets[policyId.String()+assetName.String()] += qty
+			}
+		}
+	}
+	if consumedCoin != producedCoin {
+		t.Fatalf("transaction is not balanced: consumed %d lovelace, produced %d lovelace", consumedCoin, producedCoin)
+	}
+	for asset, qty := range consumedAssets {
+		if producedAssets[asset] != qty {
+			t.Fatalf("transaction is not balanced for asset %s: consumed %d, produced %d", asset, qty, producedAssets[asset])
+		}
+	}
+}
+
+func TestTxBuilderSimple(t *testing.T) {
+	addr := testAddress(t, "addr1qx2fxv2umyhttkxyxp8x0dlpdt3k6cwng5pxj3jhsydzer3n0d3vllmyqwsx5wktcd8cc3sq835lu7drv2xwl2wywfgse35a3x")
+	destAddr := testAddress(t, "addr1vx2fxv2umyhttkxyxp8x0dlpdt3k6cwng5pxj3jhsydzers66hrl8")
+	utxos := []ledger.UnspentOutput{
+		testUtxo(addr, 0x01, 0, ledger.MaryValue{Coin: 2000000}),
+		testUtxo(addr, 0x02, 1, ledger.MaryValue{Coin: 10000000}),
+		testUtxo(addr, 0x03, 0, ledger.MaryValue{Coin: 1500000}),
+	}
+	tx, err := ledger.NewTxBuilder(testProtocolParams).
+		AddUtxos(utxos...).
+		AddOutputs(ledger.BabbageTransactionOutput{Address: destAddr, Amount: ledger.MaryValue{Coin: 5000000}}).
+		SetChangeAddress(addr).
+		SetTtl(1000000).
+		Build()
+	if err != nil {
+		t.Fatalf("failed to build transaction: %s", err)
+	}
+	// The largest UTxO covers the output, fee and change on its own
+	if len(tx.Body.Inputs) != 1 || tx.Body.Inputs[0] != utxos[1].Input {
+		t.Fatalf("did not get expected inputs: %v", tx.Body.Inputs)
+	}
+	if len(tx.Body.Outputs) != 2 {
+		t.Fatalf("expected 2 outputs, got %d", len(tx.Body.Outputs))
+	}
+	if tx.Body.Outputs[1].Address != addr {
+		t.Fatalf("change output was not sent to the change address")
+	}
+	if tx.Body.Ttl != 1000000 {
+		t.Fatalf("did not get expected TTL: %d", tx.Body.Ttl)
+	}
+	checkTxBalance(t, tx, utxos)
+	// The fee must cover the size of the transaction with a signature
+	txCbor, err := cbor.Encode(tx)
+	if err != nil {
+		t.Fatalf("failed to encode transaction: %s", err)
+	}
+	signedSize := uint64(len(txCbor)) + 101
+	if minFee := testProtocolParams.MinFeeA*signedSize + testProtocolParams.MinFeeB; tx.Body.Fee < minFee {
+		t.Fatalf("fee %d is less than minimum fee %d", tx.Body.Fee, minFee)
+	}
+	// The transaction body must round-trip through CBOR unchanged
+	tmpTx, err := ledger.NewBabbageTransactionFromCbor(txCbor)
+	if err != nil {
+		t.Fatalf("failed to decode transaction: %s", err)
+	}
+	if tmpTx.Body.Hash() != tx.Body.Hash() {
+		t.Fatalf("transaction body hash did not round-trip")
+	}
+}
+
+func TestTxBuilderMultiAsset(t *testing.T) {
+	addr := testAddress(t, "addr1qx2fxv2umyhttkxyxp8x0dlpdt3k6cwng5pxj3jhsydzer3n0d3vllmyqwsx5wktcd8cc3sq835lu7drv2xwl2wywfgse35a3x")
+	destAddr := testAddress(t, "addr1vx2fxv2umyhttkxyxp8x0dlpdt3k6cwng5pxj3jhsydzers66hrl8")
+	var policyId ledger.Blake2b224
+	copy(policyId[:], bytes.Repeat([]byte{0x11}, len(policyId)))
+	assetName := cbor.NewByteString([]byte("TOKEN"))
+	utxos := []ledger.UnspentOutput{
+		testUtxo(addr, 0x01, 0, ledger.MaryValue{Coin: 20000000}),
+		testUtxo(
+			addr,
+			0x02,
+			0,
+			ledger.MaryValue{
+				Coin:   1500000,
+				Assets: ledger.MultiAsset{policyId: {assetName: 100}},
+			},
+		),
+	}
+	tx, err := ledger.NewTxBuilder(testProtocolParams).
+		AddUtxos(utxos...).
+		AddOutputs(
+			ledger.BabbageTransactionOutput{
+				Address: destAddr,
+				// The minimum lovelace is populated automatically
+				Amount: ledger.MaryValue{Assets: ledger.MultiAsset{policyId: {assetName: 40}}},
+			},
+		).
+		SetChangeAddress(addr).
+		Build()
+	if err != nil {
+		t.Fatalf("failed to build transaction: %s", err)
+	}
+	if len(tx.Body.Inputs) != 2 {
+		t.Fatalf("expected 2 inputs, got %d", len(tx.Body.Inputs))
+	}
+	if tx.Body.Outputs[0].Amount.Coin == 0 {
+		t.Fatalf("minimum lovelace was not populated for output")
+	}
+	if qty := tx.Body.Outputs[1].Amount.Assets[policyId][assetName]; qty != 60 {
+		t.Fatalf("expected 60 tokens in change output, got %d", qty)
+	}
+	checkTxBalance(t, tx, utxos)
+}
+
+func TestTxBuilderInsufficientFunds(t *testing.T) {
+	addr := testAddress(t, "addr1vx2fxv2umyhttkxyxp8x0dlpdt3k6cwng5pxj3jhsydzers66hrl8")
+	_, err := ledger.NewTxBuilder(testProtocolParams).
+		AddUtxos(testUtxo(addr, 0x01, 0, ledger.MaryValue{Coin: 2000000})).
+		AddOutputs(ledger.BabbageTransactionOutput{Address: addr, Amount: ledger.MaryValue{Coin: 5000000}}).
+		SetChangeAddress(addr).
+		Build()
+	if err == nil {
+		t.Fatalf("did not get expected error for insufficient funds")
+	}
+}
+
+func TestTxBuilderWithdrawalCoversOutputs(t *testing.T) {
+	addr := testAddress(t, "addr1qx2fxv2umyhttkxyxp8x0dlpdt3k6cwng5pxj3jhsydzer3n0d3vllmyqwsx5wktcd8cc3sq835lu7drv2xwl2wywfgse35a3x")
+	rewardAddr := testAddress(t, "stake1u9999na53x0zn285dsnll092kwjultk7thjney93dyexnkc504sxv")
+	utxos := []ledger.UnspentOutput{
+		testUtxo(addr, 0x01, 0, ledger.MaryValue{Coin: 2000000}),
+	}
+	tx, err := ledger.NewTxBuilder(testProtocolParams).
+		AddUtxos(utxos...).
+		AddOutputs(ledger.BabbageTransactionOutput{Address: addr, Amount: ledger.MaryValue{Coin: 5000000}}).
+		AddWithdrawal(rewardAddr, 10000000).
+		SetChangeAddress(addr).
+		Build()
+	if err != nil {
+		t.Fatalf("failed to build transaction: %s", err)
+	}
+	// The withdrawal covers the output and fee, but the ledger still requires an input
+	if len(tx.Body.Inputs) != 1 {
+		t.Fatalf("expected 1 input, got %d", len(tx.Body.Inputs))
+	}
+	checkTxBalance(t, tx, utxos)
+}
+
+// Mainnet transaction eee233dee21729799ef4708746ab65bf2fa59f1c82f885102c93a532681dbf7d from a
+// Babbage block, which spends a single input and withdraws rewards
+const testTxBuilderMainnetTxBodyHex = "a50081825820438e038d985fe9b570b4da715e7cb45a8fc6937a2cc7108022186c814358d949010182a200581d6124d274bfd913b241a8cca20c6977775718fddb8f3763f1d365c85445011a001e8480a2005839010e2f66bc953ab54f124150d389e77e512a4732be749e1fc17d0616c74a52cfb4899e29a8f46c27ffbcaab3a5cfaede5de53c90b1693269db011ab36ba898021a0002a885031a048ae7c705a1581de14a52cfb4899e29a8f46c27ffbcaab3a5cfaede5de53c90b1693269db1a1aa449e3"
+
+func TestTxBuilderMainnetTransaction(t *testing.T) {
+	destAddr := testAddress(t, "addr1vyjdya9lmyfmysdgej3qc6thwat33lwm3umk8uwnvhy9g3gzvd2jp")
+	addr := testAddress(t, "addr1qy8z7e4uj5at2ncjg9gd8z080egj53ejhe6fu87p05rpd3622t8mfzv79x50gmp8l7724va9e7hduh098jgtz6fjd8ds0wf7rj")
+	rewardAddr := testAddress(t, "stake1u9999na53x0zn285dsnll092kwjultk7thjney93dyexnkc504sxv")
+	var inputId ledger.Blake2b256
+	inputIdBytes, err := hex.DecodeString("438e038d985fe9b570b4da715e7cb45a8fc6937a2cc7108022186c814358d949")
+	if err != nil {
+		t.Fatalf("failed to decode input ID: %s", err)
+	}
+	copy(inputId[:], inputIdBytes)
+	const fee = 174213
+	const withdrawal = 446974435
+	input := ledger.UnspentOutput{
+		Input: ledger.ShelleyTransactionInput{Id: inputId, Index: 1},
+		Output: ledger.BabbageTransactionOutput{
+			Address: addr,
+			Amount:  ledger.MaryValue{Coin: 2000000 + 3010177176 + fee - withdrawal},
+		},
+	}
+	// The value left over after the outputs matches the fee paid on chain, so no change output is
+	// added and the fee is kept as is
+	tx, err := ledger.NewTxBuilder(testProtocolParams).
+		AddInputs(input).
+		AddOutputs(
+			ledger.BabbageTransactionOutput{Address: destAddr, Amount: ledger.MaryValue{Coin: 2000000}},
+			ledger.BabbageTransactionOutput{Address: addr, Amount: ledger.MaryValue{Coin: 3010177176}},
+		).
+		AddWithdrawal(rewardAddr, withdrawal).
+		SetChangeAddress(addr).
+		SetTtl(76212167).
+		Build()
+	if err != nil {
+		t.Fatalf("failed to build transaction: %s", err)
+	}
+	if hex.EncodeToString(tx.Body.Cbor()) != testTxBuilderMainnetTxBodyHex {
+		t.Fatalf("did not get expected transaction body CBOR\n  got:    %x\n  wanted: %s", tx.Body.Cbor(), testTxBuilderMainnetTxBodyHex)
+	}
+	if txHash := tx.Body.Hash(); txHash != "eee233dee21729799ef4708746ab65bf2fa59f1c82f885102c93a532681dbf7d" {
+		t.Fatalf("did not get expected transaction hash: %s", txHash)
+	}
+}
@@ -2,6 +2,9 @@ package ledger
 
 import (
 	"fmt"
+	"hash/crc32"
+	"math/big"
+	"strings"
 
 	"github.com/cloudstruct/go-cardano-ledger/cbor"
 )
@@ -15,12 +18,57 @@ const (
 	BLOCK_HEADER_TYPE_BYRON = 0
 
 	TX_TYPE_BYRON = 0
+
+	BYRON_TX_INPUT_TYPE_UTXO = 0
+
+	BYRON_TX_WITNESS_TYPE_PK     = 0
+	BYRON_TX_WITNESS_TYPE_SCRIPT = 1
+	BYRON_TX_WITNESS_TYPE_REDEEM = 2
+
+	BYRON_SSC_PAYLOAD_TYPE_COMMITMENTS  = 0
+	BYRON_SSC_PAYLOAD_TYPE_OPENINGS     = 1
+	BYRON_SSC_PAYLOAD_TYPE_SHARES       = 2
+	BYRON_SSC_PAYLOAD_TYPE_CERTIFICATES = 3
+
+	BYRON_TX_FEE_POLICY_TYPE_LINEAR = 0
+
+	// The number of slots in an epoch is 10 times the security parameter (k)
+	BYRON_SLOTS_PER_EPOCH_FACTOR = 10
 )
 
+// ByronGenesisConfig contains the values from the Byron genesis that are needed to interpret
+// Byron blocks
+type ByronGenesisConfig struct {
+	// Security parameter (k)
+	SecurityParam uint64
+}
+
+// SlotsPerEpoch returns the number of slots in a Byron epoch
+func (c *ByronGenesisConfig) SlotsPerEpoch() uint64 {
+	return c.SecurityParam * BYRON_SLOTS_PER_EPOCH_FACTOR
+}
+
+// SlotNumber returns the absolute slot number for the specified epoch and slot within that epoch
+func (c *ByronGenesisConfig) SlotNumber(epoch uint64, slot uint64) uint64 {
+	return (epoch * c.SlotsPerEpoch()) + slot
+}
+
+// EpochSlot returns the epoch and the slot within that epoch for the specified absolute slot number
+func (c *ByronGenesisConfig) EpochSlot(slotNumber uint64) (uint64, uint64) {
+	slotsPerEpoch := c.SlotsPerEpoch()
+	return slotNumber / slotsPerEpoch, slotNumber % slotsPerEpoch
+}
+
+// Byron genesis config for mainnet. Other networks may use a different security parameter (the
+// preview testnet uses k=432), so the values from their genesis should be used instead
+var ByronGenesisConfigMainnet = ByronGenesisConfig{
+	SecurityParam: 2160,
+}
+
 type ByronMainBlockHeader struct {
 	cbor.StructAsArray
 	cbor.DecodeStoreCbor
-	hash          string
+	hash          *Blake2b256
 	ProtocolMagic uint32
 	PrevBlock     Blake2b256
 	BodyProof     interface{}
@@ -35,7 +83,7 @@ type ByronMainBlockHeader struct {
 		PubKey     []byte
 		Difficulty struct {
 			cbor.StructAsArray
-			Unknown uint64
+			Value uint64
 		}
 		BlockSig []interface{}
 	}
@@ -61,47 +109,463 @@ func (h *ByronMainBlockHeader) UnmarshalCBOR(cborData []byte) error {
 	return h.UnmarshalCborGeneric(cborData, h)
 }
 
-func (h *ByronMainBlockHeader) Hash() string {
-	if h.hash == "" {
-		// Prepend bytes for CBOR list wrapper
-		// The block hash is calculated with these extra bytes, so we have to add them to
-		// get the correct value
-		h.hash = generateBlockHeaderHash(h.Cbor(), []byte{0x82, BLOCK_TYPE_BYRON_EBB})
+func (h *ByronMainBlockHeader) Hash() Blake2b256 {
+	if h.hash == nil {
+		// Prepend bytes for CBOR list wrapper and block type
+		// The block hash is calculated over [blockType, header], so we have to add these
+		// extra bytes to get the correct value
+		tmpHash := generateBlockHeaderHash(h.Cbor(), []byte{0x82, BLOCK_TYPE_BYRON_MAIN})
+		h.hash = &tmpHash
 	}
-	return h.hash
+	return *h.hash
 }
 
 func (h *ByronMainBlockHeader) BlockNumber() uint64 {
-	// Byron blocks don't store the block number in the block
-	return 0
+	// Byron blocks don't store the block number directly, but the chain difficulty is
+	// the number of main blocks since genesis
+	return h.ConsensusData.Difficulty.Value
 }
 
+// SlotNumber returns the absolute slot number using the mainnet genesis config. Use
+// SlotNumberWithConfig for other networks
 func (h *ByronMainBlockHeader) SlotNumber() uint64 {
-	return uint64(h.ConsensusData.SlotId.Slot)
+	return h.SlotNumberWithConfig(ByronGenesisConfigMainnet)
+}
+
+// SlotNumberWithConfig returns the absolute slot number using the specified genesis config
+func (h *ByronMainBlockHeader) SlotNumberWithConfig(cfg ByronGenesisConfig) uint64 {
+	return cfg.SlotNumber(h.ConsensusData.SlotId.Epoch, uint64(h.ConsensusData.SlotId.Slot))
 }
 
 func (h *ByronMainBlockHeader) Era() Era {
 	return eras[ERA_ID_BYRON]
 }
 
-// TODO: flesh this out
-type ByronTransactionBody interface{}
+type ByronTransactionBody struct {
+	cbor.StructAsArray
+	cbor.DecodeStoreCbor
+	hash       string
+	Inputs     []ByronTransactionInput
+	Outputs    []ByronTransactionOutput
+	Attributes cbor.Value
+}
+
+func (b *ByronTransactionBody) UnmarshalCBOR(cborData []byte) error {
+	return b.UnmarshalCborGeneric(cborData, b)
+}
+
+func (b *ByronTransactionBody) Hash() string {
+	if b.hash == "" {
+		b.hash = generateTransactionHash(b.Cbor(), nil)
+	}
+	return b.hash
+}
+
+type ByronTransactionInput struct {
+	cbor.StructAsArray
+	Id    Blake2b256
+	Index uint32
+}
+
+func (i *ByronTransactionInput) UnmarshalCBOR(data []byte) error {
+	var tmpData struct {
+		cbor.StructAsArray
+		Type uint
+		Data cbor.WrappedCbor
+	}
+	if _, err := cbor.Decode(data, &tmpData); err != nil {
+		return err
+	}
+	if tmpData.Type != BYRON_TX_INPUT_TYPE_UTXO {
+		return fmt.Errorf("unknown Byron transaction input type: %d", tmpData.Type)
+	}
+	// Decode the wrapped [txid, index] into a type without our custom UnmarshalCBOR()
+	type tmpInput ByronTransactionInput
+	if err := tmpData.Data.Decode((*tmpInput)(i)); err != nil {
+		return err
+	}
+	return nil
+}
+
+type ByronTransactionOutput struct {
+	cbor.StructAsArray
+	Address ByronAddress
+	Amount  uint64
+}
+
+type ByronAddress struct {
+	cbor.DecodeStoreCbor
+	Root       Blake2b224
+	Attributes cbor.Value
+	Type       uint
+	Checksum   uint32
+}
+
+func (a *ByronAddress) UnmarshalCBOR(data []byte) error {
+	var tmpData struct {
+		cbor.StructAsArray
+		Payload  cbor.WrappedCbor
+		Checksum uint32
+	}
+	if _, err := cbor.Decode(data, &tmpData); err != nil {
+		return err
+	}
+	if checksum := crc32.ChecksumIEEE(tmpData.Payload); checksum != tmpData.Checksum {
+		return fmt.Errorf("Byron address checksum mismatch: calculated %d, expected %d", checksum, tmpData.Checksum)
+	}
+	var tmpPayload struct {
+		cbor.StructAsArray
+		Root       Blake2b224
+		Attributes cbor.Value
+		Type       uint
+	}
+	if err := tmpData.Payload.Decode(&tmpPayload); err != nil {
+		return err
+	}
+	a.Root = tmpPayload.Root
+	a.Attributes = tmpPayload.Attributes
+	a.Type = tmpPayload.Type
+	a.Checksum = tmpData.Checksum
+	a.SetCbor(data)
+	return nil
+}
+
+// Bytes returns the raw bytes for the address, which is the CBOR encoding of the address
+func (a *ByronAddress) Bytes() []byte {
+	return a.Cbor()
+}
+
+// String returns the base58 encoding of the address
+func (a *ByronAddress) String() string {
+	return base58Encode(a.Bytes())
+}
+
+type ByronTransactionWitness interface {
+	isByronTransactionWitness()
+}
+
+type ByronPkWitness struct {
+	cbor.StructAsArray
+	PubKey    []byte
+	Signature []byte
+}
+
+func (w *ByronPkWitness) isByronTransactionWitness() {}
+
+type ByronScriptWitness struct {
+	cbor.StructAsArray
+	Validator ByronScript
+	Redeemer  ByronScript
+}
+
+func (w *ByronScriptWitness) isByronTransactionWitness() {}
+
+type ByronScript struct {
+	cbor.StructAsArray
+	Version uint16
+	Script  []byte
+}
+
+type ByronRedeemWitness struct {
+	cbor.StructAsArray
+	PubKey    []byte
+	Signature []byte
+}
+
+func (w *ByronRedeemWitness) isByronTransactionWitness() {}
 
-// TODO: flesh this out
-type ByronTransaction interface{}
+func NewByronTransactionWitnessFromCbor(data []byte) (ByronTransactionWitness, error) {
+	var tmpData struct {
+		cbor.StructAsArray
+		Type uint
+		Data cbor.WrappedCbor
+	}
+	if _, err := cbor.Decode(data, &tmpData); err != nil {
+		return nil, err
+	}
+	var ret ByronTransactionWitness
+	switch tmpData.Type {
+	case BYRON_TX_WITNESS_TYPE_PK:
+		ret = &ByronPkWitness{}
+	case BYRON_TX_WITNESS_TYPE_SCRIPT:
+		ret = &ByronScriptWitness{}
+	case BYRON_TX_WITNESS_TYPE_REDEEM:
+		ret = &ByronRedeemWitness{}
+	default:
+		return nil, fmt.Errorf("unknown Byron transaction witness type: %d", tmpData.Type)
+	}
+	if err := tmpData.Data.Decode(ret); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+type ByronTransaction struct {
+	cbor.StructAsArray
+	Body      ByronTransactionBody
+	Witnesses []ByronTransactionWitness
+}
+
+func (t *ByronTransaction) UnmarshalCBOR(data []byte) error {
+	var tmpData struct {
+		cbor.StructAsArray
+		Body      ByronTransactionBody
+		Witnesses []cbor.RawMessage
+	}
+	if _, err := cbor.Decode(data, &tmpData); err != nil {
+		return err
+	}
+	t.Body = tmpData.Body
+	t.Witnesses = nil
+	for _, witnessData := range tmpData.Witnesses {
+		witness, err := NewByronTransactionWitnessFromCbor(witnessData)
+		if err != nil {
+			return err
+		}
+		t.Witnesses = append(t.Witnesses, witness)
+	}
+	return nil
+}
 
 type ByronMainBlockBody struct {
 	cbor.StructAsArray
-	TxPayload  []ByronTransactionBody
-	SscPayload cbor.Value
-	DlgPayload []interface{}
-	UpdPayload []interface{}
+	TxPayload  []ByronTransaction
+	SscPayload ByronSscPayload
+	DlgPayload []ByronDelegationCertificate
+	UpdPayload ByronUpdatePayload
+}
+
+// ByronSscPayload represents the shared seed computation (SSC) payload of a Byron main block. Only
+// the fields relevant to the payload type are populated
+type ByronSscPayload struct {
+	Type         uint
+	Commitments  []ByronSscSignedCommitment
+	Openings     map[Blake2b224][]byte
+	Shares       map[Blake2b224]map[Blake2b224][][]byte
+	Certificates []ByronSscCertificate
+}
+
+func (p *ByronSscPayload) UnmarshalCBOR(data []byte) error {
+	payloadType, err := cbor.DecodeIdFromList(data)
+	if err != nil {
+		return err
+	}
+	p.Type = uint(payloadType)
+	switch payloadType {
+	case BYRON_SSC_PAYLOAD_TYPE_COMMITMENTS:
+		var tmpData struct {
+			cbor.StructAsArray
+			Type         uint
+			Commitments  []ByronSscSignedCommitment
+			Certificates []ByronSscCertificate
+		}
+		if _, err := cbor.Decode(data, &tmpData); err != nil {
+			return err
+		}
+		p.Commitments = tmpData.Commitments
+		p.Certificates = tmpData.Certificates
+	case BYRON_SSC_PAYLOAD_TYPE_OPENINGS:
+		var tmpData struct {
+			cbor.StructAsArray
+			Type         uint
+			Openings     map[Blake2b224][]byte
+			Certificates []ByronSscCertificate
+		}
+		if _, err := cbor.Decode(data, &tmpData); err != nil {
+			return err
+		}
+		p.Openings = tmpData.Openings
+		p.Certificates = tmpData.Certificates
+	case BYRON_SSC_PAYLOAD_TYPE_SHARES:
+		var tmpData struct {
+			cbor.StructAsArray
+			Type         uint
+			Shares       map[Blake2b224]map[Blake2b224][][]byte
+			Certificates []ByronSscCertificate
+		}
+		if _, err := cbor.Decode(data, &tmpData); err != nil {
+			return err
+		}
+		p.Shares = tmpData.Shares
+		p.Certificates = tmpData.Certificates
+	case BYRON_SSC_PAYLOAD_TYPE_CERTIFICATES:
+		var tmpData struct {
+			cbor.StructAsArray
+			Type         uint
+			Certificates []ByronSscCertificate
+		}
+		if _, err := cbor.Decode(data, &tmpData); err != nil {
+			return err
+		}
+		p.Certificates = tmpData.Certificates
+	default:
+		return fmt.Errorf("unknown Byron SSC payload type: %d", payloadType)
+	}
+	return nil
+}
+
+type ByronSscSignedCommitment struct {
+	cbor.StructAsArray
+	PubKey     []byte
+	Commitment ByronSscCommitment
+	Signature  []byte
+}
+
+type ByronSscCommitment struct {
+	cbor.StructAsArray
+	// Encrypted shares keyed by VSS public key
+	Shares map[cbor.ByteString][][]byte
+	Proof  ByronSscSecretProof
+}
+
+type ByronSscSecretProof struct {
+	cbor.StructAsArray
+	ExtraGen       []byte
+	Proof          []byte
+	ParallelProofs []byte
+	Commitments    [][]byte
+}
+
+type ByronSscCertificate struct {
+	cbor.StructAsArray
+	VssKey      []byte
+	ExpiryEpoch uint64
+	Signature   []byte
+	SigningKey  []byte
+}
+
+// ByronDelegationCertificate represents a heavyweight delegation certificate
+type ByronDelegationCertificate struct {
+	cbor.StructAsArray
+	Epoch       uint64
+	IssuerKey   []byte
+	DelegateKey []byte
+	Signature   []byte
+}
+
+type ByronUpdatePayload struct {
+	cbor.StructAsArray
+	// This contains either zero or one proposals
+	Proposals []ByronUpdateProposal
+	Votes     []ByronUpdateVote
+}
+
+type ByronUpdateProposal struct {
+	cbor.StructAsArray
+	cbor.DecodeStoreCbor
+	id              string
+	BlockVersion    ByronBlockVersion
+	BlockVersionMod ByronBlockVersionMod
+	SoftwareVersion ByronSoftwareVersion
+	Data            map[string]ByronUpdateData
+	Attributes      cbor.Value
+	From            []byte
+	Signature       []byte
+}
+
+func (p *ByronUpdateProposal) UnmarshalCBOR(cborData []byte) error {
+	return p.UnmarshalCborGeneric(cborData, p)
+}
+
+// Id returns the update proposal ID, which is referenced by votes for the proposal
+func (p *ByronUpdateProposal) Id() string {
+	if p.id == "" {
+		p.id = generateTransactionHash(p.Cbor(), nil)
+	}
+	return p.id
+}
+
+type ByronBlockVersion struct {
+	cbor.StructAsArray
+	Major uint16
+	Minor uint16
+	Alt   uint8
+}
+
+type ByronSoftwareVersion struct {
+	cbor.StructAsArray
+	Name    string
+	Version uint32
+}
+
+// ByronBlockVersionMod contains the protocol parameter changes for an update proposal. Each field
+// is encoded as a list containing zero or one values
+type ByronBlockVersionMod struct {
+	cbor.StructAsArray
+	ScriptVersion     []uint16
+	SlotDuration      []uint64
+	MaxBlockSize      []uint64
+	MaxHeaderSize     []uint64
+	MaxTxSize         []uint64
+	MaxProposalSize   []uint64
+	MpcThd            []uint64
+	HeavyDelThd       []uint64
+	UpdateVoteThd     []uint64
+	UpdateProposalThd []uint64
+	UpdateImplicit    []uint64
+	SoftForkRule      []ByronSoftForkRule
+	TxFeePolicy       []ByronTxFeePolicy
+	UnlockStakeEpoch  []uint64
+}
+
+type ByronSoftForkRule struct {
+	cbor.StructAsArray
+	InitThd      uint64
+	MinThd       uint64
+	ThdDecrement uint64
+}
+
+// ByronTxFeePolicy represents the linear fee policy, with values specified in nanolovelace
+type ByronTxFeePolicy struct {
+	Summand    uint64
+	Multiplier uint64
+}
+
+func (p *ByronTxFeePolicy) UnmarshalCBOR(data []byte) error {
+	var tmpData struct {
+		cbor.StructAsArray
+		Type uint
+		Data cbor.WrappedCbor
+	}
+	if _, err := cbor.Decode(data, &tmpData); err != nil {
+		return err
+	}
+	if tmpData.Type != BYRON_TX_FEE_POLICY_TYPE_LINEAR {
+		return fmt.Errorf("unknown Byron TX fee policy type: %d", tmpData.Type)
+	}
+	var tmpPolicy struct {
+		cbor.StructAsArray
+		Summand    uint64
+		Multiplier uint64
+	}
+	if err := tmpData.Data.Decode(&tmpPolicy); err != nil {
+		return err
+	}
+	p.Summand = tmpPolicy.Summand
+	p.Multiplier = tmpPolicy.Multiplier
+	return nil
+}
+
+type ByronUpdateData struct {
+	cbor.StructAsArray
+	AppDiffHash  Blake2b256
+	PkgHash      Blake2b256
+	UpdaterHash  Blake2b256
+	MetadataHash Blake2b256
+}
+
+type ByronUpdateVote struct {
+	cbor.StructAsArray
+	Voter      []byte
+	ProposalId Blake2b256
+	Vote       bool
+	Signature  []byte
 }
 
 type ByronEpochBoundaryBlockHeader struct {
 	cbor.StructAsArray
 	cbor.DecodeStoreCbor
-	hash          string
+	hash          *Blake2b256
 	ProtocolMagic uint32
 	PrevBlock     Blake2b256
 	BodyProof     interface{}
@@ -120,24 +584,32 @@ func (h *ByronEpochBoundaryBlockHeader) UnmarshalCBOR(cborData []byte) error {
 	return h.UnmarshalCborGeneric(cborData, h)
 }
 
-func (h *ByronEpochBoundaryBlockHeader) Hash() string {
-	if h.hash == "" {
-		// Prepend bytes for CBOR list wrapper
-		// The block hash is calculated with these extra bytes, so we have to add them to
-		// get the correct value
-		h.hash = generateBlockHeaderHash(h.Cbor(), []byte{0x82, BLOCK_TYPE_BYRON_MAIN})
+func (h *ByronEpochBoundaryBlockHeader) Hash() Blake2b256 {
+	if h.hash == nil {
+		// Prepend bytes for CBOR list wrapper and block type
+		// The block hash is calculated over [blockType, header], so we have to add these
+		// extra bytes to get the correct value
+		tmpHash := generateBlockHeaderHash(h.Cbor(), []byte{0x82, BLOCK_TYPE_BYRON_EBB})
+		h.hash = &tmpHash
 	}
-	return h.hash
+	return *h.hash
 }
 
 func (h *ByronEpochBoundaryBlockHeader) BlockNumber() uint64 {
-	// Byron blocks don't store the block number in the block
-	return 0
+	// Boundary blocks share the chain difficulty of the previous main block
+	return h.ConsensusData.Difficulty.Value
 }
 
+// SlotNumber returns the absolute slot number using the mainnet genesis config. Use
+// SlotNumberWithConfig for other networks
 func (h *ByronEpochBoundaryBlockHeader) SlotNumber() uint64 {
-	// There is no slot on boundary blocks
-	return 0
+	return h.SlotNumberWithConfig(ByronGenesisConfigMainnet)
+}
+
+// SlotNumberWithConfig returns the absolute slot number using the specified genesis config
+func (h *ByronEpochBoundaryBlockHeader) SlotNumberWithConfig(cfg ByronGenesisConfig) uint64 {
+	// There is no slot on boundary blocks, so we use the first slot of the epoch
+	return cfg.SlotNumber(h.ConsensusData.Epoch, 0)
 }
 
 func (h *ByronEpochBoundaryBlockHeader) Era() Era {
@@ -156,7 +628,7 @@ func (b *ByronMainBlock) UnmarshalCBOR(cborData []byte) error {
 	return b.UnmarshalCborGeneric(cborData, b)
 }
 
-func (b *ByronMainBlock) Hash() string {
+func (b *ByronMainBlock) Hash() Blake2b256 {
 	return b.Header.Hash()
 }
 
@@ -168,13 +640,21 @@ func (b *ByronMainBlock) SlotNumber() uint64 {
 	return b.Header.SlotNumber()
 }
 
+// SlotNumberWithConfig returns the absolute slot number using the specified genesis config
+func (b *ByronMainBlock) SlotNumberWithConfig(cfg ByronGenesisConfig) uint64 {
+	return b.Header.SlotNumberWithConfig(cfg)
+}
+
 func (b *ByronMainBlock) Era() Era {
 	return b.Header.Era()
 }
 
 func (b *ByronMainBlock) Transactions() []TransactionBody {
-	// TODO
-	return nil
+	ret := []TransactionBody{}
+	for idx := range b.Body.TxPayload {
+		ret = append(ret, &b.Body.TxPayload[idx].Body)
+	}
+	return ret
 }
 
 type ByronEpochBoundaryBlock struct {
@@ -189,7 +669,7 @@ func (b *ByronEpochBoundaryBlock) UnmarshalCBOR(cborData []byte) error {
 	return b.UnmarshalCborGeneric(cborData, b)
 }
 
-func (b *ByronEpochBoundaryBlock) Hash() string {
+func (b *ByronEpochBoundaryBlock) Hash() Blake2b256 {
 	return b.Header.Hash()
 }
 
@@ -201,6 +681,11 @@ func (b *ByronEpochBoundaryBlock) SlotNumber() uint64 {
 	return b.Header.SlotNumber()
 }
 
+// SlotNumberWithConfig returns the absolute slot number using the specified genesis config
+func (b *ByronEpochBoundaryBlock) SlotNumberWithConfig(cfg ByronGenesisConfig) uint64 {
+	return b.Header.SlotNumberWithConfig(cfg)
+}
+
 func (b *ByronEpochBoundaryBlock) Era() Era {
 	return b.Header.Era()
 }
@@ -257,3 +742,53 @@ func NewByronTransactionFromCbor(data []byte) (*ByronTransaction, error) {
 	}
 	return &byronTx, nil
 }
+
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+// base58Encode encodes the provided data using the Bitcoin base58 alphabet, which is
+// used for the string representation of Byron addresses
+func base58Encode(data []byte) string {
+	num := new(big.Int).SetBytes(data)
+	base := big.NewInt(int64(len(base58Alphabet)))
+	mod := new(big.Int)
+	ret := []byte{}
+	for num.Sign() > 0 {
+		num.DivMod(num, base, mod)
+		ret = append(ret, base58Alphabet[mod.Int64()])
+	}
+	// Leading zero bytes are represented by the first character of the alphabet
+	for _, b := range data {
+		if b != 0 {
+			break
+		}
+		ret = append(ret, base58Alphabet[0])
+	}
+	// Reverse the result
+	for i, j := 0, len(ret)-1; i < j; i, j = i+1, j-1 {
+		ret[i], ret[j] = ret[j], ret[i]
+	}
+	return string(ret)
+}
+
+// base58Decode decodes the provided string using the Bitcoin base58 alphabet
+func base58Decode(value string) ([]byte, error) {
+	num := new(big.Int)
+	base := big.NewInt(int64(len(base58Alphabet)))
+	for _, c := range value {
+		idx := strings.IndexRune(base58Alphabet, c)
+		if idx < 0 {
+			return nil, fmt.Errorf("invalid base58 character: %c", c)
+		}
+		num.Mul(num, base)
+		num.Add(num, big.NewInt(int64(idx)))
+	}
+	ret := num.Bytes()
+	// Leading zero bytes are represented by the first character of the alphabet
+	for _, c := range value {
+		if c != rune(base58Alphabet[0]) {
+			break
+		}
+		ret = append([]byte{0}, ret...)
+	}
+	return ret, nil
+}
@@ -0,0 +1,671 @@
+package ledger_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/cloudstruct/go-cardano-ledger"
+	"github.com/cloudstruct/go-cardano-ledger/cbor"
+	"golang.org/x/crypto/blake2b"
+)
+
+// Mainnet block 1451a0dbf16cfeddf4991a838961df1b08a68f43a19c0eb3b36cc4029c77a2d8 (epoch 207, slot 7)
+const byronMainBlockMainnetHex = "83851a2d964a09582025df38df102b89ec25a432a2972993d2fa8cc1f597a73e6260b2f07e79501eb084830258200f284bc22f5b96228ee0687b7bb87c56132f77df4235c78a1595729ccfce2001582019fb988d02ec920a6de5ac71c5d5e75f8b73d7ed8e8abea7773e28859983206e82035820d36a2619a672494604e11bb447cbcf5231e9f2ba25c2169177edc941bd50ad6c5820afc0da64183bf2664f3d4eec7238d524ba607faeeab24fc100eb861dba69971b58204e66280cd94d591072349bec0a3090a53aa945562efb6d08d56e53654b0e4098848218cf0758401bc97a2fe02c297880ce8ecfd997fe4c1ec09ee10feeee9f686760166b05281d6283468ffd93becb0c956ccddd642df9b1244c915911185fa49355f6f22bfab9811a004430ed820282840058401bc97a2fe02c297880ce8ecfd997fe4c1ec09ee10feeee9f686760166b05281d6283468ffd93becb0c956ccddd642df9b1244c915911185fa49355f6f22bfab9584061261a95b7613ee6bf2067dad77b70349729b0c50d57bc1cf30de0db4a1e73a885d0054af7c23fc6c37919dba41c602a57e2d0f9329a7954b867338d6fb2c9455840e03e62f083df5576360e60a32e22bbb07b3c8df4fcab8079f1d6f61af3954d242ba8a06516c395939f24096f3df14e103a7d9c2b80a68a9363cf1f27c7a4e3075840325068a2307397703c4eebb1de1ecab0b23c24a5e80c985e0f7546bb6571ee9eb94069708fc25ec67a4a5753a0d49ab5e536131c19c7f9dd4fd32532fd0f71028483010000826a63617264616e6f2d736c01a058204ba92aa320c60acc9ad7b9a64f2eda55c4d2ec28e604faf186708b4f0c4e8edf849f82839f8200d8185824825820b0a7782d21f37e9d98f4cbdc23bf2677d93eca1ac0fb3f79923863a698d53f8f018200d81858248258205bd3e8385d2ecdd17d3b602263e8a5e7aa0edb4dd00221f369c2720f7d85940d008200d81858248258201e4a77f8375548e5bc409a518dbcb4a8437b539682f4e840f4a1056f01cea566008200d81858248258205e83b53253f705c214d904f65fdaaa2f153db59a229a9cee1da6c329b543236100ff9f8282d818584283581ca1932430cb1ad6482a1b67964d778c18b574674fda151cdfa73c63cda101581e581cfc8a0b5477e819a27a34910e6c174b50b871192e95cca1a711bbceb3001abcb52f6d1b000000013446d5718282d818584283581c093916f7e775fba80eaa65cded085d985f7f9e4982cddd2bb7c476aea101581e581c83d3e2df30edf90acf198b85a7327d964f9d92fd739d0c986a914f6c001a27a611b61a000c48cbffa0848200d8185885825840a781c060f2b32d116ef79bb1823d4a25ea36f6c130b3a182713739ea1819e32d261db3dce30b15c29db81d1c284d3fe350d12241e8ccc65cdf08adba90e0ad4558408eb4c9549a6a044d687d6c04fdee2240994f43966ef113ebb3e76a756e39472badb137c3e0268d34ce6042f76c2534220cc1e061a1a29cce065faf486184cf078200d818588582584085dc150754227f68d1640887f8fa57c93e4cad3499f2cb7b5e8258b0b367dcceaa42bf9ea1cfff73fd0fab44d9e0a36ef61bc5d0f294365316a4e0ed12b40a135840f1233519fa85f3ecbb2deaa9dff2d7e943156d49a7a33603381f2c1779b7f65ea0d39a8dcdd227f5d69b9355ab35df0c43c2abb751c6dd24b107a2c7ac51f5088200d81858858258403559467e9b4a4e47af0388e7224358197e5d39c57c71c391db4a7d480f297d8b86b0746de21dc5dfca2bd8b8fa817c1fa1c3bd3eeaddbfd7a6b270564e416d0c5840b0e33544dcb1895b592a612f5be81242a88226d0612da76099b653f89ce7c5641af14fad696ccd44b58744915291240224fd83a26f103c0717752ea256b4af0b8200d8185885825840572c3ea039ded80f19b0d6841e9ad0d0d1b73242ac98538affbec6e7356192f48eba0291ea1b174f9c42e139ba85ce75656a036ba0993dda605d5a62956dba6558406257e3a27a896268cade4d5371537ed606d3004d6269f87ebe6056b6eff737a2a9ef82d27ba1f9b642ffc622ec27b38e69ed41e272d3de0767cad860d50fa10d82839f8200d8185824825820779a319e0d64b80eaff5ed13d08062b8672fc71ac27e7b30574c1c7972764de202ff9f8282d818582183581c2c0dd53d4e6001e006729fc09d74c5a799d5f93c9f4b74748412a823a0001a1abd89081a769cfd808282d818582183581c05b073f36ee030589a31148838cd47e8d8c8f82fec9fe091c7d53cd8a0001a0c5f26b11b00000016bc0c4c47ffa0818200d8185885825840f129f07bbfd87fd1d3ff5fb32e9a5566e02208f89518e9994048add22074f433424e682a392581268c7544e34e9c54378a8820bdcf7dddce30490bbb2d363b4b5840709a2e70d3803554a15d788235bf56c9567407102be375be5071fa81d4c137047743b5f5abefdbab6b2781822474995dff917213c962ecd111619d75b8534f0aff8203d90102809fff82809fff81a0"
+
+// Header from the above mainnet block
+const byronMainBlockHeaderMainnetHex = "851a2d964a09582025df38df102b89ec25a432a2972993d2fa8cc1f597a73e6260b2f07e79501eb084830258200f284bc22f5b96228ee0687b7bb87c56132f77df4235c78a1595729ccfce2001582019fb988d02ec920a6de5ac71c5d5e75f8b73d7ed8e8abea7773e28859983206e82035820d36a2619a672494604e11bb447cbcf5231e9f2ba25c2169177edc941bd50ad6c5820afc0da64183bf2664f3d4eec7238d524ba607faeeab24fc100eb861dba69971b58204e66280cd94d591072349bec0a3090a53aa945562efb6d08d56e53654b0e4098848218cf0758401bc97a2fe02c297880ce8ecfd997fe4c1ec09ee10feeee9f686760166b05281d6283468ffd93becb0c956ccddd642df9b1244c915911185fa49355f6f22bfab9811a004430ed820282840058401bc97a2fe02c297880ce8ecfd997fe4c1ec09ee10feeee9f686760166b05281d6283468ffd93becb0c956ccddd642df9b1244c915911185fa49355f6f22bfab9584061261a95b7613ee6bf2067dad77b70349729b0c50d57bc1cf30de0db4a1e73a885d0054af7c23fc6c37919dba41c602a57e2d0f9329a7954b867338d6fb2c9455840e03e62f083df5576360e60a32e22bbb07b3c8df4fcab8079f1d6f61af3954d242ba8a06516c395939f24096f3df14e103a7d9c2b80a68a9363cf1f27c7a4e3075840325068a2307397703c4eebb1de1ecab0b23c24a5e80c985e0f7546bb6571ee9eb94069708fc25ec67a4a5753a0d49ab5e536131c19c7f9dd4fd32532fd0f71028483010000826a63617264616e6f2d736c01a058204ba92aa320c60acc9ad7b9a64f2eda55c4d2ec28e604faf186708b4f0c4e8edf"
+
+// Legacy testnet block f38aa5e8cf0b47d1ffa8b2385aa2d43882282db2ffd5ac0e3dadec1a6f2ecf08 (epoch 0, slot 1034)
+const byronMainBlockTestnetHex = "83851a4170cb175820067e773e6ffd66ea06f7f1c967e18a1ee0916797f6a1c1abdf410379eb8b1dbe84830058200e5751c026e543b2e8ab2eb06099daa1d1e5df47778f7787faab45cdf12fe3a85820afc0da64183bf2664f3d4eec7238d524ba607faeeab24fc100eb861dba69971b8300582025777aca9e4a73d48fc73b4f961d345b06d4a6f349cb7916570d35537d53479f5820d36a2619a672494604e11bb447cbcf5231e9f2ba25c2169177edc941bd50ad6c5820afc0da64183bf2664f3d4eec7238d524ba607faeeab24fc100eb861dba69971b58204e66280cd94d591072349bec0a3090a53aa945562efb6d08d56e53654b0e409884820019040a5840cb51d29ab94e50d9a144d4f426564cec700dee4d9e857aacf91d3b689374d81f742a452818cf2489c16dfc186f6e9c76b7df40845b7c450785f02d8809767575810482028284005840cb51d29ab94e50d9a144d4f426564cec700dee4d9e857aacf91d3b689374d81f742a452818cf2489c16dfc186f6e9c76b7df40845b7c450785f02d880976757558407ec249d890d0aaf9a81207960c163ae2d6ac5e715ca6b96d5860e50d9f2b2b2a1d568faa87c9cc8bfd433a3224a96ec5d101b4b6e9db008db8857f49bae294b25840a304bf45b44fbccc78f54b9014a6b2d4354631ebff235aebb2e71a15bdd582be3794384c1ba713b99ef05766e92b8f438b2fc5af349f2bb16e85e3780aa84c07584017a846a92477d3468690d97e28a44811be4f8e3fde79e478e4dcc432b13370c669c124be03015ef2b1f121b807ffe74b1a92a4247ea8a22f9ea30d5df671cb068483000000826a63617264616e6f2d736c00a058204ba92aa320c60acc9ad7b9a64f2eda55c4d2ec28e604faf186708b4f0c4e8edf849fff8300d9010280d90102809fff82809fff81a0"
+
+// Header from legacy testnet boundary block 8f8602837f7c6f8b8867dd1cbc1842cf51a27eaed2c70ef48325d00f8efb320f (epoch 0)
+const byronEbbHeaderTestnetHex = "851a4170cb17582096fceff972c2c06bd3bb5243c39215333be6d56aaf4823073dca31afe5038471582069bc32be98bb4567c0d60f571781e92c9f3100b119d03ff8886ad3f4e2a0eff78200810081a0"
+
+type byronBlockTestDefinition struct {
+	BlockType   uint
+	CborHex     string
+	Hash        string
+	SlotNumber  uint64
+	BlockNumber uint64
+	TxCount     int
+}
+
+var byronBlockTests = []byronBlockTestDefinition{
+	{
+		BlockType:   ledger.BLOCK_TYPE_BYRON_MAIN,
+		CborHex:     byronMainBlockMainnetHex,
+		Hash:        "1451a0dbf16cfeddf4991a838961df1b08a68f43a19c0eb3b36cc4029c77a2d8",
+		SlotNumber:  4471207,
+		BlockNumber: 4468973,
+		TxCount:     2,
+	},
+	{
+		BlockType:   ledger.BLOCK_TYPE_BYRON_MAIN,
+		CborHex:     byronMainBlockTestnetHex,
+		Hash:        "f38aa5e8cf0b47d1ffa8b2385aa2d43882282db2ffd5ac0e3dadec1a6f2ecf08",
+		SlotNumber:  1034,
+		BlockNumber: 4,
+		TxCount:     0,
+	},
+}
+
+func TestByronBlockHash(t *testing.T) {
+	for _, test := range byronBlockTests {
+		cborData, err := hex.DecodeString(test.CborHex)
+		if err != nil {
+			t.Fatalf("failed to decode CBOR hex: %s", err)
+		}
+		block, err := ledger.NewBlockFromCbor(test.BlockType, cborData)
+		if err != nil {
+			t.Fatalf("failed to decode block: %s", err)
+		}
+		if block.Hash().String() != test.Hash {
+			t.Fatalf("did not get expected block hash, got: %s, wanted: %s", block.Hash().String(), test.Hash)
+		}
+		if block.SlotNumber() != test.SlotNumber {
+			t.Fatalf("did not get expected slot number, got: %d, wanted: %d", block.SlotNumber(), test.SlotNumber)
+		}
+		if block.BlockNumber() != test.BlockNumber {
+			t.Fatalf("did not get expected block number, got: %d, wanted: %d", block.BlockNumber(), test.BlockNumber)
+		}
+		if len(block.Transactions()) != test.TxCount {
+			t.Fatalf("did not get expected transaction count, got: %d, wanted: %d", len(block.Transactions()), test.TxCount)
+		}
+	}
+}
+
+var byronBlockHeaderTests = []byronBlockTestDefinition{
+	{
+		BlockType:   ledger.BLOCK_TYPE_BYRON_MAIN,
+		CborHex:     byronMainBlockHeaderMainnetHex,
+		Hash:        "1451a0dbf16cfeddf4991a838961df1b08a68f43a19c0eb3b36cc4029c77a2d8",
+		SlotNumber:  4471207,
+		BlockNumber: 4468973,
+	},
+	{
+		BlockType:   ledger.BLOCK_TYPE_BYRON_EBB,
+		CborHex:     byronEbbHeaderTestnetHex,
+		Hash:        "8f8602837f7c6f8b8867dd1cbc1842cf51a27eaed2c70ef48325d00f8efb320f",
+		SlotNumber:  0,
+		BlockNumber: 0,
+	},
+}
+
+func TestByronBlockHeaderHash(t *testing.T) {
+	for _, test := range byronBlockHeaderTests {
+		cborData, err := hex.DecodeString(test.CborHex)
+		if err != nil {
+			t.Fatalf("failed to decode CBOR hex: %s", err)
+		}
+		header, err := ledger.NewBlockHeaderFromCbor(test.BlockType, cborData)
+		if err != nil {
+			t.Fatalf("failed to decode block header: %s", err)
+		}
+		if header.Hash().String() != test.Hash {
+			t.Fatalf("did not get expected block header hash, got: %s, wanted: %s", header.Hash().String(), test.Hash)
+		}
+		if header.SlotNumber() != test.SlotNumber {
+			t.Fatalf("did not get expected slot number, got: %d, wanted: %d", header.SlotNumber(), test.SlotNumber)
+		}
+		if header.BlockNumber() != test.BlockNumber {
+			t.Fatalf("did not get expected block number, got: %d, wanted: %d", header.BlockNumber(), test.BlockNumber)
+		}
+	}
+}
+
+type byronTransactionTestDefinition struct {
+	Hash      string
+	Inputs    []string
+	Outputs   []byronTransactionOutputTestDefinition
+	Witnesses int
+}
+
+type byronTransactionOutputTestDefinition struct {
+	Address  string
+	Amount   uint64
+	Checksum uint32
+}
+
+// Transactions from mainnet block 1451a0dbf16cfeddf4991a838961df1b08a68f43a19c0eb3b36cc4029c77a2d8
+var byronTransactionTests = []byronTransactionTestDefinition{
+	{
+		Hash: "a0e512aa896f26038077bcc7c20aba4564fe380398058dc7fe65056392c60875",
+		Inputs: []string{
+			"b0a7782d21f37e9d98f4cbdc23bf2677d93eca1ac0fb3f79923863a698d53f8f#1",
+			"5bd3e8385d2ecdd17d3b602263e8a5e7aa0edb4dd00221f369c2720f7d85940d#0",
+			"1e4a77f8375548e5bc409a518dbcb4a8437b539682f4e840f4a1056f01cea566#0",
+			"5e83b53253f705c214d904f65fdaaa2f153db59a229a9cee1da6c329b5432361#0",
+		},
+		Outputs: []byronTransactionOutputTestDefinition{
+			{
+				Address:  "DdzFFzCqrhszvGBcfWZoxTuSXS7whAi5xEyD6pD53x4nXfRhNR4dg15XsTS8LVTH8Njy6VYaASWkbXEouEWr78AsUuUYq6Lf9QCv48Y4",
+				Amount:   5172024689,
+				Checksum: 3165990765,
+			},
+			{
+				Address:  "DdzFFzCqrhsezD7HStRhRVD7ABMa3fSaBMsUYNevx7GQVJjVM9eNnrWZPs1u1HAT8qAUZwcwPHQ1eCa2cyvNiSwi7Vrq3ivZwnMU3oiu",
+				Amount:   805067,
+				Checksum: 665194934,
+			},
+		},
+		Witnesses: 4,
+	},
+	{
+		Hash: "b7f12a75091ac0c5eac7de455fa46e342ba6dd411f1b2f157dc4142128fff71e",
+		Inputs: []string{
+			"779a319e0d64b80eaff5ed13d08062b8672fc71ac27e7b30574c1c7972764de2#2",
+		},
+		Outputs: []byronTransactionOutputTestDefinition{
+			{
+				Address:  "Ae2tdPwUPEZ1CiQXLwkp2VKd1afPtcdAa71ztkQhuPtXYoQdJSdTLmdJ52P",
+				Amount:   1990000000,
+				Checksum: 448629000,
+			},
+			{
+				Address:  "Ae2tdPwUPEYwNguM7TB3dMnZMfZxn1pjGHyGdjaF4mFqZF9L3bj6cdhiH8t",
+				Amount:   97644203079,
+				Checksum: 207562417,
+			},
+		},
+		Witnesses: 1,
+	},
+}
+
+func TestByronTransactionDecode(t *testing.T) {
+	cborData, err := hex.DecodeString(byronMainBlockMainnetHex)
+	if err != nil {
+		t.Fatalf("failed to decode CBOR hex: %s", err)
+	}
+	block, err := ledger.NewByronMainBlockFromCbor(cborData)
+	if err != nil {
+		t.Fatalf("failed to decode block: %s", err)
+	}
+	if len(block.Body.TxPayload) != len(byronTransactionTests) {
+		t.Fatalf("did not get expected transaction count, got: %d, wanted: %d", len(block.Body.TxPayload), len(byronTransactionTests))
+	}
+	for idx, test := range byronTransactionTests {
+		tx := block.Body.TxPayload[idx]
+		if tx.Body.Hash() != test.Hash {
+			t.Fatalf("did not get expected transaction hash, got: %s, wanted: %s", tx.Body.Hash(), test.Hash)
+		}
+		if len(tx.Body.Inputs) != len(test.Inputs) {
+			t.Fatalf("did not get expected input count, got: %d, wanted: %d", len(tx.Body.Inputs), len(test.Inputs))
+		}
+		for inputIdx, input := range tx.Body.Inputs {
+			inputStr := fmt.Sprintf("%s#%d", input.Id.String(), input.Index)
+			if inputStr != test.Inputs[inputIdx] {
+				t.Fatalf("did not get expected input, got: %s, wanted: %s", inputStr, test.Inputs[inputIdx])
+			}
+		}
+		if len(tx.Body.Outputs) != len(test.Outputs) {
+			t.Fatalf("did not get expected output count, got: %d, wanted: %d", len(tx.Body.Outputs), len(test.Outputs))
+		}
+		for outputIdx, output := range tx.Body.Outputs {
+			testOutput := test.Outputs[outputIdx]
+			if output.Address.String() != testOutput.Address {
+				t.Fatalf("did not get expected output address, got: %s, wanted: %s", output.Address.String(), testOutput.Address)
+			}
+			if output.Address.Checksum != testOutput.Checksum {
+				t.Fatalf("did not get expected address checksum, got: %d, wanted: %d", output.Address.Checksum, testOutput.Checksum)
+			}
+			if output.Amount != testOutput.Amount {
+				t.Fatalf("did not get expected output amount, got: %d, wanted: %d", output.Amount, testOutput.Amount)
+			}
+		}
+		if len(tx.Witnesses) != test.Witnesses {
+			t.Fatalf("did not get expected witness count, got: %d, wanted: %d", len(tx.Witnesses), test.Witnesses)
+		}
+		for _, witness := range tx.Witnesses {
+			pkWitness, ok := witness.(*ledger.ByronPkWitness)
+			if !ok {
+				t.Fatalf("did not get expected witness type, got: %T", witness)
+			}
+			if len(pkWitness.PubKey) != 64 || len(pkWitness.Signature) != 64 {
+				t.Fatalf("did not get expected witness sizes, got pubkey: %d, signature: %d", len(pkWitness.PubKey), len(pkWitness.Signature))
+			}
+		}
+	}
+}
+
+func TestByronTransactionWitnessDecode(t *testing.T) {
+	pubKey := make([]byte, 64)
+	signature := make([]byte, 64)
+	script := ledger.ByronScript{Version: 0, Script: []byte{0x01}}
+	testDefs := []struct {
+		witnessType  uint
+		witnessData  interface{}
+		expectedType interface{}
+	}{
+		{
+			witnessType:  ledger.BYRON_TX_WITNESS_TYPE_PK,
+			witnessData:  []interface{}{pubKey, signature},
+			expectedType: &ledger.ByronPkWitness{},
+		},
+		{
+			witnessType:  ledger.BYRON_TX_WITNESS_TYPE_SCRIPT,
+			witnessData:  []interface{}{script, script},
+			expectedType: &ledger.ByronScriptWitness{},
+		},
+		{
+			witnessType:  ledger.BYRON_TX_WITNESS_TYPE_REDEEM,
+			witnessData:  []interface{}{pubKey[:32], signature},
+			expectedType: &ledger.ByronRedeemWitness{},
+		},
+	}
+	for _, testDef := range testDefs {
+		innerCbor, err := cbor.Encode(testDef.witnessData)
+		if err != nil {
+			t.Fatalf("failed to encode witness: %s", err)
+		}
+		witnessCbor, err := cbor.Encode([]interface{}{testDef.witnessType, cbor.Tag{Number: cbor.CBOR_TAG_CBOR, Content: innerCbor}})
+		if err != nil {
+			t.Fatalf("failed to encode witness: %s", err)
+		}
+		witness, err := ledger.NewByronTransactionWitnessFromCbor(witnessCbor)
+		if err != nil {
+			t.Fatalf("failed to decode witness: %s", err)
+		}
+		if reflect.TypeOf(witness) != reflect.TypeOf(testDef.expectedType) {
+			t.Fatalf("did not get expected witness type, got: %T, wanted: %T", witness, testDef.expectedType)
+		}
+	}
+	// Unknown witness types are rejected
+	witnessCbor, err := cbor.Encode([]interface{}{3, cbor.Tag{Number: cbor.CBOR_TAG_CBOR, Content: []byte{0x80}}})
+	if err != nil {
+		t.Fatalf("failed to encode witness: %s", err)
+	}
+	if _, err := ledger.NewByronTransactionWitnessFromCbor(witnessCbor); err == nil {
+		t.Fatalf("did not get expected error for unknown witness type")
+	}
+}
+
+func TestByronAddress(t *testing.T) {
+	// Test vectors from mainnet and the preview testnet
+	testDefs := []struct {
+		addressHex string
+		address    string
+	}{
+		{
+			addressHex: "82d818584283581caf56de241bcca83d72c51e74d18487aa5bc68b45e2caa170fa329d3aa101581e581cea1425ccdd649b25af5deb7e6335da2eb8167353a55e77925122e95f001a3a858621",
+			address:    "DdzFFzCqrht2ii4Vc7KRchSkVvQtCqdGkQt4nF4Yxg1NpsubFBity2Tpt2eSEGrxBH1eva8qCFKM2Y5QkwM1SFBizRwZgz1N452WYvgG",
+		},
+		{
+			addressHex: "82d818582183581c2c0dd53d4e6001e006729fc09d74c5a799d5f93c9f4b74748412a823a0001a1abd8908",
+			address:    "Ae2tdPwUPEZ1CiQXLwkp2VKd1afPtcdAa71ztkQhuPtXYoQdJSdTLmdJ52P",
+		},
+		{
+			addressHex: "82d818582483581c5d5e698eba3dd9452add99a1af9461beb0ba61b8bece26e7399878dda1024102001a36d41aba",
+			address:    "FHnt4NL7yPXvDWHa8bVs73UEUdJd64VxWXSFNqetECtYfTd9TtJguJ14Lu3feth",
+		},
+	}
+	for _, testDef := range testDefs {
+		addrBytes, err := hex.DecodeString(testDef.addressHex)
+		if err != nil {
+			t.Fatalf("failed to decode address hex: %s", err)
+		}
+		var byronAddr ledger.ByronAddress
+		if _, err := cbor.Decode(addrBytes, &byronAddr); err != nil {
+			t.Fatalf("failed to decode address: %s", err)
+		}
+		if byronAddr.String() != testDef.address {
+			t.Fatalf("did not get expected address, got: %s, wanted: %s", byronAddr.String(), testDef.address)
+		}
+		addr, err := ledger.NewAddress(testDef.address)
+		if err != nil {
+			t.Fatalf("failed to decode address: %s", err)
+		}
+		if !bytes.Equal(addr.Bytes(), addrBytes) {
+			t.Fatalf("did not get expected address bytes, got: %x, wanted: %x", addr.Bytes(), addrBytes)
+		}
+		if addr.String() != testDef.address {
+			t.Fatalf("did not get expected address, got: %s, wanted: %s", addr.String(), testDef.address)
+		}
+	}
+}
+
+func TestByronAddressBadChecksum(t *testing.T) {
+	// Mainnet address Ae2tdPwUPEZ1CiQXLwkp2VKd1afPtcdAa71ztkQhuPtXYoQdJSdTLmdJ52P with the last
+	// byte of the CRC32 changed from 0x08 to 0x09
+	addrBytes, err := hex.DecodeString("82d818582183581c2c0dd53d4e6001e006729fc09d74c5a799d5f93c9f4b74748412a823a0001a1abd8909")
+	if err != nil {
+		t.Fatalf("failed to decode address hex: %s", err)
+	}
+	var byronAddr ledger.ByronAddress
+	if _, err := cbor.Decode(addrBytes, &byronAddr); err == nil {
+		t.Fatalf("did not get expected error for bad checksum")
+	}
+	if _, err := ledger.NewAddressFromBytes(addrBytes); err == nil {
+		t.Fatalf("did not get expected error for bad checksum")
+	}
+	// Changing a character in the base58 string also breaks the checksum
+	if _, err := ledger.NewAddress("Ae2tdPwUPEZ1CiQXLwkp2VKd1afPtcdAa71ztkQhuPtXYoQdJSdTLmdJ52Q"); err == nil {
+		t.Fatalf("did not get expected error for bad checksum")
+	}
+	if _, err := ledger.NewAddress("Ae2tdPwUPEZ1CiQXLwkp2VKd1afPtcdAa71ztkQhuPtXYoQdJSdTLmdJ520"); err == nil {
+		t.Fatalf("did not get expected error for invalid base58 character")
+	}
+}
+
+func TestByronMainBlockPayloads(t *testing.T) {
+	testDefs := []struct {
+		cborHex        string
+		sscPayloadType uint
+	}{
+		{
+			cborHex:        byronMainBlockMainnetHex,
+			sscPayloadType: ledger.BYRON_SSC_PAYLOAD_TYPE_CERTIFICATES,
+		},
+		{
+			cborHex:        byronMainBlockTestnetHex,
+			sscPayloadType: ledger.BYRON_SSC_PAYLOAD_TYPE_COMMITMENTS,
+		},
+	}
+	for _, testDef := range testDefs {
+		cborData, err := hex.DecodeString(testDef.cborHex)
+		if err != nil {
+			t.Fatalf("failed to decode CBOR hex: %s", err)
+		}
+		block, err := ledger.NewByronMainBlockFromCbor(cborData)
+		if err != nil {
+			t.Fatalf("failed to decode block: %s", err)
+		}
+		if block.Body.SscPayload.Type != testDef.sscPayloadType {
+			t.Fatalf("did not get expected SSC payload type, got: %d, wanted: %d", block.Body.SscPayload.Type, testDef.sscPayloadType)
+		}
+		if len(block.Body.SscPayload.Certificates) != 0 {
+			t.Fatalf("did not get expected SSC certificate count, got: %d, wanted: 0", len(block.Body.SscPayload.Certificates))
+		}
+		if len(block.Body.DlgPayload) != 0 {
+			t.Fatalf("did not get expected delegation certificate count, got: %d, wanted: 0", len(block.Body.DlgPayload))
+		}
+		if len(block.Body.UpdPayload.Proposals) != 0 || len(block.Body.UpdPayload.Votes) != 0 {
+			t.Fatalf("did not get expected empty update payload: %#v", block.Body.UpdPayload)
+		}
+	}
+}
+
+// The payloads below are built following the Byron CDDL, since the real blocks above only
+// contain empty delegation and update payloads
+
+func TestByronSscPayloadDecode(t *testing.T) {
+	stakeholderId := make([]byte, 28)
+	cert := []interface{}{make([]byte, 33), 207, make([]byte, 64), make([]byte, 64)}
+	certs := cbor.Tag{Number: cbor.CBOR_TAG_SET, Content: []interface{}{cert}}
+	testDefs := []struct {
+		payload     []interface{}
+		payloadType uint
+	}{
+		{
+			payload: []interface{}{
+				ledger.BYRON_SSC_PAYLOAD_TYPE_COMMITMENTS,
+				cbor.Tag{Number: cbor.CBOR_TAG_SET, Content: []interface{}{}},
+				certs,
+			},
+			payloadType: ledger.BYRON_SSC_PAYLOAD_TYPE_COMMITMENTS,
+		},
+		{
+			payload: []interface{}{
+				ledger.BYRON_SSC_PAYLOAD_TYPE_OPENINGS,
+				map[cbor.ByteString][]byte{cbor.NewByteString(stakeholderId): {0x01}},
+				certs,
+			},
+			payloadType: ledger.BYRON_SSC_PAYLOAD_TYPE_OPENINGS,
+		},
+		{
+			payload: []interface{}{
+				ledger.BYRON_SSC_PAYLOAD_TYPE_SHARES,
+				map[cbor.ByteString]interface{}{},
+				certs,
+			},
+			payloadType: ledger.BYRON_SSC_PAYLOAD_TYPE_SHARES,
+		},
+		{
+			payload: []interface{}{
+				ledger.BYRON_SSC_PAYLOAD_TYPE_CERTIFICATES,
+				certs,
+			},
+			payloadType: ledger.BYRON_SSC_PAYLOAD_TYPE_CERTIFICATES,
+		},
+	}
+	for _, testDef := range testDefs {
+		payloadCbor, err := cbor.Encode(testDef.payload)
+		if err != nil {
+			t.Fatalf("failed to encode SSC payload: %s", err)
+		}
+		var payload ledger.ByronSscPayload
+		if _, err := cbor.Decode(payloadCbor, &payload); err != nil {
+			t.Fatalf("failed to decode SSC payload: %s", err)
+		}
+		if payload.Type != testDef.payloadType {
+			t.Fatalf("did not get expected SSC payload type, got: %d, wanted: %d", payload.Type, testDef.payloadType)
+		}
+		if len(payload.Certificates) != 1 || payload.Certificates[0].ExpiryEpoch != 207 {
+			t.Fatalf("did not get expected SSC certificates: %#v", payload.Certificates)
+		}
+		if testDef.payloadType == ledger.BYRON_SSC_PAYLOAD_TYPE_OPENINGS && len(payload.Openings) != 1 {
+			t.Fatalf("did not get expected SSC openings: %#v", payload.Openings)
+		}
+	}
+	// Unknown payload types are rejected
+	payloadCbor, err := cbor.Encode([]interface{}{4, certs})
+	if err != nil {
+		t.Fatalf("failed to encode SSC payload: %s", err)
+	}
+	var payload ledger.ByronSscPayload
+	if _, err := cbor.Decode(payloadCbor, &payload); err == nil {
+		t.Fatalf("did not get expected error for unknown SSC payload type")
+	}
+}
+
+func TestByronDelegationPayloadDecode(t *testing.T) {
+	issuerKey := bytes.Repeat([]byte{0x01}, 64)
+	delegateKey := bytes.Repeat([]byte{0x02}, 64)
+	payloadCbor, err := cbor.Encode([]interface{}{[]interface{}{2, issuerKey, delegateKey, make([]byte, 64)}})
+	if err != nil {
+		t.Fatalf("failed to encode delegation payload: %s", err)
+	}
+	var payload []ledger.ByronDelegationCertificate
+	if _, err := cbor.Decode(payloadCbor, &payload); err != nil {
+		t.Fatalf("failed to decode delegation payload: %s", err)
+	}
+	if len(payload) != 1 {
+		t.Fatalf("did not get expected delegation certificate count, got: %d, wanted: 1", len(payload))
+	}
+	if payload[0].Epoch != 2 || !bytes.Equal(payload[0].IssuerKey, issuerKey) || !bytes.Equal(payload[0].DelegateKey, delegateKey) {
+		t.Fatalf("did not get expected delegation certificate: %#v", payload[0])
+	}
+}
+
+func TestByronUpdatePayloadDecode(t *testing.T) {
+	feePolicyCbor, err := cbor.Encode([]interface{}{155381000000000, 43946000000})
+	if err != nil {
+		t.Fatalf("failed to encode fee policy: %s", err)
+	}
+	none := []interface{}{}
+	proposal := []interface{}{
+		// Block version
+		[]interface{}{1, 0, 0},
+		// Block version modifications
+		[]interface{}{
+			none, none, none, none,
+			[]interface{}{4096},
+			none, none, none, none, none, none, none,
+			[]interface{}{[]interface{}{ledger.BYRON_TX_FEE_POLICY_TYPE_LINEAR, cbor.Tag{Number: cbor.CBOR_TAG_CBOR, Content: feePolicyCbor}}},
+			none,
+		},
+		// Software version
+		[]interface{}{"cardano-sl", 1},
+		map[string]interface{}{
+			"linux": []interface{}{make([]byte, 32), make([]byte, 32), make([]byte, 32), make([]byte, 32)},
+		},
+		map[interface{}]interface{}{},
+		make([]byte, 64),
+		make([]byte, 64),
+	}
+	proposalCbor, err := cbor.Encode(proposal)
+	if err != nil {
+		t.Fatalf("failed to encode update proposal: %s", err)
+	}
+	// Votes reference the proposal by its ID, which is the hash of the proposal CBOR
+	var tmpProposal ledger.ByronUpdateProposal
+	if _, err := cbor.Decode(proposalCbor, &tmpProposal); err != nil {
+		t.Fatalf("failed to decode update proposal: %s", err)
+	}
+	proposalIdBytes, err := hex.DecodeString(tmpProposal.Id())
+	if err != nil {
+		t.Fatalf("failed to decode proposal ID: %s", err)
+	}
+	var proposalId ledger.Blake2b256
+	copy(proposalId[:], proposalIdBytes)
+	vote := []interface{}{make([]byte, 64), proposalIdBytes, true, make([]byte, 64)}
+	payloadCbor, err := cbor.Encode([]interface{}{[]interface{}{cbor.RawMessage(proposalCbor)}, []interface{}{vote}})
+	if err != nil {
+		t.Fatalf("failed to encode update payload: %s", err)
+	}
+	var payload ledger.ByronUpdatePayload
+	if _, err := cbor.Decode(payloadCbor, &payload); err != nil {
+		t.Fatalf("failed to decode update payload: %s", err)
+	}
+	if len(payload.Proposals) != 1 || len(payload.Votes) != 1 {
+		t.Fatalf("did not get expected update payload: %#v", payload)
+	}
+	updateProposal := payload.Proposals[0]
+	if updateProposal.BlockVersion.Major != 1 || updateProposal.SoftwareVersion.Name != "cardano-sl" {
+		t.Fatalf("did not get expected update proposal: %#v", updateProposal)
+	}
+	if len(updateProposal.BlockVersionMod.MaxTxSize) != 1 || updateProposal.BlockVersionMod.MaxTxSize[0] != 4096 {
+		t.Fatalf("did not get expected max TX size: %#v", updateProposal.BlockVersionMod.MaxTxSize)
+	}
+	txFeePolicy := updateProposal.BlockVersionMod.TxFeePolicy
+	if len(txFeePolicy) != 1 || txFeePolicy[0].Summand != 155381000000000 || txFeePolicy[0].Multiplier != 43946000000 {
+		t.Fatalf("did not get expected TX fee policy: %#v", txFeePolicy)
+	}
+	if _, ok := updateProposal.Data["linux"]; !ok {
+		t.Fatalf("did not get expected update data: %#v", updateProposal.Data)
+	}
+	if payload.Votes[0].ProposalId != proposalId || !payload.Votes[0].Vote {
+		t.Fatalf("did not get expected update vote: %#v", payload.Votes[0])
+	}
+	if updateProposal.Id() != proposalId.String() {
+		t.Fatalf("did not get expected proposal ID, got: %s, wanted: %s", updateProposal.Id(), proposalId.String())
+	}
+}
+
+func TestByronGenesisConfigSlotNumber(t *testing.T) {
+	previewConfig := ledger.ByronGenesisConfig{SecurityParam: 432}
+	testDefs := []struct {
+		config     ledger.ByronGenesisConfig
+		epoch      uint64
+		slot       uint64
+		slotNumber uint64
+	}{
+		{
+			config:     ledger.ByronGenesisConfigMainnet,
+			epoch:      0,
+			slot:       0,
+			slotNumber: 0,
+		},
+		{
+			config:     ledger.ByronGenesisConfigMainnet,
+			epoch:      207,
+			slot:       7,
+			slotNumber: 4471207,
+		},
+		{
+			// Last Byron slot on mainnet
+			config:     ledger.ByronGenesisConfigMainnet,
+			epoch:      207,
+			slot:       21599,
+			slotNumber: 4492799,
+		},
+		{
+			config:     previewConfig,
+			epoch:      1,
+			slot:       5,
+			slotNumber: 4325,
+		},
+	}
+	for _, testDef := range testDefs {
+		if slotNumber := testDef.config.SlotNumber(testDef.epoch, testDef.slot); slotNumber != testDef.slotNumber {
+			t.Fatalf("did not get expected slot number, got: %d, wanted: %d", slotNumber, testDef.slotNumber)
+		}
+		epoch, slot := testDef.config.EpochSlot(testDef.slotNumber)
+		if epoch != testDef.epoch || slot != testDef.slot {
+			t.Fatalf("did not get expected epoch and slot, got: %d/%d, wanted: %d/%d", epoch, slot, testDef.epoch, testDef.slot)
+		}
+	}
+}
+
+func TestByronBlockHeaderSlotNumberWithConfig(t *testing.T) {
+	previewConfig := ledger.ByronGenesisConfig{SecurityParam: 432}
+	cborData, err := hex.DecodeString(byronMainBlockHeaderMainnetHex)
+	if err != nil {
+		t.Fatalf("failed to decode CBOR hex: %s", err)
+	}
+	header, err := ledger.NewByronMainBlockHeaderFromCbor(cborData)
+	if err != nil {
+		t.Fatalf("failed to decode block header: %s", err)
+	}
+	if header.SlotNumberWithConfig(ledger.ByronGenesisConfigMainnet) != header.SlotNumber() {
+		t.Fatalf("did not get expected slot number, got: %d, wanted: %d", header.SlotNumberWithConfig(ledger.ByronGenesisConfigMainnet), header.SlotNumber())
+	}
+	// Epoch 207, slot 7 with 4320 slots per epoch
+	if slotNumber := header.SlotNumberWithConfig(previewConfig); slotNumber != 894247 {
+		t.Fatalf("did not get expected slot number, got: %d, wanted: %d", slotNumber, 894247)
+	}
+	cborData, err = hex.DecodeString(byronEbbHeaderTestnetHex)
+	if err != nil {
+		t.Fatalf("failed to decode CBOR hex: %s", err)
+	}
+	ebbHeader, err := ledger.NewByronEpochBoundaryBlockHeaderFromCbor(cborData)
+	if err != nil {
+		t.Fatalf("failed to decode block header: %s", err)
+	}
+	if slotNumber := ebbHeader.SlotNumberWithConfig(previewConfig); slotNumber != 0 {
+		t.Fatalf("did not get expected slot number, got: %d, wanted: %d", slotNumber, 0)
+	}
+}
+
+func TestByronEpochBoundaryBlockHeaderMainnet(t *testing.T) {
+	// A real mainnet boundary block isn't available as a fixture, so this header follows the
+	// mainnet format (protocol magic 764824073, epoch 207) with placeholder hashes
+	prevBlock := bytes.Repeat([]byte{0x11}, 32)
+	bodyProof := bytes.Repeat([]byte{0x22}, 32)
+	headerCbor, err := cbor.Encode(
+		[]interface{}{
+			764824073,
+			prevBlock,
+			bodyProof,
+			[]interface{}{207, []interface{}{4468966}},
+			[]interface{}{map[interface{}]interface{}{}},
+		},
+	)
+	if err != nil {
+		t.Fatalf("failed to encode block header: %s", err)
+	}
+	header, err := ledger.NewByronEpochBoundaryBlockHeaderFromCbor(headerCbor)
+	if err != nil {
+		t.Fatalf("failed to decode block header: %s", err)
+	}
+	if header.ProtocolMagic != 764824073 {
+		t.Fatalf("did not get expected protocol magic, got: %d, wanted: %d", header.ProtocolMagic, 764824073)
+	}
+	if !bytes.Equal(header.PrevBlock[:], prevBlock) {
+		t.Fatalf("did not get expected previous block, got: %x, wanted: %x", header.PrevBlock[:], prevBlock)
+	}
+	// Boundary blocks use the first slot of the epoch
+	if header.SlotNumber() != 4471200 {
+		t.Fatalf("did not get expected slot number, got: %d, wanted: %d", header.SlotNumber(), 4471200)
+	}
+	if header.BlockNumber() != 4468966 {
+		t.Fatalf("did not get expected block number, got: %d, wanted: %d", header.BlockNumber(), 4468966)
+	}
+	// The hash is calculated over [0, header]
+	expectedHash := blake2b.Sum256(append([]byte{0x82, 0x00}, headerCbor...))
+	if header.Hash() != expectedHash {
+		t.Fatalf("did not get expected block header hash, got: %s, wanted: %x", header.Hash().String(), expectedHash)
+	}
+}
@@ -26,6 +26,10 @@ func (bs *ByteString) UnmarshalCBOR(data []byte) error {
 	return nil
 }
 
+func (bs ByteString) MarshalCBOR() ([]byte, error) {
+	return Encode([]byte(bs.data))
+}
+
 func (bs ByteString) Bytes() []byte {
 	return []byte(bs.data)
 }
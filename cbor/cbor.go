@@ -13,17 +13,37 @@ const (
 	CBOR_TYPE_TEXT_STRING uint8 = 0x60
 	CBOR_TYPE_ARRAY       uint8 = 0x80
 	CBOR_TYPE_MAP         uint8 = 0xa0
+	CBOR_TYPE_TAG         uint8 = 0xc0
 
 	// Only the top 3 bytes are used to specify the type
 	CBOR_TYPE_MASK uint8 = 0xe0
 
 	// Max value able to be stored in a single byte without type prefix
 	CBOR_MAX_UINT_SIMPLE uint8 = 0x17
+
+	// Tags for bignums
+	CBOR_TAG_BIGNUM_POS uint64 = 2
+	CBOR_TAG_BIGNUM_NEG uint64 = 3
+
+	// Tag for an encoded CBOR data item wrapped in a bytestring
+	CBOR_TAG_CBOR uint64 = 24
+
+	// Tag for a set, used for some sets starting in Conway
+	CBOR_TAG_SET uint64 = 258
 )
 
 // Create an alias for RawMessage for convenience
 type RawMessage = _cbor.RawMessage
 
+// Alias for Tag for convenience
+type Tag = _cbor.Tag
+
+// Alias for RawTag for convenience
+type RawTag = _cbor.RawTag
+
+// Alias for Marshaler for convenience
+type Marshaler = _cbor.Marshaler
+
 // Useful for embedding and easier to remember
 type StructAsArray struct {
 	// Tells the CBOR decoder to convert to/from a struct and a CBOR array
@@ -43,6 +63,13 @@ func (d *DecodeStoreCbor) Cbor() []byte {
 	return d.cborData
 }
 
+// SetCbor stores a copy of the specified CBOR as the original CBOR for the object. This is useful
+// for types that implement their own UnmarshalCBOR() function
+func (d *DecodeStoreCbor) SetCbor(cborData []byte) {
+	d.cborData = make([]byte, len(cborData))
+	copy(d.cborData, cborData)
+}
+
 // UnmarshalCborGeneric decodes the specified CBOR into the destination object without using the
 // destination object's UnmarshalCBOR() function
 func (d *DecodeStoreCbor) UnmarshalCborGeneric(cborData []byte, dest DecodeStoreCborInterface) error {
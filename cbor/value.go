@@ -6,6 +6,10 @@ type Value struct {
 	Value interface{}
 }
 
+func (v Value) MarshalCBOR() ([]byte, error) {
+	return Encode(v.Value)
+}
+
 func (v *Value) UnmarshalCBOR(data []byte) error {
 	cborType := data[0] & CBOR_TYPE_MASK
 	switch cborType {
@@ -44,6 +48,30 @@ func (v *Value) UnmarshalCBOR(data []byte) error {
 			return err
 		}
 		v.Value = tmpValue
+	case CBOR_TYPE_TAG:
+		// Decode the tag content separately, since it may contain types that the upstream
+		// CBOR library cannot decode into an interface{}
+		tmpTag := RawTag{}
+		if _, err := Decode(data, &tmpTag); err != nil {
+			return err
+		}
+		// Let the upstream CBOR library handle bignums
+		if tmpTag.Number == CBOR_TAG_BIGNUM_POS || tmpTag.Number == CBOR_TAG_BIGNUM_NEG {
+			var tmpValue interface{}
+			if _, err := Decode(data, &tmpValue); err != nil {
+				return err
+			}
+			v.Value = tmpValue
+			return nil
+		}
+		tmpContent := Value{}
+		if _, err := Decode(tmpTag.Content, &tmpContent); err != nil {
+			return err
+		}
+		v.Value = Tag{
+			Number:  tmpTag.Number,
+			Content: tmpContent.Value,
+		}
 	default:
 		var tmpValue interface{}
 		if _, err := Decode(data, &tmpValue); err != nil {
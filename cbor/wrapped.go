@@ -0,0 +1,39 @@
+package cbor
+
+import (
+	"fmt"
+)
+
+// Wrapper for CBOR data that is embedded as a bytestring inside of a tag 24 (encoded CBOR data item)
+type WrappedCbor []byte
+
+func (w *WrappedCbor) UnmarshalCBOR(data []byte) error {
+	var tmpTag Tag
+	if _, err := Decode(data, &tmpTag); err != nil {
+		return err
+	}
+	if tmpTag.Number != CBOR_TAG_CBOR {
+		return fmt.Errorf("unexpected tag number %d, expected %d", tmpTag.Number, CBOR_TAG_CBOR)
+	}
+	content, ok := tmpTag.Content.([]byte)
+	if !ok {
+		return fmt.Errorf("unexpected content type for tag %d: %T", CBOR_TAG_CBOR, tmpTag.Content)
+	}
+	*w = content
+	return nil
+}
+
+// Bytes returns the wrapped CBOR data
+func (w WrappedCbor) Bytes() []byte {
+	return []byte(w)
+}
+
+// Decode decodes the wrapped CBOR data into the destination object
+func (w WrappedCbor) Decode(dest interface{}) error {
+	_, err := Decode(w, dest)
+	return err
+}
+
+func (w WrappedCbor) MarshalCBOR() ([]byte, error) {
+	return Encode(Tag{Number: CBOR_TAG_CBOR, Content: []byte(w)})
+}
@@ -0,0 +1,429 @@
+package ledger
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/cloudstruct/go-cardano-ledger/cbor"
+)
+
+const (
+	CERTIFICATE_TYPE_STAKE_REGISTRATION                 = 0
+	CERTIFICATE_TYPE_STAKE_DEREGISTRATION               = 1
+	CERTIFICATE_TYPE_STAKE_DELEGATION                   = 2
+	CERTIFICATE_TYPE_POOL_REGISTRATION                  = 3
+	CERTIFICATE_TYPE_POOL_RETIREMENT                    = 4
+	CERTIFICATE_TYPE_GENESIS_KEY_DELEGATION             = 5
+	CERTIFICATE_TYPE_MOVE_INSTANTANEOUS_REWARDS         = 6
+	CERTIFICATE_TYPE_REGISTRATION                       = 7
+	CERTIFICATE_TYPE_DEREGISTRATION                     = 8
+	CERTIFICATE_TYPE_VOTE_DELEGATION                    = 9
+	CERTIFICATE_TYPE_STAKE_VOTE_DELEGATION              = 10
+	CERTIFICATE_TYPE_STAKE_REGISTRATION_DELEGATION      = 11
+	CERTIFICATE_TYPE_VOTE_REGISTRATION_DELEGATION       = 12
+	CERTIFICATE_TYPE_STAKE_VOTE_REGISTRATION_DELEGATION = 13
+	CERTIFICATE_TYPE_AUTH_COMMITTEE_HOT                 = 14
+	CERTIFICATE_TYPE_RESIGN_COMMITTEE_COLD              = 15
+	CERTIFICATE_TYPE_REGISTRATION_DREP                  = 16
+	CERTIFICATE_TYPE_DEREGISTRATION_DREP                = 17
+	CERTIFICATE_TYPE_UPDATE_DREP                        = 18
+
+	CREDENTIAL_TYPE_ADDR_KEY_HASH = 0
+	CREDENTIAL_TYPE_SCRIPT_HASH   = 1
+
+	DREP_TYPE_ADDR_KEY_HASH        = 0
+	DREP_TYPE_SCRIPT_HASH          = 1
+	DREP_TYPE_ALWAYS_ABSTAIN       = 2
+	DREP_TYPE_ALWAYS_NO_CONFIDENCE = 3
+
+	POOL_RELAY_TYPE_SINGLE_HOST_ADDRESS = 0
+	POOL_RELAY_TYPE_SINGLE_HOST_NAME    = 1
+	POOL_RELAY_TYPE_MULTI_HOST_NAME     = 2
+
+	MOVE_INSTANTANEOUS_REWARD_SOURCE_RESERVES = 0
+	MOVE_INSTANTANEOUS_REWARD_SOURCE_TREASURY = 1
+)
+
+// Helper type for decoding a certificate of any type
+type CertificateWrapper struct {
+	Type        uint
+	Certificate Certificate
+}
+
+func (c *CertificateWrapper) UnmarshalCBOR(data []byte) error {
+	certType, err := cbor.DecodeIdFromList(data)
+	if err != nil {
+		return err
+	}
+	tmpCert, err := cbor.DecodeById(
+		data,
+		map[int]interface{}{
+			CERTIFICATE_TYPE_STAKE_REGISTRATION:                 &StakeRegistrationCertificate{},
+			CERTIFICATE_TYPE_STAKE_DEREGISTRATION:               &StakeDeregistrationCertificate{},
+			CERTIFICATE_TYPE_STAKE_DELEGATION:                   &StakeDelegationCertificate{},
+			CERTIFICATE_TYPE_POOL_REGISTRATION:                  &PoolRegistrationCertificate{},
+			CERTIFICATE_TYPE_POOL_RETIREMENT:                    &PoolRetirementCertificate{},
+			CERTIFICATE_TYPE_GENESIS_KEY_DELEGATION:             &GenesisKeyDelegationCertificate{},
+			CERTIFICATE_TYPE_MOVE_INSTANTANEOUS_REWARDS:         &MoveInstantaneousRewardsCertificate{},
+			CERTIFICATE_TYPE_REGISTRATION:                       &RegistrationCertificate{},
+			CERTIFICATE_TYPE_DEREGISTRATION:                     &DeregistrationCertificate{},
+			CERTIFICATE_TYPE_VOTE_DELEGATION:                    &VoteDelegationCertificate{},
+			CERTIFICATE_TYPE_STAKE_VOTE_DELEGATION:              &StakeVoteDelegationCertificate{},
+			CERTIFICATE_TYPE_STAKE_REGISTRATION_DELEGATION:      &StakeRegistrationDelegationCertificate{},
+			CERTIFICATE_TYPE_VOTE_REGISTRATION_DELEGATION:       &VoteRegistrationDelegationCertificate{},
+			CERTIFICATE_TYPE_STAKE_VOTE_REGISTRATION_DELEGATION: &StakeVoteRegistrationDelegationCertificate{},
+			CERTIFICATE_TYPE_AUTH_COMMITTEE_HOT:                 &AuthCommitteeHotCertificate{},
+			CERTIFICATE_TYPE_RESIGN_COMMITTEE_COLD:              &ResignCommitteeColdCertificate{},
+			CERTIFICATE_TYPE_REGISTRATION_DREP:                  &RegistrationDrepCertificate{},
+			CERTIFICATE_TYPE_DEREGISTRATION_DREP:                &DeregistrationDrepCertificate{},
+			CERTIFICATE_TYPE_UPDATE_DREP:                        &UpdateDrepCertificate{},
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to decode certificate: %s", err)
+	}
+	c.Type = uint(certType)
+	c.Certificate = tmpCert.(Certificate)
+	return nil
+}
+
+func (c CertificateWrapper) MarshalCBOR() ([]byte, error) {
+	return cbor.Encode(c.Certificate)
+}
+
+type Certificate interface {
+	isCertificate()
+}
+
+type CertificateBase struct {
+	cbor.StructAsArray
+	Type uint
+}
+
+func (c *CertificateBase) isCertificate() {}
+
+// Credential represents a stake credential, committee credential or DRep credential
+type Credential struct {
+	cbor.StructAsArray
+	Type uint
+	Hash Blake2b224
+}
+
+func (c Credential) String() string {
+	switch c.Type {
+	case CREDENTIAL_TYPE_SCRIPT_HASH:
+		return fmt.Sprintf("ScriptHashObj (ScriptHash %s)", c.Hash.String())
+	default:
+		return fmt.Sprintf("KeyHashObj (KeyHash %s)", c.Hash.String())
+	}
+}
+
+func (c Credential) MarshalJSON() ([]byte, error) {
+	switch c.Type {
+	case CREDENTIAL_TYPE_SCRIPT_HASH:
+		return json.Marshal(map[string]string{"scriptHash": c.Hash.String()})
+	default:
+		return json.Marshal(map[string]string{"keyHash": c.Hash.String()})
+	}
+}
+
+// Drep represents the target of a vote delegation. The credential is only present for the
+// key hash and script hash types
+type Drep struct {
+	Type       uint
+	Credential Blake2b224
+}
+
+func (d *Drep) UnmarshalCBOR(data []byte) error {
+	drepType, err := cbor.DecodeIdFromList(data)
+	if err != nil {
+		return err
+	}
+	switch drepType {
+	case DREP_TYPE_ADDR_KEY_HASH, DREP_TYPE_SCRIPT_HASH:
+		var tmpData struct {
+			cbor.StructAsArray
+			Type       uint
+			Credential Blake2b224
+		}
+		if _, err := cbor.Decode(data, &tmpData); err != nil {
+			return err
+		}
+		d.Credential = tmpData.Credential
+	case DREP_TYPE_ALWAYS_ABSTAIN, DREP_TYPE_ALWAYS_NO_CONFIDENCE:
+	default:
+		return fmt.Errorf("unknown DRep type: %d", drepType)
+	}
+	d.Type = uint(drepType)
+	return nil
+}
+
+func (d Drep) MarshalCBOR() ([]byte, error) {
+	switch d.Type {
+	case DREP_TYPE_ADDR_KEY_HASH, DREP_TYPE_SCRIPT_HASH:
+		return cbor.Encode([]interface{}{d.Type, d.Credential})
+	case DREP_TYPE_ALWAYS_ABSTAIN, DREP_TYPE_ALWAYS_NO_CONFIDENCE:
+		return cbor.Encode([]interface{}{d.Type})
+	default:
+		return nil, fmt.Errorf("unknown DRep type: %d", d.Type)
+	}
+}
+
+type GovAnchor struct {
+	cbor.StructAsArray
+	Url      string
+	DataHash Blake2b256
+}
+
+type StakeRegistrationCertificate struct {
+	CertificateBase
+	StakeCredential Credential
+}
+
+type StakeDeregistrationCertificate struct {
+	CertificateBase
+	StakeCredential Credential
+}
+
+type StakeDelegationCertificate struct {
+	CertificateBase
+	StakeCredential Credential
+	PoolKeyHash     Blake2b224
+}
+
+type PoolRegistrationCertificate struct {
+	CertificateBase
+	Operator      Blake2b224
+	VrfKeyHash    Blake2b256
+	Pledge        uint64
+	Cost          uint64
+	Margin        UnitInterval
+	RewardAccount []byte
+	PoolOwners    []Blake2b224
+	Relays        []PoolRelay
+	PoolMetadata  *PoolMetadata
+}
+
+// UnitInterval represents a rational number between 0 and 1
+type UnitInterval struct {
+	cbor.StructAsArray
+	Numerator   uint64
+	Denominator uint64
+}
+
+type PoolMetadata struct {
+	cbor.StructAsArray
+	Url          string
+	MetadataHash Blake2b256
+}
+
+// PoolRelay represents a stake pool relay. Only the fields relevant to the relay type are populated
+type PoolRelay struct {
+	Type     uint
+	Port     *uint32
+	Ipv4     []byte
+	Ipv6     []byte
+	Hostname string
+}
+
+func (r *PoolRelay) UnmarshalCBOR(data []byte) error {
+	relayType, err := cbor.DecodeIdFromList(data)
+	if err != nil {
+		return err
+	}
+	switch relayType {
+	case POOL_RELAY_TYPE_SINGLE_HOST_ADDRESS:
+		var tmpData struct {
+			cbor.StructAsArray
+			Type uint
+			Port *uint32
+			Ipv4 []byte
+			Ipv6 []byte
+		}
+		if _, err := cbor.Decode(data, &tmpData); err != nil {
+			return err
+		}
+		r.Port = tmpData.Port
+		r.Ipv4 = tmpData.Ipv4
+		r.Ipv6 = tmpData.Ipv6
+	case POOL_RELAY_TYPE_SINGLE_HOST_NAME:
+		var tmpData struct {
+			cbor.StructAsArray
+			Type     uint
+			Port     *uint32
+			Hostname string
+		}
+		if _, err := cbor.Decode(data, &tmpData); err != nil {
+			return err
+		}
+		r.Port = tmpData.Port
+		r.Hostname = tmpData.Hostname
+	case POOL_RELAY_TYPE_MULTI_HOST_NAME:
+		var tmpData struct {
+			cbor.StructAsArray
+			Type     uint
+			Hostname string
+		}
+		if _, err := cbor.Decode(data, &tmpData); err != nil {
+			return err
+		}
+		r.Hostname = tmpData.Hostname
+	default:
+		return fmt.Errorf("unknown pool relay type: %d", relayType)
+	}
+	r.Type = uint(relayType)
+	return nil
+}
+
+func (r PoolRelay) MarshalCBOR() ([]byte, error) {
+	// Optional values are encoded as null when not present
+	optionalBytes := func(data []byte) interface{} {
+		if data == nil {
+			return nil
+		}
+		return data
+	}
+	switch r.Type {
+	case POOL_RELAY_TYPE_SINGLE_HOST_ADDRESS:
+		return cbor.Encode([]interface{}{r.Type, r.Port, optionalBytes(r.Ipv4), optionalBytes(r.Ipv6)})
+	case POOL_RELAY_TYPE_SINGLE_HOST_NAME:
+		return cbor.Encode([]interface{}{r.Type, r.Port, r.Hostname})
+	case POOL_RELAY_TYPE_MULTI_HOST_NAME:
+		return cbor.Encode([]interface{}{r.Type, r.Hostname})
+	default:
+		return nil, fmt.Errorf("unknown pool relay type: %d", r.Type)
+	}
+}
+
+type PoolRetirementCertificate struct {
+	CertificateBase
+	PoolKeyHash Blake2b224
+	Epoch       uint64
+}
+
+type GenesisKeyDelegationCertificate struct {
+	CertificateBase
+	GenesisHash         Blake2b224
+	GenesisDelegateHash Blake2b224
+	VrfKeyHash          Blake2b256
+}
+
+type MoveInstantaneousRewardsCertificate struct {
+	CertificateBase
+	Reward MoveInstantaneousReward
+}
+
+// MoveInstantaneousReward contains either a map of stake credentials to reward amounts or an amount to
+// transfer to the other accounting pot
+type MoveInstantaneousReward struct {
+	Source       uint
+	Rewards      map[Credential]int64
+	OtherPotCoin uint64
+}
+
+func (r *MoveInstantaneousReward) UnmarshalCBOR(data []byte) error {
+	var tmpData struct {
+		cbor.StructAsArray
+		Source uint
+		Target cbor.RawMessage
+	}
+	if _, err := cbor.Decode(data, &tmpData); err != nil {
+		return err
+	}
+	r.Source = tmpData.Source
+	if tmpData.Target[0]&cbor.CBOR_TYPE_MASK == cbor.CBOR_TYPE_MAP {
+		if _, err := cbor.Decode(tmpData.Target, &r.Rewards); err != nil {
+			return err
+		}
+	} else {
+		if _, err := cbor.Decode(tmpData.Target, &r.OtherPotCoin); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r MoveInstantaneousReward) MarshalCBOR() ([]byte, error) {
+	if r.Rewards != nil {
+		return cbor.Encode([]interface{}{r.Source, r.Rewards})
+	}
+	return cbor.Encode([]interface{}{r.Source, r.OtherPotCoin})
+}
+
+type RegistrationCertificate struct {
+	CertificateBase
+	StakeCredential Credential
+	Amount          uint64
+}
+
+type DeregistrationCertificate struct {
+	CertificateBase
+	StakeCredential Credential
+	Amount          uint64
+}
+
+type VoteDelegationCertificate struct {
+	CertificateBase
+	StakeCredential Credential
+	Drep            Drep
+}
+
+type StakeVoteDelegationCertificate struct {
+	CertificateBase
+	StakeCredential Credential
+	PoolKeyHash     Blake2b224
+	Drep            Drep
+}
+
+type StakeRegistrationDelegationCertificate struct {
+	CertificateBase
+	StakeCredential Credential
+	PoolKeyHash     Blake2b224
+	Amount          uint64
+}
+
+type VoteRegistrationDelegationCertificate struct {
+	CertificateBase
+	StakeCredential Credential
+	Drep            Drep
+	Amount          uint64
+}
+
+type StakeVoteRegistrationDelegationCertificate struct {
+	CertificateBase
+	StakeCredential Credential
+	PoolKeyHash     Blake2b224
+	Drep            Drep
+	Amount          uint64
+}
+
+type AuthCommitteeHotCertificate struct {
+	CertificateBase
+	ColdCredential Credential
+	HotCredential  Credential
+}
+
+type ResignCommitteeColdCertificate struct {
+	CertificateBase
+	ColdCredential Credential
+	Anchor         *GovAnchor
+}
+
+type RegistrationDrepCertificate struct {
+	CertificateBase
+	DrepCredential Credential
+	Amount         uint64
+	Anchor         *GovAnchor
+}
+
+type DeregistrationDrepCertificate struct {
+	CertificateBase
+	DrepCredential Credential
+	Amount         uint64
+}
+
+type UpdateDrepCertificate struct {
+	CertificateBase
+	DrepCredential Credential
+	Anchor         *GovAnchor
+}
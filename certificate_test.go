@@ -0,0 +1,114 @@
+package ledger_test
+
+import (
+	"encoding/hex"
+	"reflect"
+	"testing"
+
+	"github.com/cloudstruct/go-cardano-ledger"
+	"github.com/cloudstruct/go-cardano-ledger/cbor"
+)
+
+type certificateRoundTripTestDefinition struct {
+	name    string
+	cborHex string
+	newFunc func() interface{}
+}
+
+var certificateRoundTripTests = []certificateRoundTripTestDefinition{
+	{
+		name:    "DRep key hash",
+		cborHex: "8200581cffffffffffffffffffffffffffffffffffffffffffffffffffffffff",
+		newFunc: func() interface{} { return &ledger.Drep{} },
+	},
+	{
+		name:    "DRep script hash",
+		cborHex: "8201581cffffffffffffffffffffffffffffffffffffffffffffffffffffffff",
+		newFunc: func() interface{} { return &ledger.Drep{} },
+	},
+	{
+		name:    "DRep always abstain",
+		cborHex: "8102",
+		newFunc: func() interface{} { return &ledger.Drep{} },
+	},
+	{
+		name:    "DRep always no confidence",
+		cborHex: "8103",
+		newFunc: func() interface{} { return &ledger.Drep{} },
+	},
+	{
+		name:    "pool relay single host address",
+		cborHex: "8400190bb94401020304f6",
+		newFunc: func() interface{} { return &ledger.PoolRelay{} },
+	},
+	{
+		name:    "pool relay single host address without port",
+		cborHex: "8400f6f65020010db8000000000000000000000001",
+		newFunc: func() interface{} { return &ledger.PoolRelay{} },
+	},
+	{
+		name:    "pool relay single host name",
+		cborHex: "8301190bb97172656c61792e6578616d706c652e636f6d",
+		newFunc: func() interface{} { return &ledger.PoolRelay{} },
+	},
+	{
+		name:    "pool relay multi host name",
+		cborHex: "82027172656c61792e6578616d706c652e636f6d",
+		newFunc: func() interface{} { return &ledger.PoolRelay{} },
+	},
+	{
+		name:    "MIR from reserves to stake credentials",
+		cborHex: "8200a28200581caaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa1a000f42408201581caaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa20",
+		newFunc: func() interface{} { return &ledger.MoveInstantaneousReward{} },
+	},
+	{
+		name:    "MIR from treasury to other pot",
+		cborHex: "82011a05f5e100",
+		newFunc: func() interface{} { return &ledger.MoveInstantaneousReward{} },
+	},
+	{
+		name:    "vote delegation certificate",
+		cborHex: "83098200581cdddddddddddddddddddddddddddddddddddddddddddddddddddddddd8102",
+		newFunc: func() interface{} { return &ledger.CertificateWrapper{} },
+	},
+}
+
+func TestCertificateRoundTrip(t *testing.T) {
+	for _, test := range certificateRoundTripTests {
+		cborData, err := hex.DecodeString(test.cborHex)
+		if err != nil {
+			t.Fatalf("%s: failed to decode CBOR hex: %s", test.name, err)
+		}
+		decoded := test.newFunc()
+		if _, err := cbor.Decode(cborData, decoded); err != nil {
+			t.Fatalf("%s: failed to decode: %s", test.name, err)
+		}
+		encoded, err := cbor.Encode(decoded)
+		if err != nil {
+			t.Fatalf("%s: failed to encode: %s", test.name, err)
+		}
+		if hex.EncodeToString(encoded) != test.cborHex {
+			t.Fatalf("%s: did not get expected CBOR, got: %x, wanted: %s", test.name, encoded, test.cborHex)
+		}
+		// Decoding the encoded value again results in the same value
+		redecoded := test.newFunc()
+		if _, err := cbor.Decode(encoded, redecoded); err != nil {
+			t.Fatalf("%s: failed to decode: %s", test.name, err)
+		}
+		if !reflect.DeepEqual(decoded, redecoded) {
+			t.Fatalf("%s: did not get expected value, got: %#v, wanted: %#v", test.name, redecoded, decoded)
+		}
+	}
+}
+
+func TestCertificateEncodeUnknownType(t *testing.T) {
+	testDefs := []interface{}{
+		ledger.Drep{Type: 4},
+		ledger.PoolRelay{Type: 3},
+	}
+	for _, testDef := range testDefs {
+		if _, err := cbor.Encode(testDef); err == nil {
+			t.Fatalf("did not get expected error encoding %#v", testDef)
+		}
+	}
+}
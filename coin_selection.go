@@ -0,0 +1,284 @@
+package ledger
+
+import (
+	"bytes"
+	"errors"
+	"math/rand"
+	"sort"
+
+	"github.com/cloudstruct/go-cardano-ledger/cbor"
+)
+
+var (
+	// ErrInsufficientFunds is returned when the available UTxOs do not cover the target value
+	ErrInsufficientFunds = errors.New("insufficient funds")
+	// ErrMaxInputsExceeded is returned when the target value cannot be covered without exceeding the
+	// maximum number of inputs
+	ErrMaxInputsExceeded = errors.New("maximum number of inputs exceeded")
+)
+
+// CoinSelector selects UTxOs to cover a target value. A maxInputs value of 0 means that there is
+// no limit on the number of selected UTxOs. Implementations must be deterministic for the same
+// arguments
+type CoinSelector interface {
+	Select(utxos []UnspentOutput, target MaryValue, maxInputs int) ([]UnspentOutput, error)
+}
+
+// LargestFirstCoinSelector selects the UTxOs with the largest amount of each native asset in the
+// target, followed by the UTxOs with the most lovelace
+type LargestFirstCoinSelector struct{}
+
+func (s *LargestFirstCoinSelector) Select(utxos []UnspentOutput, target MaryValue, maxInputs int) ([]UnspentOutput, error) {
+	remaining := sortUtxosByCoin(utxos)
+	var selected []UnspentOutput
+	var covered MaryValue
+	for _, asset := range sortedAssetIds(target.Assets) {
+		needed := target.Assets[asset.policyId][asset.assetName]
+		// Order the remaining UTxOs by the quantity of this asset
+		sort.SliceStable(remaining, func(i, j int) bool {
+			return remaining[i].Output.Amount.Assets[asset.policyId][asset.assetName] > remaining[j].Output.Amount.Assets[asset.policyId][asset.assetName]
+		})
+		for covered.Assets[asset.policyId][asset.assetName] < needed {
+			if len(remaining) == 0 || remaining[0].Output.Amount.Assets[asset.policyId][asset.assetName] == 0 {
+				return nil, ErrInsufficientFunds
+			}
+			selected = append(selected, remaining[0])
+			covered = valueAdd(covered, remaining[0].Output.Amount)
+			remaining = remaining[1:]
+		}
+	}
+	remaining = sortUtxosByCoin(remaining)
+	for covered.Coin < target.Coin {
+		if len(remaining) == 0 {
+			return nil, ErrInsufficientFunds
+		}
+		selected = append(selected, remaining[0])
+		covered = valueAdd(covered, remaining[0].Output.Amount)
+		remaining = remaining[1:]
+	}
+	if maxInputs > 0 && len(selected) > maxInputs {
+		return nil, ErrMaxInputsExceeded
+	}
+	return selected, nil
+}
+
+// RandomImproveCoinSelector implements the Random-Improve algorithm from CIP-0002. UTxOs are first
+// selected at random until each asset in the target is covered, and then additional UTxOs are
+// selected at random while they bring the selected amount closer to twice the target amount. The
+// random number generator is seeded with the same seed for each selection, which makes the
+// selection deterministic. If the random selection reaches the maximum number of inputs before
+// covering the target, the selection falls back to LargestFirstCoinSelector
+type RandomImproveCoinSelector struct {
+	seed int64
+}
+
+// NewRandomImproveCoinSelector returns a RandomImproveCoinSelector using the specified seed
+func NewRandomImproveCoinSelector(seed int64) *RandomImproveCoinSelector {
+	return &RandomImproveCoinSelector{seed: seed}
+}
+
+func (s *RandomImproveCoinSelector) Select(utxos []UnspentOutput, target MaryValue, maxInputs int) ([]UnspentOutput, error) {
+	rng := rand.New(rand.NewSource(s.seed))
+	// Sort the UTxOs first so that the selection does not depend on their original order
+	remaining := sortUtxosByCoin(utxos)
+	var selected []UnspentOutput
+	var covered MaryValue
+	assets := sortedAssetIds(target.Assets)
+	// Random selection phase. Selecting at random can use more inputs than needed, so we use the
+	// largest UTxOs instead when the maximum number of inputs is reached
+	maxInputsReached := func() bool {
+		return maxInputs > 0 && len(selected) >= maxInputs
+	}
+	fallbackSelector := &LargestFirstCoinSelector{}
+	for _, asset := range assets {
+		needed := target.Assets[asset.policyId][asset.assetName]
+		for covered.Assets[asset.policyId][asset.assetName] < needed {
+			candidates := utxoIndexesWithAsset(remaining, asset)
+			if len(candidates) == 0 {
+				return nil, ErrInsufficientFunds
+			}
+			if maxInputsReached() {
+				return fallbackSelector.Select(utxos, target, maxInputs)
+			}
+			idx := candidates[rng.Intn(len(candidates))]
+			selected = append(selected, remaining[idx])
+			covered = valueAdd(covered, remaining[idx].Output.Amount)
+			remaining = append(remaining[:idx:idx], remaining[idx+1:]...)
+		}
+	}
+	for covered.Coin < target.Coin {
+		if len(remaining) == 0 {
+			return nil, ErrInsufficientFunds
+		}
+		if maxInputsReached() {
+			return fallbackSelector.Select(utxos, target, maxInputs)
+		}
+		idx := rng.Intn(len(remaining))
+		selected = append(selected, remaining[idx])
+		covered = valueAdd(covered, remaining[idx].Output.Amount)
+		remaining = append(remaining[:idx:idx], remaining[idx+1:]...)
+	}
+	// Improvement phase. Additional UTxOs are selected while they move the selected amount
+	// closer to the ideal amount (twice the target) without going over the maximum amount
+	// (three times the target)
+	improve := func(amountOf func(MaryValue) uint64, candidates []int) {
+		targetAmount := amountOf(target)
+		ideal := targetAmount * 2
+		maxAmount := targetAmount * 3
+		for len(candidates) > 0 {
+			if maxInputsReached() {
+				return
+			}
+			candidateIdx := rng.Intn(len(candidates))
+			idx := candidates[candidateIdx]
+			current := amountOf(covered)
+			next := current + amountOf(remaining[idx].Output.Amount)
+			if next > maxAmount || absDiff(next, ideal) >= absDiff(current, ideal) {
+				return
+			}
+			selected = append(selected, remaining[idx])
+			covered = valueAdd(covered, remaining[idx].Output.Amount)
+			remaining = append(remaining[:idx:idx], remaining[idx+1:]...)
+			// Rebuild the candidate list, since the indexes have changed
+			candidates = append(candidates[:candidateIdx:candidateIdx], candidates[candidateIdx+1:]...)
+			for i := range candidates {
+				if candidates[i] > idx {
+					candidates[i]--
+				}
+			}
+		}
+	}
+	for _, asset := range assets {
+		asset := asset
+		improve(
+			func(v MaryValue) uint64 { return v.Assets[asset.policyId][asset.assetName] },
+			utxoIndexesWithAsset(remaining, asset),
+		)
+	}
+	if target.Coin > 0 {
+		candidates := make([]int, len(remaining))
+		for i := range remaining {
+			candidates[i] = i
+		}
+		improve(func(v MaryValue) uint64 { return v.Coin }, candidates)
+	}
+	return selected, nil
+}
+
+// MinimumInputsCoinSelector selects as few UTxOs as possible. A single UTxO covering the target is
+// used when available, preferring the smallest such UTxO. Otherwise, UTxOs are selected largest
+// first and any that are not needed to cover the target are removed
+type MinimumInputsCoinSelector struct{}
+
+func (s *MinimumInputsCoinSelector) Select(utxos []UnspentOutput, target MaryValue, maxInputs int) ([]UnspentOutput, error) {
+	sorted := sortUtxosByCoin(utxos)
+	// Look for the smallest single UTxO that covers the target
+	for i := len(sorted) - 1; i >= 0; i-- {
+		if valueCovers(sorted[i].Output.Amount, target) {
+			return []UnspentOutput{sorted[i]}, nil
+		}
+	}
+	selector := &LargestFirstCoinSelector{}
+	selected, err := selector.Select(utxos, target, 0)
+	if err != nil {
+		return nil, err
+	}
+	// Remove any UTxOs that aren't needed, starting with the smallest
+	var covered MaryValue
+	for _, utxo := range selected {
+		covered = valueAdd(covered, utxo.Output.Amount)
+	}
+	for i := len(selected) - 1; i >= 0; i-- {
+		tmpCovered := valueSubtract(covered, selected[i].Output.Amount)
+		if valueCovers(tmpCovered, target) {
+			covered = tmpCovered
+			selected = append(selected[:i:i], selected[i+1:]...)
+		}
+	}
+	if maxInputs > 0 && len(selected) > maxInputs {
+		return nil, ErrMaxInputsExceeded
+	}
+	return selected, nil
+}
+
+// SelectCollateral selects UTxOs to use as collateral for a transaction. Only UTxOs containing
+// only lovelace at addresses with a key payment credential are eligible. The smallest single UTxO
+// covering the required amount is preferred, otherwise the UTxOs with the most lovelace are used,
+// up to the maximum number of collateral inputs
+func SelectCollateral(utxos []UnspentOutput, amount uint64, maxCollateralInputs int) ([]UnspentOutput, error) {
+	var eligible []UnspentOutput
+	for _, utxo := range utxos {
+		if len(utxo.Output.Amount.Assets) > 0 {
+			continue
+		}
+		if cred := utxo.Output.Address.PaymentCredential(); cred != nil && cred.Type != CREDENTIAL_TYPE_ADDR_KEY_HASH {
+			continue
+		}
+		if utxo.Output.Address.Type() == ADDRESS_TYPE_NONE_KEY || utxo.Output.Address.Type() == ADDRESS_TYPE_NONE_SCRIPT {
+			continue
+		}
+		eligible = append(eligible, utxo)
+	}
+	selector := &MinimumInputsCoinSelector{}
+	return selector.Select(eligible, MaryValue{Coin: amount}, maxCollateralInputs)
+}
+
+type assetId struct {
+	policyId  Blake2b224
+	assetName cbor.ByteString
+}
+
+// Helper function to return the assets in a MultiAsset in a consistent order
+func sortedAssetIds(assets MultiAsset) []assetId {
+	var ret []assetId
+	for policyId, policyAssets := range assets {
+		for assetName, amount := range policyAssets {
+			if amount == 0 {
+				continue
+			}
+			ret = append(ret, assetId{policyId: policyId, assetName: assetName})
+		}
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		if cmp := bytes.Compare(ret[i].policyId[:], ret[j].policyId[:]); cmp != 0 {
+			return cmp < 0
+		}
+		return bytes.Compare(ret[i].assetName.Bytes(), ret[j].assetName.Bytes()) < 0
+	})
+	return ret
+}
+
+// Helper function to return a copy of the UTxOs sorted by lovelace amount (largest first), using
+// the input to break ties
+func sortUtxosByCoin(utxos []UnspentOutput) []UnspentOutput {
+	ret := make([]UnspentOutput, len(utxos))
+	copy(ret, utxos)
+	sort.Slice(ret, func(i, j int) bool {
+		if ret[i].Output.Amount.Coin != ret[j].Output.Amount.Coin {
+			return ret[i].Output.Amount.Coin > ret[j].Output.Amount.Coin
+		}
+		if cmp := bytes.Compare(ret[i].Input.Id[:], ret[j].Input.Id[:]); cmp != 0 {
+			return cmp < 0
+		}
+		return ret[i].Input.Index < ret[j].Input.Index
+	})
+	return ret
+}
+
+// Helper function to return the indexes of the UTxOs containing the specified asset
+func utxoIndexesWithAsset(utxos []UnspentOutput, asset assetId) []int {
+	var ret []int
+	for i, utxo := range utxos {
+		if utxo.Output.Amount.Assets[asset.policyId][asset.assetName] > 0 {
+			ret = append(ret, i)
+		}
+	}
+	return ret
+}
+
+func absDiff(a uint64, b uint64) uint64 {
+	if a > b {
+		return a - b
+	}
+	return b - a
+}
@@ -0,0 +1,185 @@
+package ledger_test
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/cloudstruct/go-cardano-ledger"
+	"github.com/cloudstruct/go-cardano-ledger/cbor"
+)
+
+var testPolicyId = ledger.Blake2b224{0x11}
+var testAssetName = cbor.NewByteString([]byte("TOKEN"))
+
+func testCoinSelectionUtxos(t *testing.T) []ledger.UnspentOutput {
+	addr := testAddress(t, "addr1vx2fxv2umyhttkxyxp8x0dlpdt3k6cwng5pxj3jhsydzers66hrl8")
+	var ret []ledger.UnspentOutput
+	for i := 1; i <= 20; i++ {
+		amount := ledger.MaryValue{Coin: uint64(i) * 1000000}
+		// Every fifth UTxO also contains some tokens
+		if i%5 == 0 {
+			amount.Assets = ledger.MultiAsset{testPolicyId: {testAssetName: uint64(i)}}
+		}
+		ret = append(ret, testUtxo(addr, byte(i), uint32(i%3), amount))
+	}
+	return ret
+}
+
+func TestCoinSelectors(t *testing.T) {
+	utxos := testCoinSelectionUtxos(t)
+	// Same UTxOs in reverse order, which should not change the selection
+	reversedUtxos := make([]ledger.UnspentOutput, len(utxos))
+	for i, utxo := range utxos {
+		reversedUtxos[len(utxos)-1-i] = utxo
+	}
+	targets := []ledger.MaryValue{
+		{Coin: 5500000},
+		{Coin: 30000000},
+		{Coin: 2000000, Assets: ledger.MultiAsset{testPolicyId: {testAssetName: 25}}},
+	}
+	selectors := map[string]ledger.CoinSelector{
+		"LargestFirst":  &ledger.LargestFirstCoinSelector{},
+		"RandomImprove": ledger.NewRandomImproveCoinSelector(42),
+		"MinimumInputs": &ledger.MinimumInputsCoinSelector{},
+	}
+	for name, selector := range selectors {
+		for _, target := range targets {
+			selected, err := selector.Select(utxos, target, 0)
+			if err != nil {
+				t.Fatalf("%s: failed to select UTxOs for target %s: %s", name, target.String(), err)
+			}
+			var coin, tokens uint64
+			for _, utxo := range selected {
+				coin += utxo.Output.Amount.Coin
+				tokens += utxo.Output.Amount.Assets[testPolicyId][testAssetName]
+			}
+			if coin < target.Coin || tokens < target.Assets[testPolicyId][testAssetName] {
+				t.Fatalf("%s: selected UTxOs do not cover target %s", name, target.String())
+			}
+			tmpSelected, err := selector.Select(reversedUtxos, target, 0)
+			if err != nil {
+				t.Fatalf("%s: failed to select UTxOs for target %s: %s", name, target.String(), err)
+			}
+			if !reflect.DeepEqual(selected, tmpSelected) {
+				t.Fatalf("%s: selection is not deterministic for target %s", name, target.String())
+			}
+		}
+		// The target cannot be met
+		if _, err := selector.Select(utxos, ledger.MaryValue{Coin: 1000000000}, 0); !errors.Is(err, ledger.ErrInsufficientFunds) {
+			t.Fatalf("%s: did not get expected insufficient funds error, got: %v", name, err)
+		}
+		// The target needs more than 2 inputs
+		if _, err := selector.Select(utxos, ledger.MaryValue{Coin: 50000000}, 2); !errors.Is(err, ledger.ErrMaxInputsExceeded) {
+			t.Fatalf("%s: did not get expected max inputs error, got: %v", name, err)
+		}
+	}
+}
+
+func TestLargestFirstCoinSelector(t *testing.T) {
+	utxos := testCoinSelectionUtxos(t)
+	selector := &ledger.LargestFirstCoinSelector{}
+	selected, err := selector.Select(utxos, ledger.MaryValue{Coin: 30000000}, 0)
+	if err != nil {
+		t.Fatalf("failed to select UTxOs: %s", err)
+	}
+	if len(selected) != 2 || selected[0].Output.Amount.Coin != 20000000 || selected[1].Output.Amount.Coin != 19000000 {
+		t.Fatalf("did not get expected UTxOs: %v", selected)
+	}
+}
+
+func TestRandomImproveCoinSelectorMaxInputs(t *testing.T) {
+	addr := testAddress(t, "addr1vx2fxv2umyhttkxyxp8x0dlpdt3k6cwng5pxj3jhsydzers66hrl8")
+	// Many small UTxOs and two large ones that cover the target together
+	var utxos []ledger.UnspentOutput
+	for i := 1; i <= 30; i++ {
+		utxos = append(utxos, testUtxo(addr, byte(i), 0, ledger.MaryValue{Coin: 1000000}))
+	}
+	utxos = append(
+		utxos,
+		testUtxo(addr, 0x31, 0, ledger.MaryValue{Coin: 20000000}),
+		testUtxo(addr, 0x32, 0, ledger.MaryValue{Coin: 20000000}),
+	)
+	target := ledger.MaryValue{Coin: 30000000}
+	selector := ledger.NewRandomImproveCoinSelector(42)
+	// Without a limit, the random selection uses more than 2 inputs
+	selected, err := selector.Select(utxos, target, 0)
+	if err != nil {
+		t.Fatalf("failed to select UTxOs: %s", err)
+	}
+	if len(selected) <= 2 {
+		t.Fatalf("did not get expected number of UTxOs from random selection, got: %d", len(selected))
+	}
+	// With a limit, the selection falls back to the largest UTxOs
+	selected, err = selector.Select(utxos, target, 2)
+	if err != nil {
+		t.Fatalf("failed to select UTxOs: %s", err)
+	}
+	if len(selected) != 2 || selected[0].Output.Amount.Coin != 20000000 || selected[1].Output.Amount.Coin != 20000000 {
+		t.Fatalf("did not get expected UTxOs: %v", selected)
+	}
+}
+
+func TestMinimumInputsCoinSelector(t *testing.T) {
+	utxos := testCoinSelectionUtxos(t)
+	selector := &ledger.MinimumInputsCoinSelector{}
+	selected, err := selector.Select(utxos, ledger.MaryValue{Coin: 5500000}, 0)
+	if err != nil {
+		t.Fatalf("failed to select UTxOs: %s", err)
+	}
+	// The smallest UTxO covering the target on its own
+	if len(selected) != 1 || selected[0].Output.Amount.Coin != 6000000 {
+		t.Fatalf("did not get expected UTxOs: %v", selected)
+	}
+}
+
+func TestSelectCollateral(t *testing.T) {
+	utxos := testCoinSelectionUtxos(t)
+	selected, err := ledger.SelectCollateral(utxos, 19500000, 3)
+	if err != nil {
+		t.Fatalf("failed to select collateral: %s", err)
+	}
+	// The 20 ADA UTxO contains tokens, so it cannot be used as collateral
+	if len(selected) != 2 {
+		t.Fatalf("did not get expected collateral UTxOs: %v", selected)
+	}
+	for _, utxo := range selected {
+		if len(utxo.Output.Amount.Assets) > 0 {
+			t.Fatalf("collateral UTxO contains native assets")
+		}
+	}
+	// Collateral at a script address is not allowed
+	scriptAddr, err := ledger.NewAddressFromParts(ledger.ADDRESS_TYPE_SCRIPT_NONE, ledger.ADDRESS_NETWORK_ID_MAINNET, bytes.Repeat([]byte{0x01}, 28), nil)
+	if err != nil {
+		t.Fatalf("failed to build address: %s", err)
+	}
+	scriptUtxos := []ledger.UnspentOutput{testUtxo(scriptAddr, 0x01, 0, ledger.MaryValue{Coin: 10000000})}
+	if _, err := ledger.SelectCollateral(scriptUtxos, 5000000, 3); !errors.Is(err, ledger.ErrInsufficientFunds) {
+		t.Fatalf("did not get expected insufficient funds error, got: %v", err)
+	}
+}
+
+func TestTxBuilderMaxInputs(t *testing.T) {
+	utxos := testCoinSelectionUtxos(t)
+	addr := utxos[0].Output.Address
+	builder := func(maxInputs int) *ledger.TxBuilder {
+		return ledger.NewTxBuilder(testProtocolParams).
+			AddUtxos(utxos...).
+			AddOutputs(ledger.BabbageTransactionOutput{Address: addr, Amount: ledger.MaryValue{Coin: 30000000}}).
+			SetChangeAddress(addr).
+			SetCoinSelector(&ledger.MinimumInputsCoinSelector{}).
+			SetMaxInputs(maxInputs)
+	}
+	tx, err := builder(2).Build()
+	if err != nil {
+		t.Fatalf("failed to build transaction: %s", err)
+	}
+	if len(tx.Body.Inputs) > 2 {
+		t.Fatalf("transaction has too many inputs: %d", len(tx.Body.Inputs))
+	}
+	checkTxBalance(t, tx, utxos)
+	if _, err := builder(1).Build(); !errors.Is(err, ledger.ErrMaxInputsExceeded) {
+		t.Fatalf("did not get expected max inputs error, got: %v", err)
+	}
+}
@@ -0,0 +1,438 @@
+package ledger
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/cloudstruct/go-cardano-ledger/cbor"
+)
+
+const (
+	ERA_ID_CONWAY = 6
+
+	BLOCK_TYPE_CONWAY = 7
+
+	BLOCK_HEADER_TYPE_CONWAY = 6
+
+	TX_TYPE_CONWAY = 6
+
+	VOTER_TYPE_CONSTITUTIONAL_COMMITTEE_HOT_KEY_HASH    = 0
+	VOTER_TYPE_CONSTITUTIONAL_COMMITTEE_HOT_SCRIPT_HASH = 1
+	VOTER_TYPE_DREP_KEY_HASH                            = 2
+	VOTER_TYPE_DREP_SCRIPT_HASH                         = 3
+	VOTER_TYPE_STAKING_POOL_KEY_HASH                    = 4
+
+	VOTE_NO      = 0
+	VOTE_YES     = 1
+	VOTE_ABSTAIN = 2
+
+	GOV_ACTION_TYPE_PARAMETER_CHANGE     = 0
+	GOV_ACTION_TYPE_HARD_FORK_INITIATION = 1
+	GOV_ACTION_TYPE_TREASURY_WITHDRAWAL  = 2
+	GOV_ACTION_TYPE_NO_CONFIDENCE        = 3
+	GOV_ACTION_TYPE_UPDATE_COMMITTEE     = 4
+	GOV_ACTION_TYPE_NEW_CONSTITUTION     = 5
+	GOV_ACTION_TYPE_INFO                 = 6
+)
+
+type ConwayBlock struct {
+	cbor.StructAsArray
+	cbor.DecodeStoreCbor
+	Header                 *ConwayBlockHeader
+	TransactionBodies      []ConwayTransactionBody
+	TransactionWitnessSets []ConwayTransactionWitnessSet
+	TransactionMetadataSet map[uint]cbor.Value
+	InvalidTransactions    []uint
+}
+
+func (b *ConwayBlock) UnmarshalCBOR(cborData []byte) error {
+	return b.UnmarshalCborGeneric(cborData, b)
+}
+
+func (b *ConwayBlock) Hash() Blake2b256 {
+	return b.Header.Hash()
+}
+
+func (b *ConwayBlock) BlockNumber() uint64 {
+	return b.Header.BlockNumber()
+}
+
+func (b *ConwayBlock) SlotNumber() uint64 {
+	return b.Header.SlotNumber()
+}
+
+func (b *ConwayBlock) Era() Era {
+	return eras[ERA_ID_CONWAY]
+}
+
+func (b *ConwayBlock) Transactions() []TransactionBody {
+	ret := []TransactionBody{}
+	for idx := range b.TransactionBodies {
+		ret = append(ret, &b.TransactionBodies[idx])
+	}
+	return ret
+}
+
+type ConwayBlockHeader struct {
+	BabbageBlockHeader
+}
+
+func (h *ConwayBlockHeader) Era() Era {
+	return eras[ERA_ID_CONWAY]
+}
+
+// ConwayTransactionBody extends the Babbage transaction body with the governance fields. Conway
+// encodes most list fields as sets (tag 258), which decode transparently into the slice fields
+type ConwayTransactionBody struct {
+	BabbageTransactionBody
+	VotingProcedures     VotingProcedures    `cbor:"19,keyasint,omitempty"`
+	ProposalProcedures   []ProposalProcedure `cbor:"20,keyasint,omitempty"`
+	CurrentTreasuryValue uint64              `cbor:"21,keyasint,omitempty"`
+	Donation             uint64              `cbor:"22,keyasint,omitempty"`
+}
+
+func (b *ConwayTransactionBody) UnmarshalCBOR(cborData []byte) error {
+	return b.UnmarshalCborGeneric(cborData, b)
+}
+
+// MarshalCBOR returns the original CBOR for a decoded transaction body, or otherwise encodes the
+// populated fields of the transaction body
+func (b *ConwayTransactionBody) MarshalCBOR() ([]byte, error) {
+	if b.Cbor() != nil {
+		return b.Cbor(), nil
+	}
+	ret := b.cborMap()
+	if len(b.VotingProcedures) > 0 {
+		ret[19] = b.VotingProcedures
+	}
+	if len(b.ProposalProcedures) > 0 {
+		ret[20] = b.ProposalProcedures
+	}
+	if b.CurrentTreasuryValue > 0 {
+		ret[21] = b.CurrentTreasuryValue
+	}
+	if b.Donation > 0 {
+		ret[22] = b.Donation
+	}
+	return cbor.Encode(ret)
+}
+
+// VotingProcedures contains the votes cast in a transaction, keyed by voter and then by the governance
+// action being voted on
+type VotingProcedures map[Voter]map[GovActionId]VotingProcedure
+
+type Voter struct {
+	cbor.StructAsArray
+	Type uint
+	Hash Blake2b224
+}
+
+func (v Voter) String() string {
+	return fmt.Sprintf("Voter (Type %d, Hash %s)", v.Type, v.Hash.String())
+}
+
+type GovActionId struct {
+	cbor.StructAsArray
+	TransactionId Blake2b256
+	GovActionIdx  uint32
+}
+
+func (g GovActionId) String() string {
+	return fmt.Sprintf("GovActionId (TxId %s, Index %d)", g.TransactionId.String(), g.GovActionIdx)
+}
+
+type VotingProcedure struct {
+	cbor.StructAsArray
+	Vote   uint8
+	Anchor *GovAnchor
+}
+
+type ProposalProcedure struct {
+	cbor.StructAsArray
+	Deposit       uint64
+	RewardAccount []byte
+	GovAction     GovActionWrapper
+	Anchor        GovAnchor
+}
+
+// Helper type for decoding a governance action of any type
+type GovActionWrapper struct {
+	Type   uint
+	Action GovAction
+}
+
+func (g *GovActionWrapper) UnmarshalCBOR(data []byte) error {
+	actionType, err := cbor.DecodeIdFromList(data)
+	if err != nil {
+		return err
+	}
+	tmpAction, err := cbor.DecodeById(
+		data,
+		map[int]interface{}{
+			GOV_ACTION_TYPE_PARAMETER_CHANGE:     &ParameterChangeGovAction{},
+			GOV_ACTION_TYPE_HARD_FORK_INITIATION: &HardForkInitiationGovAction{},
+			GOV_ACTION_TYPE_TREASURY_WITHDRAWAL:  &TreasuryWithdrawalGovAction{},
+			GOV_ACTION_TYPE_NO_CONFIDENCE:        &NoConfidenceGovAction{},
+			GOV_ACTION_TYPE_UPDATE_COMMITTEE:     &UpdateCommitteeGovAction{},
+			GOV_ACTION_TYPE_NEW_CONSTITUTION:     &NewConstitutionGovAction{},
+			GOV_ACTION_TYPE_INFO:                 &InfoGovAction{},
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to decode governance action: %s", err)
+	}
+	g.Type = uint(actionType)
+	g.Action = tmpAction.(GovAction)
+	return nil
+}
+
+func (g *GovActionWrapper) MarshalCBOR() ([]byte, error) {
+	return cbor.Encode(g.Action)
+}
+
+type GovAction interface {
+	isGovAction()
+}
+
+type GovActionBase struct {
+	cbor.StructAsArray
+	Type uint
+}
+
+func (g *GovActionBase) isGovAction() {}
+
+type ParameterChangeGovAction struct {
+	GovActionBase
+	PrevActionId *GovActionId
+	ParamUpdate  ConwayProtocolParameterUpdate
+	PolicyHash   *Blake2b224
+}
+
+type HardForkInitiationGovAction struct {
+	GovActionBase
+	PrevActionId    *GovActionId
+	ProtocolVersion ProtocolVersion
+}
+
+type ProtocolVersion struct {
+	cbor.StructAsArray
+	Major uint
+	Minor uint
+}
+
+type TreasuryWithdrawalGovAction struct {
+	GovActionBase
+	// Withdrawal amounts keyed by reward account
+	Withdrawals map[cbor.ByteString]uint64
+	PolicyHash  *Blake2b224
+}
+
+type NoConfidenceGovAction struct {
+	GovActionBase
+	PrevActionId *GovActionId
+}
+
+type UpdateCommitteeGovAction struct {
+	GovActionBase
+	PrevActionId *GovActionId
+	// Cold credentials of the members to remove
+	Removed []Credential
+	// Expiration epochs keyed by the cold credentials of the members to add
+	Added  map[Credential]uint64
+	Quorum UnitInterval
+}
+
+type NewConstitutionGovAction struct {
+	GovActionBase
+	PrevActionId *GovActionId
+	Constitution struct {
+		cbor.StructAsArray
+		Anchor     GovAnchor
+		ScriptHash *Blake2b224
+	}
+}
+
+type InfoGovAction struct {
+	GovActionBase
+}
+
+// ConwayProtocolParameterUpdate contains the proposed protocol parameter changes for a parameter
+// change governance action. Fields that are not being changed are nil
+type ConwayProtocolParameterUpdate struct {
+	MinFeeA                    *uint64               `cbor:"0,keyasint,omitempty"`
+	MinFeeB                    *uint64               `cbor:"1,keyasint,omitempty"`
+	MaxBlockBodySize           *uint32               `cbor:"2,keyasint,omitempty"`
+	MaxTxSize                  *uint32               `cbor:"3,keyasint,omitempty"`
+	MaxBlockHeaderSize         *uint32               `cbor:"4,keyasint,omitempty"`
+	KeyDeposit                 *uint64               `cbor:"5,keyasint,omitempty"`
+	PoolDeposit                *uint64               `cbor:"6,keyasint,omitempty"`
+	MaxEpoch                   *uint32               `cbor:"7,keyasint,omitempty"`
+	NOpt                       *uint32               `cbor:"8,keyasint,omitempty"`
+	PoolPledgeInfluence        *UnitInterval         `cbor:"9,keyasint,omitempty"`
+	ExpansionRate              *UnitInterval         `cbor:"10,keyasint,omitempty"`
+	TreasuryGrowthRate         *UnitInterval         `cbor:"11,keyasint,omitempty"`
+	MinPoolCost                *uint64               `cbor:"16,keyasint,omitempty"`
+	AdaPerUtxoByte             *uint64               `cbor:"17,keyasint,omitempty"`
+	CostModels                 map[uint][]int64      `cbor:"18,keyasint,omitempty"`
+	ExecutionCosts             *ExUnitPrices         `cbor:"19,keyasint,omitempty"`
+	MaxTxExUnits               *ExUnits              `cbor:"20,keyasint,omitempty"`
+	MaxBlockExUnits            *ExUnits              `cbor:"21,keyasint,omitempty"`
+	MaxValueSize               *uint32               `cbor:"22,keyasint,omitempty"`
+	CollateralPercentage       *uint32               `cbor:"23,keyasint,omitempty"`
+	MaxCollateralInputs        *uint32               `cbor:"24,keyasint,omitempty"`
+	PoolVotingThresholds       *PoolVotingThresholds `cbor:"25,keyasint,omitempty"`
+	DrepVotingThresholds       *DrepVotingThresholds `cbor:"26,keyasint,omitempty"`
+	MinCommitteeSize           *uint                 `cbor:"27,keyasint,omitempty"`
+	CommitteeTermLimit         *uint64               `cbor:"28,keyasint,omitempty"`
+	GovActionValidityPeriod    *uint64               `cbor:"29,keyasint,omitempty"`
+	GovActionDeposit           *uint64               `cbor:"30,keyasint,omitempty"`
+	DrepDeposit                *uint64               `cbor:"31,keyasint,omitempty"`
+	DrepInactivityPeriod       *uint64               `cbor:"32,keyasint,omitempty"`
+	MinFeeRefScriptCostPerByte *UnitInterval         `cbor:"33,keyasint,omitempty"`
+}
+
+type PoolVotingThresholds struct {
+	cbor.StructAsArray
+	MotionNoConfidence    UnitInterval
+	CommitteeNormal       UnitInterval
+	CommitteeNoConfidence UnitInterval
+	HardForkInitiation    UnitInterval
+	PpSecurityGroup       UnitInterval
+}
+
+type DrepVotingThresholds struct {
+	cbor.StructAsArray
+	MotionNoConfidence    UnitInterval
+	CommitteeNormal       UnitInterval
+	CommitteeNoConfidence UnitInterval
+	UpdateToConstitution  UnitInterval
+	HardForkInitiation    UnitInterval
+	PpNetworkGroup        UnitInterval
+	PpEconomicGroup       UnitInterval
+	PpTechnicalGroup      UnitInterval
+	PpGovGroup            UnitInterval
+	TreasuryWithdrawal    UnitInterval
+}
+
+type ConwayTransactionWitnessSet struct {
+	ShelleyTransactionWitnessSet
+	PlutusV1Scripts [][]byte         `cbor:"3,keyasint,omitempty"`
+	PlutusData      []cbor.Value     `cbor:"4,keyasint,omitempty"`
+	Redeemers       *ConwayRedeemers `cbor:"5,keyasint,omitempty"`
+	PlutusV2Scripts [][]byte         `cbor:"6,keyasint,omitempty"`
+	PlutusV3Scripts [][]byte         `cbor:"7,keyasint,omitempty"`
+}
+
+// ConwayRedeemers contains the redeemers from a Conway witness set, which can be encoded as
+// either a list or a map keyed by the redeemer tag and index
+type ConwayRedeemers struct {
+	cbor.DecodeStoreCbor
+	Redeemers []Redeemer
+}
+
+type conwayRedeemerKey struct {
+	cbor.StructAsArray
+	Tag   uint8
+	Index uint32
+}
+
+type conwayRedeemerValue struct {
+	cbor.StructAsArray
+	Data    cbor.RawMessage
+	ExUnits ExUnits
+}
+
+func (r *ConwayRedeemers) UnmarshalCBOR(cborData []byte) error {
+	if len(cborData) > 0 && cborData[0]&cbor.CBOR_TYPE_MASK == cbor.CBOR_TYPE_MAP {
+		tmpRedeemers := map[conwayRedeemerKey]conwayRedeemerValue{}
+		if _, err := cbor.Decode(cborData, &tmpRedeemers); err != nil {
+			return err
+		}
+		r.Redeemers = make([]Redeemer, 0, len(tmpRedeemers))
+		for key, value := range tmpRedeemers {
+			r.Redeemers = append(
+				r.Redeemers,
+				Redeemer{
+					Tag:     key.Tag,
+					Index:   key.Index,
+					Data:    value.Data,
+					ExUnits: value.ExUnits,
+				},
+			)
+		}
+		// Map keys are unordered, so we sort the redeemers by tag and index
+		sort.Slice(r.Redeemers, func(i, j int) bool {
+			if r.Redeemers[i].Tag != r.Redeemers[j].Tag {
+				return r.Redeemers[i].Tag < r.Redeemers[j].Tag
+			}
+			return r.Redeemers[i].Index < r.Redeemers[j].Index
+		})
+	} else {
+		if _, err := cbor.Decode(cborData, &r.Redeemers); err != nil {
+			return err
+		}
+	}
+	r.SetCbor(cborData)
+	return nil
+}
+
+func (r ConwayRedeemers) MarshalCBOR() ([]byte, error) {
+	if cborData := r.Cbor(); cborData != nil {
+		return cborData, nil
+	}
+	return cbor.Encode(r.Redeemers)
+}
+
+type ConwayTransaction struct {
+	cbor.StructAsArray
+	cbor.DecodeStoreCbor
+	Body       ConwayTransactionBody
+	WitnessSet ConwayTransactionWitnessSet
+	IsValid    bool
+	Metadata   cbor.Value
+}
+
+func (t *ConwayTransaction) UnmarshalCBOR(cborData []byte) error {
+	return t.UnmarshalCborGeneric(cborData, t)
+}
+
+func (t *ConwayTransaction) MarshalCBOR() ([]byte, error) {
+	// Use the original CBOR for decoded transactions, which keeps the encoding of sets (tag 258)
+	// and map-form redeemers
+	if cborData := t.Cbor(); cborData != nil {
+		return cborData, nil
+	}
+	return cbor.Encode([]interface{}{&t.Body, &t.WitnessSet, t.IsValid, t.Metadata})
+}
+
+func NewConwayBlockFromCbor(data []byte) (*ConwayBlock, error) {
+	var conwayBlock ConwayBlock
+	if _, err := cbor.Decode(data, &conwayBlock); err != nil {
+		return nil, fmt.Errorf("decode error: %s", err)
+	}
+	return &conwayBlock, nil
+}
+
+func NewConwayBlockHeaderFromCbor(data []byte) (*ConwayBlockHeader, error) {
+	var conwayBlockHeader ConwayBlockHeader
+	if _, err := cbor.Decode(data, &conwayBlockHeader); err != nil {
+		return nil, fmt.Errorf("decode error: %s", err)
+	}
+	return &conwayBlockHeader, nil
+}
+
+func NewConwayTransactionBodyFromCbor(data []byte) (*ConwayTransactionBody, error) {
+	var conwayTx ConwayTransactionBody
+	if _, err := cbor.Decode(data, &conwayTx); err != nil {
+		return nil, fmt.Errorf("decode error: %s", err)
+	}
+	return &conwayTx, nil
+}
+
+func NewConwayTransactionFromCbor(data []byte) (*ConwayTransaction, error) {
+	var conwayTx ConwayTransaction
+	if _, err := cbor.Decode(data, &conwayTx); err != nil {
+		return nil, fmt.Errorf("decode error: %s", err)
+	}
+	return &conwayTx, nil
+}
@@ -0,0 +1,183 @@
+package ledger_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/cloudstruct/go-cardano-ledger"
+	"github.com/cloudstruct/go-cardano-ledger/cbor"
+)
+
+// Conway transaction body with inputs and certificates encoded as sets (tag 258), containing DRep
+// registration, committee hot key authorization, vote delegation and stake/vote delegation certificates
+const conwayTxBodyHex = "a600d9010281825820111111111111111111111111111111111111111111111111111111111111111100018182581d61222222222222222222222222222222222222222222222222222222221a000f4240021a00030d4004d901028484108200581caaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa1a1dcd6500f6830e8200581cbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb8201581ccccccccccccccccccccccccccccccccccccccccccccccccccccccccc83098200581cdddddddddddddddddddddddddddddddddddddddddddddddddddddddd8102840a8200581cdddddddddddddddddddddddddddddddddddddddddddddddddddddddd581ceeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeee8200581cffffffffffffffffffffffffffffffffffffffffffffffffffffffff151903e816191388"
+
+func TestConwayTransactionBodyDecode(t *testing.T) {
+	cborData, err := hex.DecodeString(conwayTxBodyHex)
+	if err != nil {
+		t.Fatalf("failed to decode CBOR hex: %s", err)
+	}
+	txBody, err := ledger.NewConwayTransactionBodyFromCbor(cborData)
+	if err != nil {
+		t.Fatalf("failed to decode transaction body: %s", err)
+	}
+	if len(txBody.Inputs) != 1 || txBody.Inputs[0].Index != 0 {
+		t.Fatalf("did not get expected inputs: %#v", txBody.Inputs)
+	}
+	if txBody.Fee != 200000 {
+		t.Fatalf("did not get expected fee, got: %d, wanted: %d", txBody.Fee, 200000)
+	}
+	if txBody.CurrentTreasuryValue != 1000 {
+		t.Fatalf("did not get expected current treasury value, got: %d, wanted: %d", txBody.CurrentTreasuryValue, 1000)
+	}
+	if txBody.Donation != 5000 {
+		t.Fatalf("did not get expected donation, got: %d, wanted: %d", txBody.Donation, 5000)
+	}
+	if len(txBody.Certificates) != 4 {
+		t.Fatalf("did not get expected certificate count, got: %d, wanted: %d", len(txBody.Certificates), 4)
+	}
+	regDrep, ok := txBody.Certificates[0].Certificate.(*ledger.RegistrationDrepCertificate)
+	if !ok {
+		t.Fatalf("did not get expected certificate type: %T", txBody.Certificates[0].Certificate)
+	}
+	if regDrep.Amount != 500000000 || regDrep.Anchor != nil || regDrep.DrepCredential.Hash.String() != "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa" {
+		t.Fatalf("did not get expected DRep registration certificate: %#v", regDrep)
+	}
+	authHot, ok := txBody.Certificates[1].Certificate.(*ledger.AuthCommitteeHotCertificate)
+	if !ok {
+		t.Fatalf("did not get expected certificate type: %T", txBody.Certificates[1].Certificate)
+	}
+	if authHot.HotCredential.Type != ledger.CREDENTIAL_TYPE_SCRIPT_HASH {
+		t.Fatalf("did not get expected hot credential type, got: %d, wanted: %d", authHot.HotCredential.Type, ledger.CREDENTIAL_TYPE_SCRIPT_HASH)
+	}
+	voteDeleg, ok := txBody.Certificates[2].Certificate.(*ledger.VoteDelegationCertificate)
+	if !ok {
+		t.Fatalf("did not get expected certificate type: %T", txBody.Certificates[2].Certificate)
+	}
+	if voteDeleg.Drep.Type != ledger.DREP_TYPE_ALWAYS_ABSTAIN {
+		t.Fatalf("did not get expected DRep type, got: %d, wanted: %d", voteDeleg.Drep.Type, ledger.DREP_TYPE_ALWAYS_ABSTAIN)
+	}
+	stakeVoteDeleg, ok := txBody.Certificates[3].Certificate.(*ledger.StakeVoteDelegationCertificate)
+	if !ok {
+		t.Fatalf("did not get expected certificate type: %T", txBody.Certificates[3].Certificate)
+	}
+	if stakeVoteDeleg.Drep.Type != ledger.DREP_TYPE_ADDR_KEY_HASH || stakeVoteDeleg.Drep.Credential.String() != "ffffffffffffffffffffffffffffffffffffffffffffffffffffffff" {
+		t.Fatalf("did not get expected DRep: %#v", stakeVoteDeleg.Drep)
+	}
+	if hex.EncodeToString(txBody.Cbor()) != conwayTxBodyHex {
+		t.Fatalf("original CBOR was not preserved")
+	}
+}
+
+// Conway transaction body containing votes from a DRep and an SPO and proposals for a treasury
+// withdrawal, committee update, parameter change and info action
+const conwayGovTxBodyHex = "a500d90102818258201111111111111111111111111111111111111111111111111111111111111111000180021a00030d4013a28202581c66666666666666666666666666666666666666666666666666666666a18258205555555555555555555555555555555555555555555555555555555555555555018201f68204581c77777777777777777777777777777777777777777777777777777777a1825820555555555555555555555555555555555555555555555555555555555555555501820082781a68747470733a2f2f6578616d706c652e636f6d2f612e6a736f6e5820444444444444444444444444444444444444444444444444444444444444444414d9010284841b000000174876e800581de1333333333333333333333333333333333333333333333333333333338302a1581de1333333333333333333333333333333333333333333333333333333331a004c4b40f682781a68747470733a2f2f6578616d706c652e636f6d2f612e6a736f6e58204444444444444444444444444444444444444444444444444444444444444444841b000000174876e800581de1333333333333333333333333333333333333333333333333333333338504825820555555555555555555555555555555555555555555555555555555555555555501d90102818200581c88888888888888888888888888888888888888888888888888888888a18201581c99999999999999999999999999999999999999999999999999999999190258d81e82020382781a68747470733a2f2f6578616d706c652e636f6d2f612e6a736f6e58204444444444444444444444444444444444444444444444444444444444444444841b000000174876e800581de1333333333333333333333333333333333333333333333333333333338400f6a200182c1821d81e820f01581cabababababababababababababababababababababababababababab82781a68747470733a2f2f6578616d706c652e636f6d2f612e6a736f6e58204444444444444444444444444444444444444444444444444444444444444444841b000000174876e800581de133333333333333333333333333333333333333333333333333333333810682781a68747470733a2f2f6578616d706c652e636f6d2f612e6a736f6e58204444444444444444444444444444444444444444444444444444444444444444"
+
+func TestConwayGovernanceDecode(t *testing.T) {
+	cborData, err := hex.DecodeString(conwayGovTxBodyHex)
+	if err != nil {
+		t.Fatalf("failed to decode CBOR hex: %s", err)
+	}
+	txBody, err := ledger.NewConwayTransactionBodyFromCbor(cborData)
+	if err != nil {
+		t.Fatalf("failed to decode transaction body: %s", err)
+	}
+	var govActionId ledger.GovActionId
+	copy(govActionId.TransactionId[:], bytes.Repeat([]byte{0x55}, 32))
+	govActionId.GovActionIdx = 1
+	var drepVoter ledger.Voter
+	drepVoter.Type = ledger.VOTER_TYPE_DREP_KEY_HASH
+	copy(drepVoter.Hash[:], bytes.Repeat([]byte{0x66}, 28))
+	drepVote, ok := txBody.VotingProcedures[drepVoter][govActionId]
+	if !ok {
+		t.Fatalf("did not find expected DRep vote: %#v", txBody.VotingProcedures)
+	}
+	if drepVote.Vote != ledger.VOTE_YES || drepVote.Anchor != nil {
+		t.Fatalf("did not get expected DRep vote: %#v", drepVote)
+	}
+	var spoVoter ledger.Voter
+	spoVoter.Type = ledger.VOTER_TYPE_STAKING_POOL_KEY_HASH
+	copy(spoVoter.Hash[:], bytes.Repeat([]byte{0x77}, 28))
+	spoVote, ok := txBody.VotingProcedures[spoVoter][govActionId]
+	if !ok {
+		t.Fatalf("did not find expected SPO vote: %#v", txBody.VotingProcedures)
+	}
+	if spoVote.Vote != ledger.VOTE_NO || spoVote.Anchor == nil || spoVote.Anchor.Url != "https://example.com/a.json" {
+		t.Fatalf("did not get expected SPO vote: %#v", spoVote)
+	}
+	if len(txBody.ProposalProcedures) != 4 {
+		t.Fatalf("did not get expected proposal count, got: %d, wanted: %d", len(txBody.ProposalProcedures), 4)
+	}
+	withdrawal, ok := txBody.ProposalProcedures[0].GovAction.Action.(*ledger.TreasuryWithdrawalGovAction)
+	if !ok {
+		t.Fatalf("did not get expected governance action type: %T", txBody.ProposalProcedures[0].GovAction.Action)
+	}
+	for rewardAccount, amount := range withdrawal.Withdrawals {
+		if rewardAccount.String() != "e133333333333333333333333333333333333333333333333333333333" || amount != 5000000 {
+			t.Fatalf("did not get expected treasury withdrawal: %s => %d", rewardAccount.String(), amount)
+		}
+	}
+	committee, ok := txBody.ProposalProcedures[1].GovAction.Action.(*ledger.UpdateCommitteeGovAction)
+	if !ok {
+		t.Fatalf("did not get expected governance action type: %T", txBody.ProposalProcedures[1].GovAction.Action)
+	}
+	if committee.PrevActionId == nil || *committee.PrevActionId != govActionId {
+		t.Fatalf("did not get expected previous action ID: %#v", committee.PrevActionId)
+	}
+	if len(committee.Removed) != 1 || len(committee.Added) != 1 || committee.Quorum.Numerator != 2 || committee.Quorum.Denominator != 3 {
+		t.Fatalf("did not get expected committee update: %#v", committee)
+	}
+	paramChange, ok := txBody.ProposalProcedures[2].GovAction.Action.(*ledger.ParameterChangeGovAction)
+	if !ok {
+		t.Fatalf("did not get expected governance action type: %T", txBody.ProposalProcedures[2].GovAction.Action)
+	}
+	if paramChange.ParamUpdate.MinFeeA == nil || *paramChange.ParamUpdate.MinFeeA != 44 || paramChange.ParamUpdate.MinFeeB != nil {
+		t.Fatalf("did not get expected parameter update: %#v", paramChange.ParamUpdate)
+	}
+	if _, ok := txBody.ProposalProcedures[3].GovAction.Action.(*ledger.InfoGovAction); !ok {
+		t.Fatalf("did not get expected governance action type: %T", txBody.ProposalProcedures[3].GovAction.Action)
+	}
+}
+
+// Mainnet Conway transaction a0aeaf016f56434bca3293f6bb0cfc1a3f54b13509b892a325d0fa2acc4c8843, which
+// encodes its inputs and witnesses as sets (tag 258) and contains redeemers
+const conwayMainnetTxHex = "84ad00d901028382582010192dc4209a99189a8bb3b4ec1bfc72abb66941b36dade154ab43cfc4f82477018258204c15c23b69450168ed7a920fa91ee2547dc9c36a85a24b3e8bdc8111eb761d9302825820d3733670d1bbb0b44d6e1424d796442c27d4f87e526766afb91f16a95d743bd900018382583901a899bc44776965fc83d8d2c5ada95f22d08ef6de17bf12b558ab9f56b297dd932ba109c09287b4df30ef3dd2c3f6480b37aeeec1fa20d33f1a72f9541da300583911ea07b733d932129c378af627436e7cbc2ef0bf96e0036bb51b3bde6b52563c5410bff6a0d43ccebb7c37e1f69f5eb260552521adff33b9c201821b0000002b4b1c8479a2581c97bbb7db0baef89caefce61b8107ac74c7a7340166b39d906f174beca14554616c6f731a018b4c81581cf5808c2c990d86da54bfc97d89cee6efa20cd8461616359478d96b4ca2434d5350015820cc92eb089f1308c6cd9f55298ca716a66aa84534ea6ad6c6c5d9ffbbcad792811b7fffffffd6e7aa74028201d818587bd8799fd8799fd87a9f581c1eae96baf29e27682ea3f815aba361a0c6059d45e4bfbe95bbd2f44affffd8799f4040ffd8799f581c97bbb7db0baef89caefce61b8107ac74c7a7340166b39d906f174bec4554616c6f73ff1a291855951b0000002b48cba5b01a018b1f6a19012c19012cd8799f190e52ffd87980ff825839015b7e23228dba75595645fc357d0f97ba258cfccfff5d588d4bb9165b533b9586f0fb9aafd578e0d0154e9478d23614e736eb39d1a30d8a991a13d363f9021a000a26f3031a0986e4e705a1581df11eae96baf29e27682ea3f815aba361a0c6059d45e4bfbe95bbd2f44a000758205160f88b929bf8a6c57c285b889488f9137c0ef3cfd0bcf408a10020e69146d5081a0986e4330b5820ebefd2e645c467ba2ff8c89231093039c55b3ba8198ed9217179e76c572169ad0dd90102818258204c15c23b69450168ed7a920fa91ee2547dc9c36a85a24b3e8bdc8111eb761d93020ed9010281581c5b7e23228dba75595645fc357d0f97ba258cfccfff5d588d4bb9165b10825839015b7e23228dba75595645fc357d0f97ba258cfccfff5d588d4bb9165b533b9586f0fb9aafd578e0d0154e9478d23614e736eb39d1a30d8a991a1386914c111a004c4b4012d90102848258200dc17712e37a4e741767db2f90d4ffbf69faf88b9bed4c47864f7bd912924bea00825820cf4ecddde0d81f9ce8fcc881a85eb1f8ccdaf6807f03fea4cd02da896a621776008258202536194d2a976370a932174c10975493ab58fd7c16395d50e62b7c0e1949baea00825820d46bd227bd2cf93dedd22ae9b6d92d30140cf0d68b756f6608e38d680c61ad1700a200d9010281825820c5d63d7dc066df52592135b6d3cb4f3470d06f7bdd4b2d2e32eb59ca3782662f5840bcd7bc9405a6c0369040bc3b7906afa3e6b30fde99503e768aab0f261ebadd6b2cf4f382efcec390a88318f001f3bb9313378b83b4d1c767d13cd583effe29060583840002d87980821962d91a007cc793840000d87980821a00012dfc1a0166fa60840300d8799f009f1a000aae60ff4100d87a809fd87a80ffff821a001465aa1a19a0dcc6f5a11902a2a1636d736781774d696e737761703a204f72646572204578656375746564"
+
+func TestConwayTransactionRoundTrip(t *testing.T) {
+	testDefs := []struct {
+		TxHex     string
+		Redeemers int
+	}{
+		{
+			TxHex:     conwayMainnetTxHex,
+			Redeemers: 3,
+		},
+		// The transaction body above with a witness set containing a single redeemer in map form
+		{
+			TxHex:     "84" + conwayTxBodyHex + "a105a18200008200821903e81907d0f5f6",
+			Redeemers: 1,
+		},
+	}
+	for _, test := range testDefs {
+		txCbor, err := hex.DecodeString(test.TxHex)
+		if err != nil {
+			t.Fatalf("failed to decode CBOR hex: %s", err)
+		}
+		tx, err := ledger.NewConwayTransactionFromCbor(txCbor)
+		if err != nil {
+			t.Fatalf("failed to decode transaction: %s", err)
+		}
+		if tx.WitnessSet.Redeemers == nil || len(tx.WitnessSet.Redeemers.Redeemers) != test.Redeemers {
+			t.Fatalf("did not get expected redeemers: %#v", tx.WitnessSet.Redeemers)
+		}
+		// The transaction is re-encoded exactly as it was decoded
+		encoded, err := cbor.Encode(tx)
+		if err != nil {
+			t.Fatalf("failed to encode transaction: %s", err)
+		}
+		if !bytes.Equal(encoded, txCbor) {
+			t.Fatalf("did not get original CBOR\n  got:    %x\n  wanted: %x", encoded, txCbor)
+		}
+	}
+}
@@ -30,6 +30,10 @@ var eras = map[uint8]Era{
 		Id:   ERA_ID_BABBAGE,
 		Name: "Babbage",
 	},
+	ERA_ID_CONWAY: Era{
+		Id:   ERA_ID_CONWAY,
+		Name: "Conway",
+	},
 }
 
 func GetEraById(eraId uint8) *Era {
@@ -39,3 +43,12 @@ func GetEraById(eraId uint8) *Era {
 	}
 	return &era
 }
+
+func GetEraByName(eraName string) *Era {
+	for _, era := range eras {
+		if era.Name == eraName {
+			return &era
+		}
+	}
+	return nil
+}
@@ -36,6 +36,10 @@ var getEraByIdTests = []getEraByIdTestDefinition{
 		Id:   5,
 		Name: "Babbage",
 	},
+	{
+		Id:   6,
+		Name: "Conway",
+	},
 	{
 		Id:        99,
 		ExpectNil: true,
@@ -56,3 +60,21 @@ func TestGetEraById(t *testing.T) {
 		}
 	}
 }
+
+func TestGetEraByName(t *testing.T) {
+	for _, test := range getEraByIdTests {
+		if test.ExpectNil {
+			continue
+		}
+		era := ledger.GetEraByName(test.Name)
+		if era == nil {
+			t.Fatalf("got unexpected nil for era name %s", test.Name)
+		}
+		if era.Id != test.Id {
+			t.Fatalf("did not get expected era ID for name %s, got: %d, wanted: %d", test.Name, era.Id, test.Id)
+		}
+	}
+	if era := ledger.GetEraByName("Unknown"); era != nil {
+		t.Fatalf("got unexpected era for unknown name: %s", era.Name)
+	}
+}
@@ -1,16 +1,69 @@
 package ledger
 
 import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
 	"github.com/cloudstruct/go-cardano-ledger/cbor"
 )
 
 const (
-	APPLY_TX_ERROR_UTXOW_FAILURE = 0
-
-	UTXOW_FAILURE_UTXO_FAILURE = 2
-
-	UTXO_FAILURE_FROM_ALONZO = 1
+	APPLY_TX_ERROR_UTXOW_FAILURE  = 0
+	APPLY_TX_ERROR_DELEGS_FAILURE = 1
+
+	BABBAGE_UTXOW_FAILURE_ALONZO_IN_BABBAGE           = 1
+	UTXOW_FAILURE_UTXO_FAILURE                        = 2
+	BABBAGE_UTXOW_FAILURE_MALFORMED_SCRIPT_WITNESSES  = 3
+	BABBAGE_UTXOW_FAILURE_MALFORMED_REFERENCE_SCRIPTS = 4
+
+	ALONZO_UTXOW_FAILURE_SHELLEY_IN_ALONZO               = 0
+	ALONZO_UTXOW_FAILURE_MISSING_REDEEMERS               = 1
+	ALONZO_UTXOW_FAILURE_MISSING_REQUIRED_DATUMS         = 2
+	ALONZO_UTXOW_FAILURE_NOT_ALLOWED_SUPPLEMENTAL_DATUMS = 3
+	ALONZO_UTXOW_FAILURE_PP_VIEW_HASHES_DONT_MATCH       = 4
+	ALONZO_UTXOW_FAILURE_MISSING_REQUIRED_SIGNERS        = 5
+	ALONZO_UTXOW_FAILURE_UNSPENDABLE_UTXO_NO_DATUM_HASH  = 6
+	ALONZO_UTXOW_FAILURE_EXTRA_REDEEMERS                 = 7
+
+	SHELLEY_UTXOW_FAILURE_INVALID_WITNESSES_UTXOW             = 0
+	SHELLEY_UTXOW_FAILURE_MISSING_VKEY_WITNESSES_UTXOW        = 1
+	SHELLEY_UTXOW_FAILURE_MISSING_SCRIPT_WITNESSES_UTXOW      = 2
+	SHELLEY_UTXOW_FAILURE_SCRIPT_WITNESS_NOT_VALIDATING_UTXOW = 3
+	SHELLEY_UTXOW_FAILURE_UTXO_FAILURE                        = 4
+	SHELLEY_UTXOW_FAILURE_MIR_INSUFFICIENT_GENESIS_SIGS_UTXOW = 5
+	SHELLEY_UTXOW_FAILURE_MISSING_TX_BODY_METADATA_HASH       = 6
+	SHELLEY_UTXOW_FAILURE_MISSING_TX_METADATA                 = 7
+	SHELLEY_UTXOW_FAILURE_CONFLICTING_METADATA_HASH           = 8
+	SHELLEY_UTXOW_FAILURE_INVALID_METADATA                    = 9
+	SHELLEY_UTXOW_FAILURE_EXTRANEOUS_SCRIPT_WITNESSES_UTXOW   = 10
+
+	UTXO_FAILURE_FROM_ALONZO                              = 1
+	BABBAGE_UTXO_FAILURE_INCORRECT_TOTAL_COLLATERAL_FIELD = 2
+	BABBAGE_UTXO_FAILURE_BABBAGE_OUTPUT_TOO_SMALL_UTXO    = 3
+	BABBAGE_UTXO_FAILURE_BABBAGE_NON_DISJOINT_REF_INPUTS  = 4
+
+	SHELLEY_UTXO_FAILURE_BAD_INPUTS_UTXO                = 0
+	SHELLEY_UTXO_FAILURE_EXPIRED_UTXO                   = 1
+	SHELLEY_UTXO_FAILURE_MAX_TX_SIZE_UTXO               = 2
+	SHELLEY_UTXO_FAILURE_INPUT_SET_EMPTY                = 3
+	SHELLEY_UTXO_FAILURE_FEE_TOO_SMALL_UTXO             = 4
+	SHELLEY_UTXO_FAILURE_VALUE_NOT_CONSERVED_UTXO       = 5
+	SHELLEY_UTXO_FAILURE_OUTPUT_TOO_SMALL_UTXO          = 6
+	SHELLEY_UTXO_FAILURE_UPDATE_FAILURE                 = 7
+	SHELLEY_UTXO_FAILURE_WRONG_NETWORK                  = 8
+	SHELLEY_UTXO_FAILURE_WRONG_NETWORK_WITHDRAWAL       = 9
+	SHELLEY_UTXO_FAILURE_OUTPUT_BOOT_ADDR_ATTRS_TOO_BIG = 10
+
+	// Allegra and Mary use the Shelley numbering with a validity interval in place
+	// of the TTL and a couple of additional failures
+	ALLEGRA_UTXO_FAILURE_OUTSIDE_VALIDITY_INTERVAL_UTXO = 1
+	ALLEGRA_UTXO_FAILURE_TRIES_TO_FORGE_ADA             = 11
+	ALLEGRA_UTXO_FAILURE_OUTPUT_TOO_BIG_UTXO            = 12
 
 	UTXO_FAILURE_BAD_INPUTS_UTXO                = 0
 	UTXO_FAILURE_OUTSIDE_VALIDITY_INTERVAL_UTXO = 1
@@ -33,6 +86,59 @@ const (
 	UTXO_FAILURE_OUTSIDE_FORECAST               = 18
 	UTXO_FAILURE_TOO_MANY_COLLATERAL_INPUTS     = 19
 	UTXO_FAILURE_NO_COLLATERAL_INPUTS           = 20
+
+	POOL_FAILURE_STAKE_POOL_NOT_REGISTERED_ON_KEY  = 0
+	POOL_FAILURE_STAKE_POOL_RETIREMENT_WRONG_EPOCH = 1
+	POOL_FAILURE_WRONG_CERTIFICATE_TYPE            = 2
+	POOL_FAILURE_STAKE_POOL_COST_TOO_LOW           = 3
+	POOL_FAILURE_WRONG_NETWORK                     = 4
+	POOL_FAILURE_POOL_METADATA_HASH_TOO_BIG        = 5
+
+	UTXOS_FAILURE_VALIDATION_TAG_MISMATCH = 0
+	UTXOS_FAILURE_COLLECT_ERRORS          = 1
+	UTXOS_FAILURE_UPDATE_FAILURE          = 2
+
+	TAG_MISMATCH_DESCRIPTION_PASSED_UNEXPECTEDLY = 0
+	TAG_MISMATCH_DESCRIPTION_FAILED_UNEXPECTEDLY = 1
+
+	FAILURE_DESCRIPTION_ONE_PHASE_FAILURE = 0
+	FAILURE_DESCRIPTION_PLUTUS_FAILURE    = 1
+
+	COLLECT_ERROR_NO_REDEEMER     = 0
+	COLLECT_ERROR_NO_WITNESS      = 1
+	COLLECT_ERROR_NO_COST_MODEL   = 2
+	COLLECT_ERROR_BAD_TRANSLATION = 3
+
+	PPUP_FAILURE_NON_GENESIS_UPDATE    = 0
+	PPUP_FAILURE_PP_UPDATE_WRONG_EPOCH = 1
+	PPUP_FAILURE_PV_CANNOT_FOLLOW      = 2
+
+	DELEGS_FAILURE_DELEGATEE_NOT_REGISTERED   = 0
+	DELEGS_FAILURE_WITHDRAWALS_NOT_IN_REWARDS = 1
+	DELEGS_FAILURE_DELPL_FAILURE              = 2
+
+	DELPL_FAILURE_POOL_FAILURE  = 0
+	DELPL_FAILURE_DELEG_FAILURE = 1
+
+	DELEG_FAILURE_STAKE_KEY_ALREADY_REGISTERED           = 0
+	DELEG_FAILURE_STAKE_KEY_NOT_REGISTERED               = 1
+	DELEG_FAILURE_STAKE_KEY_NON_ZERO_ACCOUNT_BALANCE     = 2
+	DELEG_FAILURE_STAKE_DELEGATION_IMPOSSIBLE            = 3
+	DELEG_FAILURE_WRONG_CERTIFICATE_TYPE                 = 4
+	DELEG_FAILURE_GENESIS_KEY_NOT_IN_MAPPING             = 5
+	DELEG_FAILURE_DUPLICATE_GENESIS_DELEGATE             = 6
+	DELEG_FAILURE_INSUFFICIENT_FOR_INSTANTANEOUS_REWARDS = 7
+	DELEG_FAILURE_MIR_CERTIFICATE_TOO_LATE_IN_EPOCH      = 8
+	DELEG_FAILURE_DUPLICATE_GENESIS_VRF                  = 9
+	DELEG_FAILURE_STAKE_KEY_IN_REWARDS                   = 10
+	DELEG_FAILURE_MIR_TRANSFER_NOT_CURRENTLY_ALLOWED     = 11
+	DELEG_FAILURE_MIR_NEGATIVES_NOT_CURRENTLY_ALLOWED    = 12
+	DELEG_FAILURE_INSUFFICIENT_FOR_TRANSFER              = 13
+	DELEG_FAILURE_MIR_PRODUCES_NEGATIVE_UPDATE           = 14
+	DELEG_FAILURE_MIR_NEGATIVE_TRANSFER                  = 15
+
+	// Marker preceding the script logs in the description of a Plutus failure
+	PLUTUS_FAILURE_LOGS_MARKER = "Script debugging logs:"
 )
 
 // Helper type to make the code a little cleaner
@@ -61,10 +167,31 @@ func (e *GenericError) UnmarshalCBOR(data []byte) error {
 	return nil
 }
 
+func (e *GenericError) MarshalCBOR() ([]byte, error) {
+	if e.Cbor != nil {
+		return e.Cbor, nil
+	}
+	return cbor.Encode(e.Value)
+}
+
 func (e *GenericError) Error() string {
 	return fmt.Sprintf("GenericError (%v)", e.Value)
 }
 
+// MarshalJSON returns the decoded value of the failure, since the ledger type is not known
+func (e *GenericError) MarshalJSON() ([]byte, error) {
+	tmpValue, err := jsonValue(reflect.ValueOf(e.Value))
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(
+		map[string]interface{}{
+			"tag":      "GenericError",
+			"contents": tmpValue,
+		},
+	)
+}
+
 func NewEraMismatchErrorFromCbor(cborData []byte) (error, error) {
 	newErr := &EraMismatch{}
 	if _, err := cbor.Decode(cborData, newErr); err != nil {
@@ -73,6 +200,16 @@ func NewEraMismatchErrorFromCbor(cborData []byte) (error, error) {
 	return newErr, nil
 }
 
+// Helper function to get the name of an era for error output. This falls back to the numeric ID for
+// unknown eras, since errors can be decoded from a node running a newer era than we know about
+func errorEraName(eraId uint8) string {
+	era := GetEraById(eraId)
+	if era == nil {
+		return fmt.Sprintf("%d", eraId)
+	}
+	return era.Name
+}
+
 type EraMismatch struct {
 	cbor.StructAsArray
 	LedgerEra uint8
@@ -80,7 +217,20 @@ type EraMismatch struct {
 }
 
 func (e *EraMismatch) Error() string {
-	return fmt.Sprintf("The era of the node and the tx do not match. The node is running in the %s era, but the transaction is for the %s era.", GetEraById(e.LedgerEra).Name, GetEraById(e.OtherEra).Name)
+	return fmt.Sprintf("The era of the node and the tx do not match. The node is running in the %s era, but the transaction is for the %s era.", errorEraName(e.LedgerEra), errorEraName(e.OtherEra))
+}
+
+func (e *EraMismatch) MarshalJSON() ([]byte, error) {
+	return json.Marshal(
+		map[string]interface{}{
+			"ledgerEraName": errorEraName(e.LedgerEra),
+			"otherEraName":  errorEraName(e.OtherEra),
+		},
+	)
+}
+
+func (e *EraMismatch) MarshalCBOR() ([]byte, error) {
+	return marshalErrorCbor(e)
 }
 
 // Helper function to try to parse CBOR as various error types
@@ -108,6 +258,7 @@ func NewShelleyTxValidationErrorFromCbor(cborData []byte) (error, error) {
 }
 
 type ShelleyTxValidationError struct {
+	cbor.DecodeStoreCbor
 	Era uint8
 	Err ApplyTxError
 }
@@ -118,23 +269,52 @@ func (e *ShelleyTxValidationError) UnmarshalCBOR(data []byte) error {
 		Inner struct {
 			cbor.StructAsArray
 			Era          uint8
-			ApplyTxError ApplyTxError
+			ApplyTxError cbor.RawMessage
 		}
 	}
 	if _, err := cbor.Decode(data, &tmpData); err != nil {
 		return err
 	}
 	e.Era = tmpData.Inner.Era
-	e.Err = tmpData.Inner.ApplyTxError
+	// The era determines the ledger rules used for decoding the failures
+	e.Err = ApplyTxError{Era: e.Era}
+	if _, err := cbor.Decode(tmpData.Inner.ApplyTxError, &e.Err); err != nil {
+		return err
+	}
+	e.SetCbor(data)
 	return nil
 }
 
+func (e *ShelleyTxValidationError) MarshalCBOR() ([]byte, error) {
+	if cborData := e.Cbor(); cborData != nil {
+		return cborData, nil
+	}
+	return cbor.Encode([]interface{}{[]interface{}{e.Era, &e.Err}})
+}
+
 func (e *ShelleyTxValidationError) Error() string {
-	return fmt.Sprintf("ShelleyTxValidationError ShelleyBasedEra%s (%s)", GetEraById(e.Era).Name, e.Err.Error())
+	return fmt.Sprintf("ShelleyTxValidationError ShelleyBasedEra%s (%s)", errorEraName(e.Era), e.Err.Error())
+}
+
+func (e *ShelleyTxValidationError) MarshalJSON() ([]byte, error) {
+	return json.Marshal(
+		map[string]interface{}{
+			"kind":  "ShelleyTxValidationError",
+			"era":   fmt.Sprintf("ShelleyBasedEra%s", errorEraName(e.Era)),
+			"error": &e.Err,
+		},
+	)
+}
+
+func (e *ShelleyTxValidationError) Unwrap() error {
+	return &e.Err
 }
 
 type ApplyTxError struct {
 	cbor.StructAsArray
+	// The era determines how the failures are decoded. This is populated automatically when
+	// decoding a ShelleyTxValidationError and is assumed to be Babbage when not set
+	Era      uint8
 	Failures []error
 }
 
@@ -143,27 +323,31 @@ func (e *ApplyTxError) UnmarshalCBOR(data []byte) error {
 	if _, err := cbor.Decode(data, &tmpData); err != nil {
 		return err
 	}
+	if e.Era == ERA_ID_BYRON {
+		e.Era = ERA_ID_BABBAGE
+	}
+	e.Failures = nil
 	for _, failure := range tmpData {
-		var tmpFailure []cbor.RawMessage
-		if _, err := cbor.Decode(failure, &tmpFailure); err != nil {
-			return err
-		}
-		failureType, err := cbor.DecodeIdFromList(failure)
-		if err != nil {
-			return err
-		}
-		var newErr error
-		switch failureType {
-		case APPLY_TX_ERROR_UTXOW_FAILURE:
-			newErr = &UtxowFailure{}
+		var idMap map[int]interface{}
+		switch e.Era {
+		case ERA_ID_CONWAY:
+			idMap = map[int]interface{}{
+				CONWAY_LEDGER_FAILURE_UTXOW:                       &ConwayUtxowFailure{},
+				CONWAY_LEDGER_FAILURE_CERTS:                       &ConwayCertsFailure{},
+				CONWAY_LEDGER_FAILURE_GOV:                         &ConwayGovFailure{},
+				CONWAY_LEDGER_FAILURE_WDRL_NOT_DELEGATED_TO_DREP:  &ConwayWdrlNotDelegatedToDRep{},
+				CONWAY_LEDGER_FAILURE_TREASURY_VALUE_MISMATCH:     &ConwayTreasuryValueMismatch{},
+				CONWAY_LEDGER_FAILURE_TX_REF_SCRIPTS_SIZE_TOO_BIG: &ConwayTxRefScriptsSizeTooBig{},
+				CONWAY_LEDGER_FAILURE_MEMPOOL_FAILURE:             &ConwayMempoolFailure{},
+			}
 		default:
-			if tmpErr, err := NewGenericErrorFromCbor(data); err != nil {
-				return err
-			} else {
-				newErr = tmpErr
+			idMap = map[int]interface{}{
+				APPLY_TX_ERROR_UTXOW_FAILURE:  &UtxowFailure{Era: e.Era},
+				APPLY_TX_ERROR_DELEGS_FAILURE: &DelegsFailure{Era: e.Era},
 			}
 		}
-		if _, err := cbor.Decode(tmpFailure[1], newErr); err != nil {
+		newErr, err := newFailureFromCbor(failure, idMap)
+		if err != nil {
 			return err
 		}
 		e.Failures = append(e.Failures, newErr)
@@ -171,6 +355,14 @@ func (e *ApplyTxError) UnmarshalCBOR(data []byte) error {
 	return nil
 }
 
+func (e *ApplyTxError) MarshalCBOR() ([]byte, error) {
+	failures := e.Failures
+	if failures == nil {
+		failures = []error{}
+	}
+	return cbor.Encode(failures)
+}
+
 func (e *ApplyTxError) Error() string {
 	ret := "ApplyTxError (["
 	for idx, failure := range e.Failures {
@@ -183,32 +375,142 @@ func (e *ApplyTxError) Error() string {
 	return ret
 }
 
-type UtxowFailure struct {
+// MarshalJSON returns the list of failures
+func (e *ApplyTxError) MarshalJSON() ([]byte, error) {
+	failures := e.Failures
+	if failures == nil {
+		failures = []error{}
+	}
+	return json.Marshal(failures)
+}
+
+// Unwrap returns the individual failures
+func (e *ApplyTxError) Unwrap() []error {
+	return e.Failures
+}
+
+// Is reports whether the target matches any of the individual failures. This is what allows
+// errors.Is to look through the failures, since the errors package only unwraps multiple errors
+// in Go 1.20 and later
+func (e *ApplyTxError) Is(target error) bool {
+	for _, failure := range e.Failures {
+		if errors.Is(failure, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// As finds the first individual failure that matches the target, which allows errors.As to find
+// a specific failure. See Is for why this is needed
+func (e *ApplyTxError) As(target interface{}) bool {
+	for _, failure := range e.Failures {
+		if errors.As(failure, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// Helper function to decode a ledger predicate failure by its leading type ID. Failures
+// with an unknown type ID or an unexpected structure are decoded as GenericError
+func newFailureFromCbor(cborData []byte, idMap map[int]interface{}) (error, error) {
+	newErr, err := cbor.DecodeById(cborData, idMap)
+	if err != nil {
+		return NewGenericErrorFromCbor(cborData)
+	}
+	return newErr.(error), nil
+}
+
+// Helper function for generating the CBOR representation of an error type. The exported fields
+// are encoded as a list, which matches the encoding of ledger predicate failures. Only the
+// top-level ShelleyTxValidationError keeps the original CBOR, so changes to the fields of a
+// decoded failure are reflected when it is encoded
+func marshalErrorCbor(e interface{}) ([]byte, error) {
+	rv := reflect.ValueOf(e)
+	for rv.Kind() == reflect.Ptr {
+		rv = rv.Elem()
+	}
+	return cbor.Encode(appendStructFieldsCbor([]interface{}{}, rv))
+}
+
+func appendStructFieldsCbor(dest []interface{}, rv reflect.Value) []interface{} {
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		if field.Anonymous {
+			// Flatten embedded structs, such as the common failure base types
+			if field.Type.Kind() == reflect.Struct {
+				dest = appendStructFieldsCbor(dest, rv.Field(i))
+			}
+			continue
+		}
+		// The era of nested failures is only used as context for decoding
+		if !field.IsExported() || field.Name == "Era" {
+			continue
+		}
+		fieldValue := rv.Field(i)
+		// Lists are never encoded as null
+		if fieldValue.Kind() == reflect.Slice && fieldValue.IsNil() && field.Type.Elem().Kind() != reflect.Uint8 {
+			dest = append(dest, reflect.MakeSlice(field.Type, 0, 0).Interface())
+			continue
+		}
+		// Make sure that we use the custom CBOR encoding for types that provide one
+		if fieldValue.CanAddr() {
+			if marshaler, ok := fieldValue.Addr().Interface().(cbor.Marshaler); ok {
+				dest = append(dest, marshaler)
+				continue
+			}
+		}
+		dest = append(dest, fieldValue.Interface())
+	}
+	return dest
+}
+
+// Common base for ledger predicate failures, which are encoded as a list with
+// a leading type ID
+type PredicateFailureBase struct {
+	cbor.StructAsArray
+	Type uint8
+}
+
+// Helper type for decoding a predicate failure that wraps a failure from another ledger rule.
+// Wrapping failures have an Era field, which determines how the inner failure is decoded. It is
+// populated automatically by ApplyTxError or the parent failure
+type wrappedPredicateFailure struct {
 	cbor.StructAsArray
+	Type uint8
+	Err  cbor.RawMessage
+}
+
+type UtxowFailure struct {
+	PredicateFailureBase
+	Era uint8
 	Err error
 }
 
 func (e *UtxowFailure) UnmarshalCBOR(data []byte) error {
-	var tmpFailure []cbor.RawMessage
-	if _, err := cbor.Decode(data, &tmpFailure); err != nil {
-		return err
-	}
-	failureType, err := cbor.DecodeIdFromList(data)
-	if err != nil {
+	var tmpData wrappedPredicateFailure
+	if _, err := cbor.Decode(data, &tmpData); err != nil {
 		return err
 	}
-	var newErr error
-	switch failureType {
-	case UTXOW_FAILURE_UTXO_FAILURE:
-		newErr = &UtxoFailure{}
+	e.Type = tmpData.Type
+	var idMap map[int]interface{}
+	switch e.Era {
+	case ERA_ID_SHELLEY, ERA_ID_ALLEGRA, ERA_ID_MARY:
+		idMap = shelleyUtxowFailureIdMap(e.Era)
+	case ERA_ID_ALONZO:
+		idMap = alonzoUtxowFailureIdMap(e.Era)
 	default:
-		if tmpErr, err := NewGenericErrorFromCbor(data); err != nil {
-			return err
-		} else {
-			newErr = tmpErr
+		idMap = map[int]interface{}{
+			BABBAGE_UTXOW_FAILURE_ALONZO_IN_BABBAGE:           &AlonzoInBabbageUtxowPredFailure{Era: e.Era},
+			UTXOW_FAILURE_UTXO_FAILURE:                        &UtxoFailure{Era: e.Era},
+			BABBAGE_UTXOW_FAILURE_MALFORMED_SCRIPT_WITNESSES:  &MalformedScriptWitnesses{},
+			BABBAGE_UTXOW_FAILURE_MALFORMED_REFERENCE_SCRIPTS: &MalformedReferenceScripts{},
 		}
 	}
-	if _, err := cbor.Decode(tmpFailure[1], newErr); err != nil {
+	newErr, err := newFailureFromCbor(tmpData.Err, idMap)
+	if err != nil {
 		return err
 	}
 	e.Err = newErr
@@ -219,61 +521,263 @@ func (e *UtxowFailure) Error() string {
 	return fmt.Sprintf("UtxowFailure (%s)", e.Err)
 }
 
+func (e *UtxowFailure) MarshalJSON() ([]byte, error) {
+	return marshalErrorJson("UtxowFailure", e)
+}
+
+func (e *UtxowFailure) Unwrap() error {
+	return e.Err
+}
+
+func (e *UtxowFailure) MarshalCBOR() ([]byte, error) {
+	return marshalErrorCbor(e)
+}
+
+type AlonzoInBabbageUtxowPredFailure struct {
+	PredicateFailureBase
+	Era uint8
+	Err error
+}
+
+func (e *AlonzoInBabbageUtxowPredFailure) UnmarshalCBOR(data []byte) error {
+	var tmpData wrappedPredicateFailure
+	if _, err := cbor.Decode(data, &tmpData); err != nil {
+		return err
+	}
+	e.Type = tmpData.Type
+	newErr, err := newFailureFromCbor(tmpData.Err, alonzoUtxowFailureIdMap(e.Era))
+	if err != nil {
+		return err
+	}
+	e.Err = newErr
+	return nil
+}
+
+func (e *AlonzoInBabbageUtxowPredFailure) Error() string {
+	return fmt.Sprintf("AlonzoInBabbageUtxowPredFailure (%s)", e.Err)
+}
+
+func (e *AlonzoInBabbageUtxowPredFailure) MarshalJSON() ([]byte, error) {
+	return marshalErrorJson("AlonzoInBabbageUtxowPredFailure", e)
+}
+
+func (e *AlonzoInBabbageUtxowPredFailure) Unwrap() error {
+	return e.Err
+}
+
+func (e *AlonzoInBabbageUtxowPredFailure) MarshalCBOR() ([]byte, error) {
+	return marshalErrorCbor(e)
+}
+
+type ShelleyInAlonzoUtxowPredFailure struct {
+	PredicateFailureBase
+	Era uint8
+	Err error
+}
+
+func (e *ShelleyInAlonzoUtxowPredFailure) UnmarshalCBOR(data []byte) error {
+	var tmpData wrappedPredicateFailure
+	if _, err := cbor.Decode(data, &tmpData); err != nil {
+		return err
+	}
+	e.Type = tmpData.Type
+	newErr, err := newFailureFromCbor(tmpData.Err, shelleyUtxowFailureIdMap(e.Era))
+	if err != nil {
+		return err
+	}
+	e.Err = newErr
+	return nil
+}
+
+func (e *ShelleyInAlonzoUtxowPredFailure) Error() string {
+	return fmt.Sprintf("ShelleyInAlonzoUtxowPredFailure (%s)", e.Err)
+}
+
+func (e *ShelleyInAlonzoUtxowPredFailure) MarshalJSON() ([]byte, error) {
+	return marshalErrorJson("ShelleyInAlonzoUtxowPredFailure", e)
+}
+
+func (e *ShelleyInAlonzoUtxowPredFailure) Unwrap() error {
+	return e.Err
+}
+
+func (e *ShelleyInAlonzoUtxowPredFailure) MarshalCBOR() ([]byte, error) {
+	return marshalErrorCbor(e)
+}
+
+func alonzoUtxowFailureIdMap(era uint8) map[int]interface{} {
+	return map[int]interface{}{
+		ALONZO_UTXOW_FAILURE_SHELLEY_IN_ALONZO:               &ShelleyInAlonzoUtxowPredFailure{Era: era},
+		ALONZO_UTXOW_FAILURE_MISSING_REDEEMERS:               &MissingRedeemers{},
+		ALONZO_UTXOW_FAILURE_MISSING_REQUIRED_DATUMS:         &MissingRequiredDatums{},
+		ALONZO_UTXOW_FAILURE_NOT_ALLOWED_SUPPLEMENTAL_DATUMS: &NotAllowedSupplementalDatums{},
+		ALONZO_UTXOW_FAILURE_PP_VIEW_HASHES_DONT_MATCH:       &PPViewHashesDontMatch{},
+		ALONZO_UTXOW_FAILURE_MISSING_REQUIRED_SIGNERS:        &MissingRequiredSigners{},
+		ALONZO_UTXOW_FAILURE_UNSPENDABLE_UTXO_NO_DATUM_HASH:  &UnspendableUtxoNoDatumHash{},
+		ALONZO_UTXOW_FAILURE_EXTRA_REDEEMERS:                 &ExtraRedeemers{},
+	}
+}
+
+func shelleyUtxowFailureIdMap(era uint8) map[int]interface{} {
+	return map[int]interface{}{
+		SHELLEY_UTXOW_FAILURE_INVALID_WITNESSES_UTXOW:             &InvalidWitnessesUtxow{},
+		SHELLEY_UTXOW_FAILURE_MISSING_VKEY_WITNESSES_UTXOW:        &MissingVKeyWitnessesUtxow{},
+		SHELLEY_UTXOW_FAILURE_MISSING_SCRIPT_WITNESSES_UTXOW:      &MissingScriptWitnessesUtxow{},
+		SHELLEY_UTXOW_FAILURE_SCRIPT_WITNESS_NOT_VALIDATING_UTXOW: &ScriptWitnessNotValidatingUtxow{},
+		SHELLEY_UTXOW_FAILURE_UTXO_FAILURE:                        &UtxoFailure{Era: era},
+		SHELLEY_UTXOW_FAILURE_MIR_INSUFFICIENT_GENESIS_SIGS_UTXOW: &MIRInsufficientGenesisSigsUtxow{},
+		SHELLEY_UTXOW_FAILURE_MISSING_TX_BODY_METADATA_HASH:       &MissingTxBodyMetadataHash{},
+		SHELLEY_UTXOW_FAILURE_MISSING_TX_METADATA:                 &MissingTxMetadata{},
+		SHELLEY_UTXOW_FAILURE_CONFLICTING_METADATA_HASH:           &ConflictingMetadataHash{},
+		SHELLEY_UTXOW_FAILURE_INVALID_METADATA:                    &InvalidMetadata{},
+		SHELLEY_UTXOW_FAILURE_EXTRANEOUS_SCRIPT_WITNESSES_UTXOW:   &ExtraneousScriptWitnessesUtxow{},
+	}
+}
+
 type UtxoFailure struct {
-	cbor.StructAsArray
+	PredicateFailureBase
 	Era uint8
-	Err UtxoFailureError
+	Err error
 }
 
 func (e *UtxoFailure) UnmarshalCBOR(data []byte) error {
-	var tmpData struct {
-		cbor.StructAsArray
-		Era uint8
-		Err cbor.RawMessage
-	}
+	var tmpData wrappedPredicateFailure
 	if _, err := cbor.Decode(data, &tmpData); err != nil {
 		return err
 	}
-	e.Era = tmpData.Era
-	newErr, err := cbor.DecodeById(
-		tmpData.Err,
-		map[int]interface{}{
-			UTXO_FAILURE_BAD_INPUTS_UTXO:                &BadInputsUtxo{},
-			UTXO_FAILURE_OUTSIDE_VALIDITY_INTERVAL_UTXO: &OutsideValidityIntervalUtxo{},
-			UTXO_FAILURE_MAX_TX_SIZE_UTXO:               &MaxTxSizeUtxo{},
-			UTXO_FAILURE_INPUT_SET_EMPTY:                &InputSetEmptyUtxo{},
-			UTXO_FAILURE_FEE_TOO_SMALL_UTXO:             &FeeTooSmallUtxo{},
-			UTXO_FAILURE_VALUE_NOT_CONSERVED_UTXO:       &ValueNotConservedUtxo{},
-			UTXO_FAILURE_OUTPUT_TOO_SMALL_UTXO:          &OutputTooSmallUtxo{},
-			UTXO_FAILURE_UTXOS_FAILURE:                  &UtxosFailure{},
-			UTXO_FAILURE_WRONG_NETWORK:                  &WrongNetwork{},
-			UTXO_FAILURE_WRONG_NETWORK_WITHDRAWAL:       &WrongNetworkWithdrawal{},
-			UTXO_FAILURE_OUTPUT_BOOT_ADDR_ATTRS_TOO_BIG: &OutputBootAddrAttrsTooBig{},
-			UTXO_FAILURE_TRIES_TO_FORGE_ADA:             &TriesToForgeADA{},
-			UTXO_FAILURE_OUTPUT_TOO_BIG_UTXO:            &OutputTooBigUtxo{},
-			UTXO_FAILURE_INSUFFICIENT_COLLATERAL:        &InsufficientCollateral{},
-			UTXO_FAILURE_SCRIPTS_NOT_PAID_UTXO:          &ScriptsNotPaidUtxo{},
-			UTXO_FAILURE_EX_UNITS_TOO_BIG_UTXO:          &ExUnitsTooBigUtxo{},
-			UTXO_FAILURE_COLLATERAL_CONTAINS_NON_ADA:    &CollateralContainsNonADA{},
-			UTXO_FAILURE_WRONG_NETWORK_IN_TX_BODY:       &WrongNetworkInTxBody{},
-			UTXO_FAILURE_OUTSIDE_FORECAST:               &OutsideForecast{},
-			UTXO_FAILURE_TOO_MANY_COLLATERAL_INPUTS:     &TooManyCollateralInputs{},
-			UTXO_FAILURE_NO_COLLATERAL_INPUTS:           &NoCollateralInputs{},
-		},
-	)
+	e.Type = tmpData.Type
+	var idMap map[int]interface{}
+	switch e.Era {
+	case ERA_ID_SHELLEY:
+		idMap = shelleyUtxoFailureIdMap()
+	case ERA_ID_ALLEGRA, ERA_ID_MARY:
+		idMap = allegraUtxoFailureIdMap()
+	case ERA_ID_ALONZO:
+		idMap = alonzoUtxoFailureIdMap(e.Era)
+	case ERA_ID_CONWAY:
+		idMap = conwayUtxoFailureIdMap()
+	default:
+		idMap = babbageUtxoFailureIdMap(e.Era)
+	}
+	newErr, err := newFailureFromCbor(tmpData.Err, idMap)
 	if err != nil {
-		newErr, err = NewGenericErrorFromCbor(tmpData.Err)
-		if err != nil {
-			return fmt.Errorf("failed to parse UtxoFailure: %s", err)
-		}
+		return err
 	}
-	e.Err = newErr.(UtxoFailureError)
+	e.Err = newErr
 	return nil
 }
 
 func (e *UtxoFailure) Error() string {
-	// TODO: lookup era name programmatically
-	return fmt.Sprintf("UtxoFailure (FromAlonzoUtxoFail (%s))", e.Err)
+	return fmt.Sprintf("UtxoFailure (%s)", e.Err)
+}
+
+func (e *UtxoFailure) MarshalJSON() ([]byte, error) {
+	return marshalErrorJson("UtxoFailure", e)
+}
+
+func (e *UtxoFailure) Unwrap() error {
+	return e.Err
+}
+
+func (e *UtxoFailure) MarshalCBOR() ([]byte, error) {
+	return marshalErrorCbor(e)
+}
+
+type FromAlonzoUtxoFail struct {
+	PredicateFailureBase
+	Era uint8
+	Err error
+}
+
+func (e *FromAlonzoUtxoFail) UnmarshalCBOR(data []byte) error {
+	var tmpData wrappedPredicateFailure
+	if _, err := cbor.Decode(data, &tmpData); err != nil {
+		return err
+	}
+	e.Type = tmpData.Type
+	newErr, err := newFailureFromCbor(tmpData.Err, alonzoUtxoFailureIdMap(e.Era))
+	if err != nil {
+		return err
+	}
+	e.Err = newErr
+	return nil
+}
+
+func (e *FromAlonzoUtxoFail) Error() string {
+	return fmt.Sprintf("FromAlonzoUtxoFail (%s)", e.Err)
+}
+
+// MarshalJSON uses the current ledger name for this constructor, AlonzoInBabbageUtxoPredFailure
+func (e *FromAlonzoUtxoFail) MarshalJSON() ([]byte, error) {
+	return marshalErrorJson("AlonzoInBabbageUtxoPredFailure", e)
+}
+
+func (e *FromAlonzoUtxoFail) Unwrap() error {
+	return e.Err
+}
+
+func (e *FromAlonzoUtxoFail) MarshalCBOR() ([]byte, error) {
+	return marshalErrorCbor(e)
+}
+
+func shelleyUtxoFailureIdMap() map[int]interface{} {
+	return map[int]interface{}{
+		SHELLEY_UTXO_FAILURE_BAD_INPUTS_UTXO:                &BadInputsUtxo{},
+		SHELLEY_UTXO_FAILURE_EXPIRED_UTXO:                   &ExpiredUtxo{},
+		SHELLEY_UTXO_FAILURE_MAX_TX_SIZE_UTXO:               &MaxTxSizeUtxo{},
+		SHELLEY_UTXO_FAILURE_INPUT_SET_EMPTY:                &InputSetEmptyUtxo{},
+		SHELLEY_UTXO_FAILURE_FEE_TOO_SMALL_UTXO:             &FeeTooSmallUtxo{},
+		SHELLEY_UTXO_FAILURE_VALUE_NOT_CONSERVED_UTXO:       &ValueNotConservedUtxo{},
+		SHELLEY_UTXO_FAILURE_OUTPUT_TOO_SMALL_UTXO:          &OutputTooSmallUtxo{},
+		SHELLEY_UTXO_FAILURE_UPDATE_FAILURE:                 &UpdateFailure{},
+		SHELLEY_UTXO_FAILURE_WRONG_NETWORK:                  &WrongNetwork{},
+		SHELLEY_UTXO_FAILURE_WRONG_NETWORK_WITHDRAWAL:       &WrongNetworkWithdrawal{},
+		SHELLEY_UTXO_FAILURE_OUTPUT_BOOT_ADDR_ATTRS_TOO_BIG: &OutputBootAddrAttrsTooBig{},
+	}
+}
+
+func allegraUtxoFailureIdMap() map[int]interface{} {
+	idMap := shelleyUtxoFailureIdMap()
+	delete(idMap, SHELLEY_UTXO_FAILURE_EXPIRED_UTXO)
+	idMap[ALLEGRA_UTXO_FAILURE_OUTSIDE_VALIDITY_INTERVAL_UTXO] = &OutsideValidityIntervalUtxo{}
+	idMap[ALLEGRA_UTXO_FAILURE_TRIES_TO_FORGE_ADA] = &TriesToForgeADA{}
+	idMap[ALLEGRA_UTXO_FAILURE_OUTPUT_TOO_BIG_UTXO] = &AllegraOutputTooBigUtxo{}
+	return idMap
+}
+
+func babbageUtxoFailureIdMap(era uint8) map[int]interface{} {
+	return map[int]interface{}{
+		UTXO_FAILURE_FROM_ALONZO:                              &FromAlonzoUtxoFail{Era: era},
+		BABBAGE_UTXO_FAILURE_INCORRECT_TOTAL_COLLATERAL_FIELD: &IncorrectTotalCollateralField{},
+		BABBAGE_UTXO_FAILURE_BABBAGE_OUTPUT_TOO_SMALL_UTXO:    &BabbageOutputTooSmallUtxo{},
+		BABBAGE_UTXO_FAILURE_BABBAGE_NON_DISJOINT_REF_INPUTS:  &BabbageNonDisjointRefInputs{},
+	}
+}
+
+func alonzoUtxoFailureIdMap(era uint8) map[int]interface{} {
+	return map[int]interface{}{
+		UTXO_FAILURE_BAD_INPUTS_UTXO:                &BadInputsUtxo{},
+		UTXO_FAILURE_OUTSIDE_VALIDITY_INTERVAL_UTXO: &OutsideValidityIntervalUtxo{},
+		UTXO_FAILURE_MAX_TX_SIZE_UTXO:               &MaxTxSizeUtxo{},
+		UTXO_FAILURE_INPUT_SET_EMPTY:                &InputSetEmptyUtxo{},
+		UTXO_FAILURE_FEE_TOO_SMALL_UTXO:             &FeeTooSmallUtxo{},
+		UTXO_FAILURE_VALUE_NOT_CONSERVED_UTXO:       &ValueNotConservedUtxo{},
+		UTXO_FAILURE_OUTPUT_TOO_SMALL_UTXO:          &OutputTooSmallUtxo{},
+		UTXO_FAILURE_UTXOS_FAILURE:                  &UtxosFailure{Era: era},
+		UTXO_FAILURE_WRONG_NETWORK:                  &WrongNetwork{},
+		UTXO_FAILURE_WRONG_NETWORK_WITHDRAWAL:       &WrongNetworkWithdrawal{},
+		UTXO_FAILURE_OUTPUT_BOOT_ADDR_ATTRS_TOO_BIG: &OutputBootAddrAttrsTooBig{},
+		UTXO_FAILURE_TRIES_TO_FORGE_ADA:             &TriesToForgeADA{},
+		UTXO_FAILURE_OUTPUT_TOO_BIG_UTXO:            &OutputTooBigUtxo{},
+		UTXO_FAILURE_INSUFFICIENT_COLLATERAL:        &InsufficientCollateral{},
+		UTXO_FAILURE_SCRIPTS_NOT_PAID_UTXO:          &ScriptsNotPaidUtxo{},
+		UTXO_FAILURE_EX_UNITS_TOO_BIG_UTXO:          &ExUnitsTooBigUtxo{},
+		UTXO_FAILURE_COLLATERAL_CONTAINS_NON_ADA:    &CollateralContainsNonADA{},
+		UTXO_FAILURE_WRONG_NETWORK_IN_TX_BODY:       &WrongNetworkInTxBody{},
+		UTXO_FAILURE_OUTSIDE_FORECAST:               &OutsideForecast{},
+		UTXO_FAILURE_TOO_MANY_COLLATERAL_INPUTS:     &TooManyCollateralInputs{},
+		UTXO_FAILURE_NO_COLLATERAL_INPUTS:           &NoCollateralInputs{},
+	}
 }
 
 type UtxoFailureError interface {
@@ -288,6 +792,10 @@ type UtxoFailureErrorBase struct {
 
 func (e *UtxoFailureErrorBase) isUtxoFailureError() {}
 
+func (e *UtxoFailureErrorBase) setUtxoFailureType(failureType uint8) {
+	e.Type = failureType
+}
+
 type BadInputsUtxo struct {
 	UtxoFailureErrorBase
 	Inputs []TxIn
@@ -305,25 +813,91 @@ func (e *BadInputsUtxo) Error() string {
 	return ret
 }
 
+func (e *BadInputsUtxo) MarshalJSON() ([]byte, error) {
+	return marshalErrorJson("BadInputsUTxO", e)
+}
+
+func (e *BadInputsUtxo) MarshalCBOR() ([]byte, error) {
+	return marshalErrorCbor(e)
+}
+
 type TxIn struct {
 	cbor.StructAsArray
 	Utxo cbor.ByteString
-	TxIx uint8
+	TxIx uint32
 }
 
-func (e *TxIn) String() string {
+func (e TxIn) String() string {
 	return fmt.Sprintf("TxIn (Utxo %s, TxIx %d)", e.Utxo, e.TxIx)
 }
 
+// MarshalJSON returns the input in the "txid#index" form used by the ledger
+func (e TxIn) MarshalJSON() ([]byte, error) {
+	return json.Marshal(fmt.Sprintf("%s#%d", e.Utxo.String(), e.TxIx))
+}
+
 type OutsideValidityIntervalUtxo struct {
 	UtxoFailureErrorBase
-	ValidityInterval cbor.Value
-	Slot             uint32
+	ValidityInterval ValidityInterval
+	Slot             uint64
 }
 
 func (e *OutsideValidityIntervalUtxo) Error() string {
-	validityInterval := e.ValidityInterval.Value.([]interface{})
-	return fmt.Sprintf("OutsideValidityIntervalUtxo (ValidityInterval { invalidBefore = %v, invalidHereafter = %v }, Slot %d)", validityInterval[0], validityInterval[1], e.Slot)
+	return fmt.Sprintf("OutsideValidityIntervalUtxo (%s, Slot %d)", e.ValidityInterval.String(), e.Slot)
+}
+
+// ValidityInterval represents the slot range during which a transaction is valid. Each bound
+// is optional and is encoded as an empty or single-item list
+type ValidityInterval struct {
+	InvalidBefore    *uint64
+	InvalidHereafter *uint64
+}
+
+func (v *ValidityInterval) UnmarshalCBOR(data []byte) error {
+	var tmpData struct {
+		cbor.StructAsArray
+		InvalidBefore    []uint64
+		InvalidHereafter []uint64
+	}
+	if _, err := cbor.Decode(data, &tmpData); err != nil {
+		return err
+	}
+	if len(tmpData.InvalidBefore) > 0 {
+		v.InvalidBefore = &tmpData.InvalidBefore[0]
+	}
+	if len(tmpData.InvalidHereafter) > 0 {
+		v.InvalidHereafter = &tmpData.InvalidHereafter[0]
+	}
+	return nil
+}
+
+func (v ValidityInterval) MarshalCBOR() ([]byte, error) {
+	tmpData := [][]uint64{{}, {}}
+	if v.InvalidBefore != nil {
+		tmpData[0] = []uint64{*v.InvalidBefore}
+	}
+	if v.InvalidHereafter != nil {
+		tmpData[1] = []uint64{*v.InvalidHereafter}
+	}
+	return cbor.Encode(tmpData)
+}
+
+func (v ValidityInterval) String() string {
+	strictMaybeSlot := func(slot *uint64) string {
+		if slot == nil {
+			return "SNothing"
+		}
+		return fmt.Sprintf("SJust (SlotNo %d)", *slot)
+	}
+	return fmt.Sprintf("ValidityInterval { invalidBefore = %s, invalidHereafter = %s }", strictMaybeSlot(v.InvalidBefore), strictMaybeSlot(v.InvalidHereafter))
+}
+
+func (e *OutsideValidityIntervalUtxo) MarshalJSON() ([]byte, error) {
+	return marshalErrorJson("OutsideValidityIntervalUTxO", e)
+}
+
+func (e *OutsideValidityIntervalUtxo) MarshalCBOR() ([]byte, error) {
+	return marshalErrorCbor(e)
 }
 
 type MaxTxSizeUtxo struct {
@@ -336,6 +910,14 @@ func (e *MaxTxSizeUtxo) Error() string {
 	return fmt.Sprintf("MaxTxSizeUtxo (ActualSize %d, MaxSize %d)", e.ActualSize, e.MaxSize)
 }
 
+func (e *MaxTxSizeUtxo) MarshalJSON() ([]byte, error) {
+	return marshalErrorJson("MaxTxSizeUTxO", e)
+}
+
+func (e *MaxTxSizeUtxo) MarshalCBOR() ([]byte, error) {
+	return marshalErrorCbor(e)
+}
+
 type InputSetEmptyUtxo struct {
 	UtxoFailureErrorBase
 }
@@ -344,6 +926,14 @@ func (e *InputSetEmptyUtxo) Error() string {
 	return "InputSetEmptyUtxo"
 }
 
+func (e *InputSetEmptyUtxo) MarshalJSON() ([]byte, error) {
+	return marshalErrorJson("InputSetEmptyUTxO", e)
+}
+
+func (e *InputSetEmptyUtxo) MarshalCBOR() ([]byte, error) {
+	return marshalErrorCbor(e)
+}
+
 type FeeTooSmallUtxo struct {
 	UtxoFailureErrorBase
 	MinimumFee  uint64
@@ -354,14 +944,30 @@ func (e *FeeTooSmallUtxo) Error() string {
 	return fmt.Sprintf("FeeTooSmallUtxo (MinimumFee %d, SuppliedFee %d)", e.MinimumFee, e.SuppliedFee)
 }
 
+func (e *FeeTooSmallUtxo) MarshalJSON() ([]byte, error) {
+	return marshalErrorJson("FeeTooSmallUTxO", e)
+}
+
+func (e *FeeTooSmallUtxo) MarshalCBOR() ([]byte, error) {
+	return marshalErrorCbor(e)
+}
+
 type ValueNotConservedUtxo struct {
 	UtxoFailureErrorBase
-	Consumed uint64
-	Produced uint64
+	Consumed MaryValue
+	Produced MaryValue
 }
 
 func (e *ValueNotConservedUtxo) Error() string {
-	return fmt.Sprintf("ValueNotConservedUtxo (Consumed %d, Produced %d)", e.Consumed, e.Produced)
+	return fmt.Sprintf("ValueNotConservedUtxo (Consumed (%s), Produced (%s))", e.Consumed.String(), e.Produced.String())
+}
+
+func (e *ValueNotConservedUtxo) MarshalJSON() ([]byte, error) {
+	return marshalErrorJson("ValueNotConservedUTxO", e)
+}
+
+func (e *ValueNotConservedUtxo) MarshalCBOR() ([]byte, error) {
+	return marshalErrorCbor(e)
 }
 
 type OutputTooSmallUtxo struct {
@@ -381,39 +987,340 @@ func (e *OutputTooSmallUtxo) Error() string {
 	return ret
 }
 
-type TxOut cbor.Value
-
-func (t *TxOut) String() string {
-	return fmt.Sprintf("TxOut (%v)", t.Value)
+func (e *OutputTooSmallUtxo) MarshalJSON() ([]byte, error) {
+	return marshalErrorJson("OutputTooSmallUTxO", e)
 }
 
-type UtxosFailure struct {
-	UtxoFailureErrorBase
-	Err GenericError
+func (e *OutputTooSmallUtxo) MarshalCBOR() ([]byte, error) {
+	return marshalErrorCbor(e)
 }
 
-func (e *UtxosFailure) Error() string {
-	return fmt.Sprintf("UtxosFailure (%s)", e.Err)
-}
+// TxOut is a transaction output, as included in ledger errors from any Shelley-based era
+type TxOut = BabbageTransactionOutput
 
-type WrongNetwork struct {
+// Utxo is a set of unspent transaction outputs, keyed by transaction input
+type Utxo map[TxIn]TxOut
+
+func (u Utxo) String() string {
+	var utxos []string
+	for txIn, txOut := range u {
+		utxos = append(utxos, fmt.Sprintf("(%s, %s)", txIn.String(), txOut.String()))
+	}
+	// Sort the entries to give consistent output
+	sort.Strings(utxos)
+	return fmt.Sprintf("UTxO (fromList [%s])", strings.Join(utxos, ", "))
+}
+
+func (u Utxo) MarshalJSON() ([]byte, error) {
+	ret := map[string]TxOut{}
+	for txIn, txOut := range u {
+		ret[fmt.Sprintf("%s#%d", txIn.Utxo.String(), txIn.TxIx)] = txOut
+	}
+	return json.Marshal(ret)
+}
+
+type UtxosFailure struct {
+	UtxoFailureErrorBase
+	Era uint8
+	Err error
+}
+
+func (e *UtxosFailure) UnmarshalCBOR(data []byte) error {
+	var tmpData wrappedPredicateFailure
+	if _, err := cbor.Decode(data, &tmpData); err != nil {
+		return err
+	}
+	e.Type = tmpData.Type
+	idMap := map[int]interface{}{
+		UTXOS_FAILURE_VALIDATION_TAG_MISMATCH: &ValidationTagMismatch{},
+		UTXOS_FAILURE_COLLECT_ERRORS:          &CollectErrors{},
+	}
+	// Protocol parameter updates were removed from the UTXOS rule in Conway
+	if e.Era != ERA_ID_CONWAY {
+		idMap[UTXOS_FAILURE_UPDATE_FAILURE] = &UpdateFailure{}
+	}
+	newErr, err := newFailureFromCbor(tmpData.Err, idMap)
+	if err != nil {
+		return err
+	}
+	e.Err = newErr
+	return nil
+}
+
+func (e *UtxosFailure) Error() string {
+	return fmt.Sprintf("UtxosFailure (%s)", e.Err)
+}
+
+func (e *UtxosFailure) MarshalJSON() ([]byte, error) {
+	return marshalErrorJson("UtxosFailure", e)
+}
+
+func (e *UtxosFailure) Unwrap() error {
+	return e.Err
+}
+
+func (e *UtxosFailure) MarshalCBOR() ([]byte, error) {
+	return marshalErrorCbor(e)
+}
+
+type ValidationTagMismatch struct {
+	PredicateFailureBase
+	IsValid     bool
+	Description TagMismatchDescription
+}
+
+func (e *ValidationTagMismatch) Error() string {
+	return fmt.Sprintf("ValidationTagMismatch (IsValid %t, %s)", e.IsValid, e.Description.String())
+}
+
+func (e *ValidationTagMismatch) MarshalJSON() ([]byte, error) {
+	return marshalErrorJson("ValidationTagMismatch", e)
+}
+
+func (e *ValidationTagMismatch) MarshalCBOR() ([]byte, error) {
+	return marshalErrorCbor(e)
+}
+
+type TagMismatchDescription struct {
+	Type     uint8
+	Failures []FailureDescription
+}
+
+func (d *TagMismatchDescription) MarshalCBOR() ([]byte, error) {
+	if d.Type == TAG_MISMATCH_DESCRIPTION_PASSED_UNEXPECTEDLY {
+		return cbor.Encode([]interface{}{d.Type})
+	}
+	failures := d.Failures
+	if failures == nil {
+		failures = []FailureDescription{}
+	}
+	return cbor.Encode([]interface{}{d.Type, failures})
+}
+
+func (d *TagMismatchDescription) UnmarshalCBOR(data []byte) error {
+	descType, err := cbor.DecodeIdFromList(data)
+	if err != nil {
+		return err
+	}
+	d.Type = uint8(descType)
+	switch descType {
+	case TAG_MISMATCH_DESCRIPTION_PASSED_UNEXPECTEDLY:
+		return nil
+	case TAG_MISMATCH_DESCRIPTION_FAILED_UNEXPECTEDLY:
+		var tmpData struct {
+			cbor.StructAsArray
+			Type     uint8
+			Failures []FailureDescription
+		}
+		if _, err := cbor.Decode(data, &tmpData); err != nil {
+			return err
+		}
+		d.Failures = tmpData.Failures
+		return nil
+	default:
+		return fmt.Errorf("unknown tag mismatch description type: %d", descType)
+	}
+}
+
+func (d *TagMismatchDescription) MarshalJSON() ([]byte, error) {
+	if d.Type == TAG_MISMATCH_DESCRIPTION_PASSED_UNEXPECTEDLY {
+		return json.Marshal(map[string]interface{}{"tag": "PassedUnexpectedly"})
+	}
+	failures := d.Failures
+	if failures == nil {
+		failures = []FailureDescription{}
+	}
+	return json.Marshal(
+		map[string]interface{}{
+			"tag":      "FailedUnexpectedly",
+			"contents": failures,
+		},
+	)
+}
+
+func (d *TagMismatchDescription) String() string {
+	if d.Type == TAG_MISMATCH_DESCRIPTION_PASSED_UNEXPECTEDLY {
+		return "PassedUnexpectedly"
+	}
+	ret := "FailedUnexpectedly (["
+	for idx, failure := range d.Failures {
+		ret = fmt.Sprintf("%s%s", ret, failure.String())
+		if idx < (len(d.Failures) - 1) {
+			ret = fmt.Sprintf("%s, ", ret)
+		}
+	}
+	ret = fmt.Sprintf("%s])", ret)
+	return ret
+}
+
+type FailureDescription struct {
+	Type        uint8
+	Description string
+	// Serialized script evaluation context, which can be used to re-run the failing script.
+	// This is only present for Plutus failures
+	PlutusDebug []byte
+}
+
+func (d *FailureDescription) MarshalCBOR() ([]byte, error) {
+	if d.Type == FAILURE_DESCRIPTION_PLUTUS_FAILURE {
+		plutusDebug := d.PlutusDebug
+		if plutusDebug == nil {
+			plutusDebug = []byte{}
+		}
+		return cbor.Encode([]interface{}{d.Type, d.Description, plutusDebug})
+	}
+	return cbor.Encode([]interface{}{d.Type, d.Description})
+}
+
+func (d *FailureDescription) UnmarshalCBOR(data []byte) error {
+	var tmpData []cbor.RawMessage
+	if _, err := cbor.Decode(data, &tmpData); err != nil {
+		return err
+	}
+	if len(tmpData) < 2 {
+		return fmt.Errorf("invalid failure description length: %d", len(tmpData))
+	}
+	if _, err := cbor.Decode(tmpData[0], &d.Type); err != nil {
+		return err
+	}
+	if _, err := cbor.Decode(tmpData[1], &d.Description); err != nil {
+		return err
+	}
+	if d.Type == FAILURE_DESCRIPTION_PLUTUS_FAILURE && len(tmpData) > 2 {
+		if _, err := cbor.Decode(tmpData[2], &d.PlutusDebug); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Logs returns the debugging output of a failed Plutus script, if any
+func (d *FailureDescription) Logs() []string {
+	idx := strings.Index(d.Description, PLUTUS_FAILURE_LOGS_MARKER)
+	if idx < 0 {
+		return nil
+	}
+	ret := []string{}
+	for _, line := range strings.Split(d.Description[idx+len(PLUTUS_FAILURE_LOGS_MARKER):], "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		ret = append(ret, line)
+	}
+	return ret
+}
+
+func (d *FailureDescription) MarshalJSON() ([]byte, error) {
+	ret := map[string]interface{}{}
+	switch d.Type {
+	case FAILURE_DESCRIPTION_ONE_PHASE_FAILURE:
+		ret["tag"] = "OnePhaseFailure"
+		ret["contents"] = d.Description
+	default:
+		ret["tag"] = "PlutusFailure"
+		ret["contents"] = []interface{}{d.Description, hex.EncodeToString(d.PlutusDebug)}
+	}
+	return json.Marshal(ret)
+}
+
+func (d *FailureDescription) String() string {
+	switch d.Type {
+	case FAILURE_DESCRIPTION_ONE_PHASE_FAILURE:
+		return fmt.Sprintf("OnePhaseFailure (%q)", d.Description)
+	default:
+		return fmt.Sprintf("PlutusFailure (%q)", d.Description)
+	}
+}
+
+type CollectErrors struct {
+	PredicateFailureBase
+	Errors []CollectError
+}
+
+func (e *CollectErrors) Error() string {
+	ret := "CollectErrors (["
+	for idx, collectErr := range e.Errors {
+		ret = fmt.Sprintf("%s%s", ret, collectErr.String())
+		if idx < (len(e.Errors) - 1) {
+			ret = fmt.Sprintf("%s, ", ret)
+		}
+	}
+	ret = fmt.Sprintf("%s])", ret)
+	return ret
+}
+
+func (e *CollectErrors) MarshalJSON() ([]byte, error) {
+	return marshalErrorJson("CollectErrors", e)
+}
+
+func (e *CollectErrors) MarshalCBOR() ([]byte, error) {
+	return marshalErrorCbor(e)
+}
+
+type CollectError struct {
+	cbor.StructAsArray
+	Type  uint8
+	Value cbor.Value
+}
+
+func (c *CollectError) String() string {
+	switch c.Type {
+	case COLLECT_ERROR_NO_REDEEMER:
+		return fmt.Sprintf("NoRedeemer (%v)", c.Value.Value)
+	case COLLECT_ERROR_NO_WITNESS:
+		return fmt.Sprintf("NoWitness (%v)", c.Value.Value)
+	case COLLECT_ERROR_NO_COST_MODEL:
+		return fmt.Sprintf("NoCostModel (%v)", c.Value.Value)
+	case COLLECT_ERROR_BAD_TRANSLATION:
+		return fmt.Sprintf("BadTranslation (%v)", c.Value.Value)
+	default:
+		return fmt.Sprintf("CollectError (Type %d, %v)", c.Type, c.Value.Value)
+	}
+}
+
+type WrongNetwork struct {
 	UtxoFailureErrorBase
 	ExpectedNetworkId int
-	Addresses         cbor.Value
+	Addresses         []Address
 }
 
 func (e *WrongNetwork) Error() string {
-	return fmt.Sprintf("WrongNetwork (ExpectedNetworkId %d, Addresses (%v))", e.ExpectedNetworkId, e.Addresses.Value)
+	return fmt.Sprintf("WrongNetwork (ExpectedNetworkId %d, Addresses (%s))", e.ExpectedNetworkId, formatAddresses(e.Addresses))
+}
+
+func (e *WrongNetwork) MarshalJSON() ([]byte, error) {
+	return marshalErrorJson("WrongNetwork", e)
+}
+
+func (e *WrongNetwork) MarshalCBOR() ([]byte, error) {
+	return marshalErrorCbor(e)
 }
 
 type WrongNetworkWithdrawal struct {
 	UtxoFailureErrorBase
 	ExpectedNetworkId int
-	RewardAccounts    cbor.Value
+	RewardAccounts    []Address
 }
 
 func (e *WrongNetworkWithdrawal) Error() string {
-	return fmt.Sprintf("WrongNetworkWithdrawal (ExpectedNetworkId %d, RewardAccounts (%v))", e.ExpectedNetworkId, e.RewardAccounts.Value)
+	return fmt.Sprintf("WrongNetworkWithdrawal (ExpectedNetworkId %d, RewardAccounts (%s))", e.ExpectedNetworkId, formatAddresses(e.RewardAccounts))
+}
+
+func formatAddresses(addrs []Address) string {
+	tmpAddrs := make([]string, 0, len(addrs))
+	for _, addr := range addrs {
+		tmpAddrs = append(tmpAddrs, addr.String())
+	}
+	return fmt.Sprintf("fromList [%s]", strings.Join(tmpAddrs, ", "))
+}
+
+func (e *WrongNetworkWithdrawal) MarshalJSON() ([]byte, error) {
+	return marshalErrorJson("WrongNetworkWithdrawal", e)
+}
+
+func (e *WrongNetworkWithdrawal) MarshalCBOR() ([]byte, error) {
+	return marshalErrorCbor(e)
 }
 
 type OutputBootAddrAttrsTooBig struct {
@@ -433,6 +1340,14 @@ func (e *OutputBootAddrAttrsTooBig) Error() string {
 	return ret
 }
 
+func (e *OutputBootAddrAttrsTooBig) MarshalJSON() ([]byte, error) {
+	return marshalErrorJson("OutputBootAddrAttrsTooBig", e)
+}
+
+func (e *OutputBootAddrAttrsTooBig) MarshalCBOR() ([]byte, error) {
+	return marshalErrorCbor(e)
+}
+
 type TriesToForgeADA struct {
 	UtxoFailureErrorBase
 }
@@ -441,6 +1356,14 @@ func (e *TriesToForgeADA) Error() string {
 	return "TriesToForgeADA"
 }
 
+func (e *TriesToForgeADA) MarshalJSON() ([]byte, error) {
+	return marshalErrorJson("TriesToForgeADA", e)
+}
+
+func (e *TriesToForgeADA) MarshalCBOR() ([]byte, error) {
+	return marshalErrorCbor(e)
+}
+
 type OutputTooBigUtxo struct {
 	UtxoFailureErrorBase
 	Outputs []struct {
@@ -463,9 +1386,17 @@ func (e *OutputTooBigUtxo) Error() string {
 
 }
 
+func (e *OutputTooBigUtxo) MarshalJSON() ([]byte, error) {
+	return marshalErrorJson("OutputTooBigUTxO", e)
+}
+
+func (e *OutputTooBigUtxo) MarshalCBOR() ([]byte, error) {
+	return marshalErrorCbor(e)
+}
+
 type InsufficientCollateral struct {
 	UtxoFailureErrorBase
-	BalanceComputed    uint64
+	BalanceComputed    int64
 	RequiredCollateral uint64
 }
 
@@ -473,14 +1404,29 @@ func (e *InsufficientCollateral) Error() string {
 	return fmt.Sprintf("InsufficientCollateral (BalanceComputed %d, RequiredCollateral %d)", e.BalanceComputed, e.RequiredCollateral)
 }
 
+func (e *InsufficientCollateral) MarshalJSON() ([]byte, error) {
+	return marshalErrorJson("InsufficientCollateral", e)
+}
+
+func (e *InsufficientCollateral) MarshalCBOR() ([]byte, error) {
+	return marshalErrorCbor(e)
+}
+
 type ScriptsNotPaidUtxo struct {
 	UtxoFailureErrorBase
-	// TODO: determine content/structure of this value
-	Value cbor.Value
+	Utxo Utxo
 }
 
 func (e *ScriptsNotPaidUtxo) Error() string {
-	return fmt.Sprintf("ScriptsNotPaidUtxo (%v)", e.Value.Value)
+	return fmt.Sprintf("ScriptsNotPaidUtxo (%s)", e.Utxo.String())
+}
+
+func (e *ScriptsNotPaidUtxo) MarshalJSON() ([]byte, error) {
+	return marshalErrorJson("ScriptsNotPaidUTxO", e)
+}
+
+func (e *ScriptsNotPaidUtxo) MarshalCBOR() ([]byte, error) {
+	return marshalErrorCbor(e)
 }
 
 type ExUnitsTooBigUtxo struct {
@@ -493,14 +1439,29 @@ func (e *ExUnitsTooBigUtxo) Error() string {
 	return fmt.Sprintf("ExUnitsTooBigUtxo (MaxAllowed %d, Supplied %d)", e.MaxAllowed, e.Supplied)
 }
 
+func (e *ExUnitsTooBigUtxo) MarshalJSON() ([]byte, error) {
+	return marshalErrorJson("ExUnitsTooBigUTxO", e)
+}
+
+func (e *ExUnitsTooBigUtxo) MarshalCBOR() ([]byte, error) {
+	return marshalErrorCbor(e)
+}
+
 type CollateralContainsNonADA struct {
 	UtxoFailureErrorBase
-	// TODO: determine content/structure of this value
-	Value cbor.Value
+	Value MaryValue
 }
 
 func (e *CollateralContainsNonADA) Error() string {
-	return fmt.Sprintf("CollateralContainsNonADA (%v)", e.Value.Value)
+	return fmt.Sprintf("CollateralContainsNonADA (%s)", e.Value.String())
+}
+
+func (e *CollateralContainsNonADA) MarshalJSON() ([]byte, error) {
+	return marshalErrorJson("CollateralContainsNonADA", e)
+}
+
+func (e *CollateralContainsNonADA) MarshalCBOR() ([]byte, error) {
+	return marshalErrorCbor(e)
 }
 
 type WrongNetworkInTxBody struct {
@@ -513,6 +1474,14 @@ func (e *WrongNetworkInTxBody) Error() string {
 	return fmt.Sprintf("WrongNetworkInTxBody (ActualNetworkId %d, TransactionNetworkId %d)", e.ActualNetworkId, e.TransactionNetworkId)
 }
 
+func (e *WrongNetworkInTxBody) MarshalJSON() ([]byte, error) {
+	return marshalErrorJson("WrongNetworkInTxBody", e)
+}
+
+func (e *WrongNetworkInTxBody) MarshalCBOR() ([]byte, error) {
+	return marshalErrorCbor(e)
+}
+
 type OutsideForecast struct {
 	UtxoFailureErrorBase
 	Slot uint32
@@ -522,6 +1491,14 @@ func (e *OutsideForecast) Error() string {
 	return fmt.Sprintf("OutsideForecast (Slot %d)", e.Slot)
 }
 
+func (e *OutsideForecast) MarshalJSON() ([]byte, error) {
+	return marshalErrorJson("OutsideForecast", e)
+}
+
+func (e *OutsideForecast) MarshalCBOR() ([]byte, error) {
+	return marshalErrorCbor(e)
+}
+
 type TooManyCollateralInputs struct {
 	UtxoFailureErrorBase
 	MaxAllowed int
@@ -532,10 +1509,1201 @@ func (e *TooManyCollateralInputs) Error() string {
 	return fmt.Sprintf("TooManyCollateralInputs (MaxAllowed %d, Supplied %d)", e.MaxAllowed, e.Supplied)
 }
 
+func (e *TooManyCollateralInputs) MarshalJSON() ([]byte, error) {
+	return marshalErrorJson("TooManyCollateralInputs", e)
+}
+
+func (e *TooManyCollateralInputs) MarshalCBOR() ([]byte, error) {
+	return marshalErrorCbor(e)
+}
+
 type NoCollateralInputs struct {
 	UtxoFailureErrorBase
 }
 
 func (e *NoCollateralInputs) Error() string {
-	return "NoMollateralInputs"
+	return "NoCollateralInputs"
+}
+
+func (e *NoCollateralInputs) MarshalJSON() ([]byte, error) {
+	return marshalErrorJson("NoCollateralInputs", e)
+}
+
+func (e *NoCollateralInputs) MarshalCBOR() ([]byte, error) {
+	return marshalErrorCbor(e)
+}
+
+type IncorrectTotalCollateralField struct {
+	UtxoFailureErrorBase
+	ProvidedCollateral int64
+	TotalCollateral    uint64
+}
+
+func (e *IncorrectTotalCollateralField) Error() string {
+	return fmt.Sprintf("IncorrectTotalCollateralField (ProvidedCollateral %d, TotalCollateral %d)", e.ProvidedCollateral, e.TotalCollateral)
+}
+
+func (e *IncorrectTotalCollateralField) MarshalJSON() ([]byte, error) {
+	return marshalErrorJson("IncorrectTotalCollateralField", e)
+}
+
+func (e *IncorrectTotalCollateralField) MarshalCBOR() ([]byte, error) {
+	return marshalErrorCbor(e)
+}
+
+type BabbageOutputTooSmallUtxo struct {
+	UtxoFailureErrorBase
+	Outputs []struct {
+		cbor.StructAsArray
+		Output    TxOut
+		MinAmount uint64
+	}
+}
+
+func (e *BabbageOutputTooSmallUtxo) Error() string {
+	ret := "BabbageOutputTooSmallUtxo (["
+	for idx, output := range e.Outputs {
+		ret = fmt.Sprintf("%s(Output (%s), MinAmount %d)", ret, output.Output.String(), output.MinAmount)
+		if idx < (len(e.Outputs) - 1) {
+			ret = fmt.Sprintf("%s, ", ret)
+		}
+	}
+	ret = fmt.Sprintf("%s])", ret)
+	return ret
+}
+
+func (e *BabbageOutputTooSmallUtxo) MarshalJSON() ([]byte, error) {
+	return marshalErrorJson("BabbageOutputTooSmallUTxO", e)
+}
+
+func (e *BabbageOutputTooSmallUtxo) MarshalCBOR() ([]byte, error) {
+	return marshalErrorCbor(e)
+}
+
+type BabbageNonDisjointRefInputs struct {
+	UtxoFailureErrorBase
+	Inputs []TxIn
+}
+
+func (e *BabbageNonDisjointRefInputs) Error() string {
+	ret := "BabbageNonDisjointRefInputs (["
+	for idx, input := range e.Inputs {
+		ret = fmt.Sprintf("%s%s", ret, input.String())
+		if idx < (len(e.Inputs) - 1) {
+			ret = fmt.Sprintf("%s, ", ret)
+		}
+	}
+	ret = fmt.Sprintf("%s])", ret)
+	return ret
+}
+
+func (e *BabbageNonDisjointRefInputs) MarshalJSON() ([]byte, error) {
+	return marshalErrorJson("BabbageNonDisjointRefInputs", e)
+}
+
+func (e *BabbageNonDisjointRefInputs) MarshalCBOR() ([]byte, error) {
+	return marshalErrorCbor(e)
+}
+
+type InvalidWitnessesUtxow struct {
+	PredicateFailureBase
+	VerificationKeys []cbor.ByteString
+}
+
+func (e *InvalidWitnessesUtxow) Error() string {
+	return fmt.Sprintf("InvalidWitnessesUTXOW (%v)", e.VerificationKeys)
+}
+
+func (e *InvalidWitnessesUtxow) MarshalJSON() ([]byte, error) {
+	return marshalErrorJson("InvalidWitnessesUTXOW", e)
+}
+
+func (e *InvalidWitnessesUtxow) MarshalCBOR() ([]byte, error) {
+	return marshalErrorCbor(e)
+}
+
+type MissingVKeyWitnessesUtxow struct {
+	PredicateFailureBase
+	KeyHashes []Blake2b224
+}
+
+func (e *MissingVKeyWitnessesUtxow) Error() string {
+	return fmt.Sprintf("MissingVKeyWitnessesUTXOW (%v)", e.KeyHashes)
+}
+
+func (e *MissingVKeyWitnessesUtxow) MarshalJSON() ([]byte, error) {
+	return marshalErrorJson("MissingVKeyWitnessesUTXOW", e)
+}
+
+func (e *MissingVKeyWitnessesUtxow) MarshalCBOR() ([]byte, error) {
+	return marshalErrorCbor(e)
+}
+
+type MissingScriptWitnessesUtxow struct {
+	PredicateFailureBase
+	ScriptHashes []Blake2b224
+}
+
+func (e *MissingScriptWitnessesUtxow) Error() string {
+	return fmt.Sprintf("MissingScriptWitnessesUTXOW (%v)", e.ScriptHashes)
+}
+
+func (e *MissingScriptWitnessesUtxow) MarshalJSON() ([]byte, error) {
+	return marshalErrorJson("MissingScriptWitnessesUTXOW", e)
+}
+
+func (e *MissingScriptWitnessesUtxow) MarshalCBOR() ([]byte, error) {
+	return marshalErrorCbor(e)
+}
+
+type ScriptWitnessNotValidatingUtxow struct {
+	PredicateFailureBase
+	ScriptHashes []Blake2b224
+}
+
+func (e *ScriptWitnessNotValidatingUtxow) Error() string {
+	return fmt.Sprintf("ScriptWitnessNotValidatingUTXOW (%v)", e.ScriptHashes)
+}
+
+func (e *ScriptWitnessNotValidatingUtxow) MarshalJSON() ([]byte, error) {
+	return marshalErrorJson("ScriptWitnessNotValidatingUTXOW", e)
+}
+
+func (e *ScriptWitnessNotValidatingUtxow) MarshalCBOR() ([]byte, error) {
+	return marshalErrorCbor(e)
+}
+
+type MissingTxBodyMetadataHash struct {
+	PredicateFailureBase
+	MetadataHash Blake2b256
+}
+
+func (e *MissingTxBodyMetadataHash) Error() string {
+	return fmt.Sprintf("MissingTxBodyMetadataHash (%s)", e.MetadataHash.String())
+}
+
+func (e *MissingTxBodyMetadataHash) MarshalJSON() ([]byte, error) {
+	return marshalErrorJson("MissingTxBodyMetadataHash", e)
+}
+
+func (e *MissingTxBodyMetadataHash) MarshalCBOR() ([]byte, error) {
+	return marshalErrorCbor(e)
+}
+
+type MissingTxMetadata struct {
+	PredicateFailureBase
+	MetadataHash Blake2b256
+}
+
+func (e *MissingTxMetadata) Error() string {
+	return fmt.Sprintf("MissingTxMetadata (%s)", e.MetadataHash.String())
+}
+
+func (e *MissingTxMetadata) MarshalJSON() ([]byte, error) {
+	return marshalErrorJson("MissingTxMetadata", e)
+}
+
+func (e *MissingTxMetadata) MarshalCBOR() ([]byte, error) {
+	return marshalErrorCbor(e)
+}
+
+type ConflictingMetadataHash struct {
+	PredicateFailureBase
+	BodyMetadataHash   Blake2b256
+	ActualMetadataHash Blake2b256
+}
+
+func (e *ConflictingMetadataHash) Error() string {
+	return fmt.Sprintf("ConflictingMetadataHash (BodyMetadataHash %s, ActualMetadataHash %s)", e.BodyMetadataHash.String(), e.ActualMetadataHash.String())
+}
+
+func (e *ConflictingMetadataHash) MarshalJSON() ([]byte, error) {
+	return marshalErrorJson("ConflictingMetadataHash", e)
+}
+
+func (e *ConflictingMetadataHash) MarshalCBOR() ([]byte, error) {
+	return marshalErrorCbor(e)
+}
+
+type InvalidMetadata struct {
+	PredicateFailureBase
+}
+
+func (e *InvalidMetadata) Error() string {
+	return "InvalidMetadata"
+}
+
+func (e *InvalidMetadata) MarshalJSON() ([]byte, error) {
+	return marshalErrorJson("InvalidMetadata", e)
+}
+
+func (e *InvalidMetadata) MarshalCBOR() ([]byte, error) {
+	return marshalErrorCbor(e)
+}
+
+type ExtraneousScriptWitnessesUtxow struct {
+	PredicateFailureBase
+	ScriptHashes []Blake2b224
+}
+
+func (e *ExtraneousScriptWitnessesUtxow) Error() string {
+	return fmt.Sprintf("ExtraneousScriptWitnessesUTXOW (%v)", e.ScriptHashes)
+}
+
+func (e *ExtraneousScriptWitnessesUtxow) MarshalJSON() ([]byte, error) {
+	return marshalErrorJson("ExtraneousScriptWitnessesUTXOW", e)
+}
+
+func (e *ExtraneousScriptWitnessesUtxow) MarshalCBOR() ([]byte, error) {
+	return marshalErrorCbor(e)
+}
+
+type MissingRedeemers struct {
+	PredicateFailureBase
+	// List of script purpose and script hash pairs
+	Redeemers cbor.Value
+}
+
+func (e *MissingRedeemers) Error() string {
+	return fmt.Sprintf("MissingRedeemers (%v)", e.Redeemers.Value)
+}
+
+func (e *MissingRedeemers) MarshalJSON() ([]byte, error) {
+	return marshalErrorJson("MissingRedeemers", e)
+}
+
+func (e *MissingRedeemers) MarshalCBOR() ([]byte, error) {
+	return marshalErrorCbor(e)
+}
+
+type MissingRequiredDatums struct {
+	PredicateFailureBase
+	MissingDatumHashes  []Blake2b256
+	ReceivedDatumHashes []Blake2b256
+}
+
+func (e *MissingRequiredDatums) Error() string {
+	return fmt.Sprintf("MissingRequiredDatums (Missing %v, Received %v)", e.MissingDatumHashes, e.ReceivedDatumHashes)
+}
+
+func (e *MissingRequiredDatums) MarshalJSON() ([]byte, error) {
+	return marshalErrorJson("MissingRequiredDatums", e)
+}
+
+func (e *MissingRequiredDatums) MarshalCBOR() ([]byte, error) {
+	return marshalErrorCbor(e)
+}
+
+type NotAllowedSupplementalDatums struct {
+	PredicateFailureBase
+	UnallowedDatumHashes  []Blake2b256
+	AcceptableDatumHashes []Blake2b256
+}
+
+func (e *NotAllowedSupplementalDatums) Error() string {
+	return fmt.Sprintf("NotAllowedSupplementalDatums (Unallowed %v, Acceptable %v)", e.UnallowedDatumHashes, e.AcceptableDatumHashes)
+}
+
+func (e *NotAllowedSupplementalDatums) MarshalJSON() ([]byte, error) {
+	return marshalErrorJson("NotAllowedSupplementalDatums", e)
+}
+
+func (e *NotAllowedSupplementalDatums) MarshalCBOR() ([]byte, error) {
+	return marshalErrorCbor(e)
+}
+
+type PPViewHashesDontMatch struct {
+	PredicateFailureBase
+	// These are encoded as an empty list when no hash is present
+	SuppliedHash []Blake2b256
+	ExpectedHash []Blake2b256
+}
+
+func (e *PPViewHashesDontMatch) Error() string {
+	return fmt.Sprintf("PPViewHashesDontMatch (Supplied %v, Expected %v)", e.SuppliedHash, e.ExpectedHash)
+}
+
+func (e *PPViewHashesDontMatch) MarshalJSON() ([]byte, error) {
+	return marshalErrorJson("PPViewHashesDontMatch", e)
+}
+
+func (e *PPViewHashesDontMatch) MarshalCBOR() ([]byte, error) {
+	return marshalErrorCbor(e)
+}
+
+type UnspendableUtxoNoDatumHash struct {
+	PredicateFailureBase
+	Inputs []TxIn
+}
+
+func (e *UnspendableUtxoNoDatumHash) Error() string {
+	ret := "UnspendableUTxONoDatumHash (["
+	for idx, input := range e.Inputs {
+		ret = fmt.Sprintf("%s%s", ret, input.String())
+		if idx < (len(e.Inputs) - 1) {
+			ret = fmt.Sprintf("%s, ", ret)
+		}
+	}
+	ret = fmt.Sprintf("%s])", ret)
+	return ret
+}
+
+func (e *UnspendableUtxoNoDatumHash) MarshalJSON() ([]byte, error) {
+	return marshalErrorJson("UnspendableUTxONoDatumHash", e)
+}
+
+func (e *UnspendableUtxoNoDatumHash) MarshalCBOR() ([]byte, error) {
+	return marshalErrorCbor(e)
+}
+
+type ExtraRedeemers struct {
+	PredicateFailureBase
+	// List of redeemer pointers or script purposes, depending on the era
+	Redeemers cbor.Value
+}
+
+func (e *ExtraRedeemers) Error() string {
+	return fmt.Sprintf("ExtraRedeemers (%v)", e.Redeemers.Value)
+}
+
+func (e *ExtraRedeemers) MarshalJSON() ([]byte, error) {
+	return marshalErrorJson("ExtraRedeemers", e)
+}
+
+func (e *ExtraRedeemers) MarshalCBOR() ([]byte, error) {
+	return marshalErrorCbor(e)
+}
+
+type MalformedScriptWitnesses struct {
+	PredicateFailureBase
+	ScriptHashes []Blake2b224
+}
+
+func (e *MalformedScriptWitnesses) Error() string {
+	return fmt.Sprintf("MalformedScriptWitnesses (%v)", e.ScriptHashes)
+}
+
+func (e *MalformedScriptWitnesses) MarshalJSON() ([]byte, error) {
+	return marshalErrorJson("MalformedScriptWitnesses", e)
+}
+
+func (e *MalformedScriptWitnesses) MarshalCBOR() ([]byte, error) {
+	return marshalErrorCbor(e)
+}
+
+type MalformedReferenceScripts struct {
+	PredicateFailureBase
+	ScriptHashes []Blake2b224
+}
+
+func (e *MalformedReferenceScripts) Error() string {
+	return fmt.Sprintf("MalformedReferenceScripts (%v)", e.ScriptHashes)
+}
+
+func (e *MalformedReferenceScripts) MarshalJSON() ([]byte, error) {
+	return marshalErrorJson("MalformedReferenceScripts", e)
+}
+
+func (e *MalformedReferenceScripts) MarshalCBOR() ([]byte, error) {
+	return marshalErrorCbor(e)
+}
+
+type DelegFailure struct {
+	PredicateFailureBase
+	Era uint8
+	Err error
+}
+
+func (e *DelegFailure) UnmarshalCBOR(data []byte) error {
+	var tmpData wrappedPredicateFailure
+	if _, err := cbor.Decode(data, &tmpData); err != nil {
+		return err
+	}
+	e.Type = tmpData.Type
+	var idMap map[int]interface{}
+	switch e.Era {
+	case ERA_ID_CONWAY:
+		idMap = conwayDelegFailureIdMap()
+	default:
+		idMap = shelleyDelegFailureIdMap()
+	}
+	newErr, err := newFailureFromCbor(tmpData.Err, idMap)
+	if err != nil {
+		return err
+	}
+	e.Err = newErr
+	return nil
+}
+
+func (e *DelegFailure) Error() string {
+	return fmt.Sprintf("DelegFailure (%s)", e.Err)
+}
+
+func (e *DelegFailure) MarshalJSON() ([]byte, error) {
+	return marshalErrorJson("DelegFailure", e)
+}
+
+func (e *DelegFailure) Unwrap() error {
+	return e.Err
+}
+
+func (e *DelegFailure) MarshalCBOR() ([]byte, error) {
+	return marshalErrorCbor(e)
+}
+
+type StakeKeyNotRegisteredDeleg struct {
+	PredicateFailureBase
+	StakeCredential Credential
+}
+
+func (e *StakeKeyNotRegisteredDeleg) Error() string {
+	return fmt.Sprintf("StakeKeyNotRegisteredDELEG (%s)", e.StakeCredential.String())
+}
+
+func (e *StakeKeyNotRegisteredDeleg) MarshalJSON() ([]byte, error) {
+	return marshalErrorJson("StakeKeyNotRegisteredDELEG", e)
+}
+
+func (e *StakeKeyNotRegisteredDeleg) MarshalCBOR() ([]byte, error) {
+	return marshalErrorCbor(e)
+}
+
+type PoolFailure struct {
+	PredicateFailureBase
+	Err error
+}
+
+func (e *PoolFailure) UnmarshalCBOR(data []byte) error {
+	var tmpData wrappedPredicateFailure
+	if _, err := cbor.Decode(data, &tmpData); err != nil {
+		return err
+	}
+	e.Type = tmpData.Type
+	newErr, err := newFailureFromCbor(
+		tmpData.Err,
+		map[int]interface{}{
+			POOL_FAILURE_STAKE_POOL_NOT_REGISTERED_ON_KEY:  &StakePoolNotRegisteredOnKeyPool{},
+			POOL_FAILURE_STAKE_POOL_RETIREMENT_WRONG_EPOCH: &StakePoolRetirementWrongEpochPool{},
+			POOL_FAILURE_WRONG_CERTIFICATE_TYPE:            &WrongCertificateTypePool{},
+			POOL_FAILURE_STAKE_POOL_COST_TOO_LOW:           &StakePoolCostTooLowPool{},
+			POOL_FAILURE_WRONG_NETWORK:                     &WrongNetworkPool{},
+			POOL_FAILURE_POOL_METADATA_HASH_TOO_BIG:        &PoolMedataHashTooBig{},
+		},
+	)
+	if err != nil {
+		return err
+	}
+	e.Err = newErr
+	return nil
+}
+
+func (e *PoolFailure) Error() string {
+	return fmt.Sprintf("PoolFailure (%s)", e.Err)
+}
+
+func (e *PoolFailure) MarshalJSON() ([]byte, error) {
+	return marshalErrorJson("PoolFailure", e)
+}
+
+func (e *PoolFailure) Unwrap() error {
+	return e.Err
+}
+
+func (e *PoolFailure) MarshalCBOR() ([]byte, error) {
+	return marshalErrorCbor(e)
+}
+
+type StakePoolNotRegisteredOnKeyPool struct {
+	PredicateFailureBase
+	PoolKeyHash Blake2b224
+}
+
+func (e *StakePoolNotRegisteredOnKeyPool) Error() string {
+	return fmt.Sprintf("StakePoolNotRegisteredOnKeyPOOL (KeyHash %s)", e.PoolKeyHash.String())
+}
+
+func (e *StakePoolNotRegisteredOnKeyPool) MarshalJSON() ([]byte, error) {
+	return marshalErrorJson("StakePoolNotRegisteredOnKeyPOOL", e)
+}
+
+func (e *StakePoolNotRegisteredOnKeyPool) MarshalCBOR() ([]byte, error) {
+	return marshalErrorCbor(e)
+}
+
+type StakePoolRetirementWrongEpochPool struct {
+	PredicateFailureBase
+	CurrentEpoch    uint64
+	RetirementEpoch uint64
+	MaxEpoch        uint64
+}
+
+func (e *StakePoolRetirementWrongEpochPool) Error() string {
+	return fmt.Sprintf("StakePoolRetirementWrongEpochPOOL (CurrentEpoch %d, RetirementEpoch %d, MaxEpoch %d)", e.CurrentEpoch, e.RetirementEpoch, e.MaxEpoch)
+}
+
+func (e *StakePoolRetirementWrongEpochPool) MarshalJSON() ([]byte, error) {
+	return marshalErrorJson("StakePoolRetirementWrongEpochPOOL", e)
+}
+
+func (e *StakePoolRetirementWrongEpochPool) MarshalCBOR() ([]byte, error) {
+	return marshalErrorCbor(e)
+}
+
+type WrongCertificateTypePool struct {
+	PredicateFailureBase
+	CertificateType uint8
+}
+
+func (e *WrongCertificateTypePool) Error() string {
+	return fmt.Sprintf("WrongCertificateTypePOOL (%d)", e.CertificateType)
+}
+
+func (e *WrongCertificateTypePool) MarshalJSON() ([]byte, error) {
+	return marshalErrorJson("WrongCertificateTypePOOL", e)
+}
+
+func (e *WrongCertificateTypePool) MarshalCBOR() ([]byte, error) {
+	return marshalErrorCbor(e)
+}
+
+type StakePoolCostTooLowPool struct {
+	PredicateFailureBase
+	SuppliedCost uint64
+	MinimumCost  uint64
+}
+
+func (e *StakePoolCostTooLowPool) Error() string {
+	return fmt.Sprintf("StakePoolCostTooLowPOOL (SuppliedCost %d, MinimumCost %d)", e.SuppliedCost, e.MinimumCost)
+}
+
+func (e *StakePoolCostTooLowPool) MarshalJSON() ([]byte, error) {
+	return marshalErrorJson("StakePoolCostTooLowPOOL", e)
+}
+
+func (e *StakePoolCostTooLowPool) MarshalCBOR() ([]byte, error) {
+	return marshalErrorCbor(e)
+}
+
+type WrongNetworkPool struct {
+	PredicateFailureBase
+	ExpectedNetworkId uint
+	SuppliedNetworkId uint
+	PoolKeyHash       Blake2b224
+}
+
+func (e *WrongNetworkPool) Error() string {
+	return fmt.Sprintf("WrongNetworkPOOL (ExpectedNetworkId %d, SuppliedNetworkId %d, KeyHash %s)", e.ExpectedNetworkId, e.SuppliedNetworkId, e.PoolKeyHash.String())
+}
+
+func (e *WrongNetworkPool) MarshalJSON() ([]byte, error) {
+	return marshalErrorJson("WrongNetworkPOOL", e)
+}
+
+func (e *WrongNetworkPool) MarshalCBOR() ([]byte, error) {
+	return marshalErrorCbor(e)
+}
+
+type PoolMedataHashTooBig struct {
+	PredicateFailureBase
+	PoolKeyHash Blake2b224
+	Size        int
+}
+
+func (e *PoolMedataHashTooBig) Error() string {
+	return fmt.Sprintf("PoolMedataHashTooBig (KeyHash %s, Size %d)", e.PoolKeyHash.String(), e.Size)
+}
+
+func (e *PoolMedataHashTooBig) MarshalJSON() ([]byte, error) {
+	return marshalErrorJson("PoolMedataHashTooBig", e)
+}
+
+func (e *PoolMedataHashTooBig) MarshalCBOR() ([]byte, error) {
+	return marshalErrorCbor(e)
+}
+
+type ExpiredUtxo struct {
+	UtxoFailureErrorBase
+	Ttl  uint64
+	Slot uint64
+}
+
+func (e *ExpiredUtxo) Error() string {
+	return fmt.Sprintf("ExpiredUtxo (Ttl %d, Slot %d)", e.Ttl, e.Slot)
+}
+
+func (e *ExpiredUtxo) MarshalJSON() ([]byte, error) {
+	return marshalErrorJson("ExpiredUTxO", e)
+}
+
+func (e *ExpiredUtxo) MarshalCBOR() ([]byte, error) {
+	return marshalErrorCbor(e)
+}
+
+type AllegraOutputTooBigUtxo struct {
+	UtxoFailureErrorBase
+	Outputs []TxOut
+}
+
+func (e *AllegraOutputTooBigUtxo) Error() string {
+	ret := "OutputTooBigUtxo (["
+	for idx, output := range e.Outputs {
+		ret = fmt.Sprintf("%s%s", ret, output.String())
+		if idx < (len(e.Outputs) - 1) {
+			ret = fmt.Sprintf("%s, ", ret)
+		}
+	}
+	ret = fmt.Sprintf("%s])", ret)
+	return ret
+}
+
+func (e *AllegraOutputTooBigUtxo) MarshalJSON() ([]byte, error) {
+	return marshalErrorJson("OutputTooBigUTxO", e)
+}
+
+func (e *AllegraOutputTooBigUtxo) MarshalCBOR() ([]byte, error) {
+	return marshalErrorCbor(e)
+}
+
+type MIRInsufficientGenesisSigsUtxow struct {
+	PredicateFailureBase
+	KeyHashes []Blake2b224
+}
+
+func (e *MIRInsufficientGenesisSigsUtxow) Error() string {
+	return fmt.Sprintf("MIRInsufficientGenesisSigsUTXOW (%v)", e.KeyHashes)
+}
+
+func (e *MIRInsufficientGenesisSigsUtxow) MarshalJSON() ([]byte, error) {
+	return marshalErrorJson("MIRInsufficientGenesisSigsUTXOW", e)
+}
+
+func (e *MIRInsufficientGenesisSigsUtxow) MarshalCBOR() ([]byte, error) {
+	return marshalErrorCbor(e)
+}
+
+type MissingRequiredSigners struct {
+	PredicateFailureBase
+	KeyHashes []Blake2b224
+}
+
+func (e *MissingRequiredSigners) Error() string {
+	return fmt.Sprintf("MissingRequiredSigners (%v)", e.KeyHashes)
+}
+
+func (e *MissingRequiredSigners) MarshalJSON() ([]byte, error) {
+	return marshalErrorJson("MissingRequiredSigners", e)
+}
+
+func (e *MissingRequiredSigners) MarshalCBOR() ([]byte, error) {
+	return marshalErrorCbor(e)
+}
+
+type UpdateFailure struct {
+	UtxoFailureErrorBase
+	Err error
+}
+
+func (e *UpdateFailure) UnmarshalCBOR(data []byte) error {
+	var tmpData wrappedPredicateFailure
+	if _, err := cbor.Decode(data, &tmpData); err != nil {
+		return err
+	}
+	e.Type = tmpData.Type
+	newErr, err := newFailureFromCbor(
+		tmpData.Err,
+		map[int]interface{}{
+			PPUP_FAILURE_NON_GENESIS_UPDATE:    &NonGenesisUpdatePpup{},
+			PPUP_FAILURE_PP_UPDATE_WRONG_EPOCH: &PPUpdateWrongEpoch{},
+			PPUP_FAILURE_PV_CANNOT_FOLLOW:      &PVCannotFollowPpup{},
+		},
+	)
+	if err != nil {
+		return err
+	}
+	e.Err = newErr
+	return nil
+}
+
+func (e *UpdateFailure) Error() string {
+	return fmt.Sprintf("UpdateFailure (%s)", e.Err)
+}
+
+func (e *UpdateFailure) MarshalJSON() ([]byte, error) {
+	return marshalErrorJson("UpdateFailure", e)
+}
+
+func (e *UpdateFailure) Unwrap() error {
+	return e.Err
+}
+
+func (e *UpdateFailure) MarshalCBOR() ([]byte, error) {
+	return marshalErrorCbor(e)
+}
+
+type NonGenesisUpdatePpup struct {
+	PredicateFailureBase
+	SuppliedKeyHashes []Blake2b224
+	GenesisKeyHashes  []Blake2b224
+}
+
+func (e *NonGenesisUpdatePpup) Error() string {
+	return fmt.Sprintf("NonGenesisUpdatePPUP (Supplied %v, Genesis %v)", e.SuppliedKeyHashes, e.GenesisKeyHashes)
+}
+
+func (e *NonGenesisUpdatePpup) MarshalJSON() ([]byte, error) {
+	return marshalErrorJson("NonGenesisUpdatePPUP", e)
+}
+
+func (e *NonGenesisUpdatePpup) MarshalCBOR() ([]byte, error) {
+	return marshalErrorCbor(e)
+}
+
+type PPUpdateWrongEpoch struct {
+	PredicateFailureBase
+	CurrentEpoch uint64
+	TargetEpoch  uint64
+	// 0 for a vote for the current epoch, 1 for a vote for the next epoch
+	VotingPeriod uint8
+}
+
+func (e *PPUpdateWrongEpoch) Error() string {
+	return fmt.Sprintf("PPUpdateWrongEpoch (CurrentEpoch %d, TargetEpoch %d, VotingPeriod %d)", e.CurrentEpoch, e.TargetEpoch, e.VotingPeriod)
+}
+
+func (e *PPUpdateWrongEpoch) MarshalJSON() ([]byte, error) {
+	return marshalErrorJson("PPUpdateWrongEpoch", e)
+}
+
+func (e *PPUpdateWrongEpoch) MarshalCBOR() ([]byte, error) {
+	return marshalErrorCbor(e)
+}
+
+type PVCannotFollowPpup struct {
+	PredicateFailureBase
+	ProtocolVersion ProtocolVersion
+}
+
+func (e *PVCannotFollowPpup) Error() string {
+	return fmt.Sprintf("PVCannotFollowPPUP (ProtocolVersion %d.%d)", e.ProtocolVersion.Major, e.ProtocolVersion.Minor)
+}
+
+func (e *PVCannotFollowPpup) MarshalJSON() ([]byte, error) {
+	return marshalErrorJson("PVCannotFollowPPUP", e)
+}
+
+func (e *PVCannotFollowPpup) MarshalCBOR() ([]byte, error) {
+	return marshalErrorCbor(e)
+}
+
+type DelegsFailure struct {
+	PredicateFailureBase
+	Era uint8
+	Err error
+}
+
+func (e *DelegsFailure) UnmarshalCBOR(data []byte) error {
+	var tmpData wrappedPredicateFailure
+	if _, err := cbor.Decode(data, &tmpData); err != nil {
+		return err
+	}
+	e.Type = tmpData.Type
+	newErr, err := newFailureFromCbor(
+		tmpData.Err,
+		map[int]interface{}{
+			DELEGS_FAILURE_DELEGATEE_NOT_REGISTERED:   &DelegateeNotRegisteredDelegs{},
+			DELEGS_FAILURE_WITHDRAWALS_NOT_IN_REWARDS: &WithdrawalsNotInRewardsDelegs{},
+			DELEGS_FAILURE_DELPL_FAILURE:              &DelplFailure{Era: e.Era},
+		},
+	)
+	if err != nil {
+		return err
+	}
+	e.Err = newErr
+	return nil
+}
+
+func (e *DelegsFailure) Error() string {
+	return fmt.Sprintf("DelegsFailure (%s)", e.Err)
+}
+
+func (e *DelegsFailure) MarshalJSON() ([]byte, error) {
+	return marshalErrorJson("DelegsFailure", e)
+}
+
+func (e *DelegsFailure) Unwrap() error {
+	return e.Err
+}
+
+func (e *DelegsFailure) MarshalCBOR() ([]byte, error) {
+	return marshalErrorCbor(e)
+}
+
+type DelegateeNotRegisteredDelegs struct {
+	PredicateFailureBase
+	PoolKeyHash Blake2b224
+}
+
+func (e *DelegateeNotRegisteredDelegs) Error() string {
+	return fmt.Sprintf("DelegateeNotRegisteredDELEG (KeyHash %s)", e.PoolKeyHash.String())
+}
+
+func (e *DelegateeNotRegisteredDelegs) MarshalJSON() ([]byte, error) {
+	return marshalErrorJson("DelegateeNotRegisteredDELEG", e)
+}
+
+func (e *DelegateeNotRegisteredDelegs) MarshalCBOR() ([]byte, error) {
+	return marshalErrorCbor(e)
+}
+
+type WithdrawalsNotInRewardsDelegs struct {
+	PredicateFailureBase
+	// Withdrawal amounts keyed by reward account
+	Withdrawals map[cbor.ByteString]uint64
+}
+
+func (e *WithdrawalsNotInRewardsDelegs) Error() string {
+	return fmt.Sprintf("WithdrawalsNotInRewardsDELEGS (%v)", e.Withdrawals)
+}
+
+func (e *WithdrawalsNotInRewardsDelegs) MarshalJSON() ([]byte, error) {
+	return marshalErrorJson("WithdrawalsNotInRewardsDELEGS", e)
+}
+
+func (e *WithdrawalsNotInRewardsDelegs) MarshalCBOR() ([]byte, error) {
+	return marshalErrorCbor(e)
+}
+
+type DelplFailure struct {
+	PredicateFailureBase
+	Era uint8
+	Err error
+}
+
+func (e *DelplFailure) UnmarshalCBOR(data []byte) error {
+	var tmpData wrappedPredicateFailure
+	if _, err := cbor.Decode(data, &tmpData); err != nil {
+		return err
+	}
+	e.Type = tmpData.Type
+	newErr, err := newFailureFromCbor(
+		tmpData.Err,
+		map[int]interface{}{
+			DELPL_FAILURE_POOL_FAILURE:  &PoolFailure{},
+			DELPL_FAILURE_DELEG_FAILURE: &DelegFailure{Era: e.Era},
+		},
+	)
+	if err != nil {
+		return err
+	}
+	e.Err = newErr
+	return nil
+}
+
+func (e *DelplFailure) Error() string {
+	return fmt.Sprintf("DelplFailure (%s)", e.Err)
+}
+
+func (e *DelplFailure) MarshalJSON() ([]byte, error) {
+	return marshalErrorJson("DelplFailure", e)
+}
+
+func (e *DelplFailure) Unwrap() error {
+	return e.Err
+}
+
+func (e *DelplFailure) MarshalCBOR() ([]byte, error) {
+	return marshalErrorCbor(e)
+}
+
+func shelleyDelegFailureIdMap() map[int]interface{} {
+	return map[int]interface{}{
+		DELEG_FAILURE_STAKE_KEY_ALREADY_REGISTERED:           &StakeKeyAlreadyRegisteredDeleg{},
+		DELEG_FAILURE_STAKE_KEY_NOT_REGISTERED:               &StakeKeyNotRegisteredDeleg{},
+		DELEG_FAILURE_STAKE_KEY_NON_ZERO_ACCOUNT_BALANCE:     &StakeKeyNonZeroAccountBalanceDeleg{},
+		DELEG_FAILURE_STAKE_DELEGATION_IMPOSSIBLE:            &StakeDelegationImpossibleDeleg{},
+		DELEG_FAILURE_WRONG_CERTIFICATE_TYPE:                 &WrongCertificateTypeDeleg{},
+		DELEG_FAILURE_GENESIS_KEY_NOT_IN_MAPPING:             &GenesisKeyNotInMappingDeleg{},
+		DELEG_FAILURE_DUPLICATE_GENESIS_DELEGATE:             &DuplicateGenesisDelegateDeleg{},
+		DELEG_FAILURE_INSUFFICIENT_FOR_INSTANTANEOUS_REWARDS: &InsufficientForInstantaneousRewardsDeleg{},
+		DELEG_FAILURE_MIR_CERTIFICATE_TOO_LATE_IN_EPOCH:      &MIRCertificateTooLateinEpochDeleg{},
+		DELEG_FAILURE_DUPLICATE_GENESIS_VRF:                  &DuplicateGenesisVRFDeleg{},
+		DELEG_FAILURE_STAKE_KEY_IN_REWARDS:                   &StakeKeyInRewardsDeleg{},
+		DELEG_FAILURE_MIR_TRANSFER_NOT_CURRENTLY_ALLOWED:     &MIRTransferNotCurrentlyAllowed{},
+		DELEG_FAILURE_MIR_NEGATIVES_NOT_CURRENTLY_ALLOWED:    &MIRNegativesNotCurrentlyAllowed{},
+		DELEG_FAILURE_INSUFFICIENT_FOR_TRANSFER:              &InsufficientForTransferDeleg{},
+		DELEG_FAILURE_MIR_PRODUCES_NEGATIVE_UPDATE:           &MIRProducesNegativeUpdate{},
+		DELEG_FAILURE_MIR_NEGATIVE_TRANSFER:                  &MIRNegativeTransfer{},
+	}
+}
+
+type StakeKeyAlreadyRegisteredDeleg struct {
+	PredicateFailureBase
+	StakeCredential Credential
+}
+
+func (e *StakeKeyAlreadyRegisteredDeleg) Error() string {
+	return fmt.Sprintf("StakeKeyAlreadyRegisteredDELEG (%s)", e.StakeCredential.String())
+}
+
+func (e *StakeKeyAlreadyRegisteredDeleg) MarshalJSON() ([]byte, error) {
+	return marshalErrorJson("StakeKeyAlreadyRegisteredDELEG", e)
+}
+
+func (e *StakeKeyAlreadyRegisteredDeleg) MarshalCBOR() ([]byte, error) {
+	return marshalErrorCbor(e)
+}
+
+type StakeKeyNonZeroAccountBalanceDeleg struct {
+	PredicateFailureBase
+	// This is nil when the stake key has no reward account
+	Balance *uint64
+}
+
+func (e *StakeKeyNonZeroAccountBalanceDeleg) Error() string {
+	if e.Balance == nil {
+		return "StakeKeyNonZeroAccountBalanceDELEG (Nothing)"
+	}
+	return fmt.Sprintf("StakeKeyNonZeroAccountBalanceDELEG (Coin %d)", *e.Balance)
+}
+
+func (e *StakeKeyNonZeroAccountBalanceDeleg) MarshalJSON() ([]byte, error) {
+	return marshalErrorJson("StakeKeyNonZeroAccountBalanceDELEG", e)
+}
+
+func (e *StakeKeyNonZeroAccountBalanceDeleg) MarshalCBOR() ([]byte, error) {
+	return marshalErrorCbor(e)
+}
+
+type StakeDelegationImpossibleDeleg struct {
+	PredicateFailureBase
+	StakeCredential Credential
+}
+
+func (e *StakeDelegationImpossibleDeleg) Error() string {
+	return fmt.Sprintf("StakeDelegationImpossibleDELEG (%s)", e.StakeCredential.String())
+}
+
+func (e *StakeDelegationImpossibleDeleg) MarshalJSON() ([]byte, error) {
+	return marshalErrorJson("StakeDelegationImpossibleDELEG", e)
+}
+
+func (e *StakeDelegationImpossibleDeleg) MarshalCBOR() ([]byte, error) {
+	return marshalErrorCbor(e)
+}
+
+type WrongCertificateTypeDeleg struct {
+	PredicateFailureBase
+}
+
+func (e *WrongCertificateTypeDeleg) Error() string {
+	return "WrongCertificateTypeDELEG"
+}
+
+func (e *WrongCertificateTypeDeleg) MarshalJSON() ([]byte, error) {
+	return marshalErrorJson("WrongCertificateTypeDELEG", e)
+}
+
+func (e *WrongCertificateTypeDeleg) MarshalCBOR() ([]byte, error) {
+	return marshalErrorCbor(e)
+}
+
+type GenesisKeyNotInMappingDeleg struct {
+	PredicateFailureBase
+	GenesisKeyHash Blake2b224
+}
+
+func (e *GenesisKeyNotInMappingDeleg) Error() string {
+	return fmt.Sprintf("GenesisKeyNotInMappingDELEG (KeyHash %s)", e.GenesisKeyHash.String())
+}
+
+func (e *GenesisKeyNotInMappingDeleg) MarshalJSON() ([]byte, error) {
+	return marshalErrorJson("GenesisKeyNotInMappingDELEG", e)
+}
+
+func (e *GenesisKeyNotInMappingDeleg) MarshalCBOR() ([]byte, error) {
+	return marshalErrorCbor(e)
+}
+
+type DuplicateGenesisDelegateDeleg struct {
+	PredicateFailureBase
+	GenesisDelegateKeyHash Blake2b224
+}
+
+func (e *DuplicateGenesisDelegateDeleg) Error() string {
+	return fmt.Sprintf("DuplicateGenesisDelegateDELEG (KeyHash %s)", e.GenesisDelegateKeyHash.String())
+}
+
+func (e *DuplicateGenesisDelegateDeleg) MarshalJSON() ([]byte, error) {
+	return marshalErrorJson("DuplicateGenesisDelegateDELEG", e)
+}
+
+func (e *DuplicateGenesisDelegateDeleg) MarshalCBOR() ([]byte, error) {
+	return marshalErrorCbor(e)
+}
+
+type InsufficientForInstantaneousRewardsDeleg struct {
+	PredicateFailureBase
+	Pot       uint8
+	Needed    uint64
+	Available uint64
+}
+
+func (e *InsufficientForInstantaneousRewardsDeleg) Error() string {
+	return fmt.Sprintf("InsufficientForInstantaneousRewardsDELEG (%s, Needed %d, Available %d)", mirPotName(e.Pot), e.Needed, e.Available)
+}
+
+func (e *InsufficientForInstantaneousRewardsDeleg) MarshalJSON() ([]byte, error) {
+	return marshalErrorJson("InsufficientForInstantaneousRewardsDELEG", e)
+}
+
+func (e *InsufficientForInstantaneousRewardsDeleg) MarshalCBOR() ([]byte, error) {
+	return marshalErrorCbor(e)
+}
+
+type MIRCertificateTooLateinEpochDeleg struct {
+	PredicateFailureBase
+	CurrentSlot uint64
+	TooLateSlot uint64
+}
+
+func (e *MIRCertificateTooLateinEpochDeleg) Error() string {
+	return fmt.Sprintf("MIRCertificateTooLateinEpochDELEG (CurrentSlot %d, TooLateSlot %d)", e.CurrentSlot, e.TooLateSlot)
+}
+
+func (e *MIRCertificateTooLateinEpochDeleg) MarshalJSON() ([]byte, error) {
+	return marshalErrorJson("MIRCertificateTooLateinEpochDELEG", e)
+}
+
+func (e *MIRCertificateTooLateinEpochDeleg) MarshalCBOR() ([]byte, error) {
+	return marshalErrorCbor(e)
+}
+
+type DuplicateGenesisVRFDeleg struct {
+	PredicateFailureBase
+	VrfKeyHash Blake2b256
+}
+
+func (e *DuplicateGenesisVRFDeleg) Error() string {
+	return fmt.Sprintf("DuplicateGenesisVRFDELEG (%s)", e.VrfKeyHash.String())
+}
+
+func (e *DuplicateGenesisVRFDeleg) MarshalJSON() ([]byte, error) {
+	return marshalErrorJson("DuplicateGenesisVRFDELEG", e)
+}
+
+func (e *DuplicateGenesisVRFDeleg) MarshalCBOR() ([]byte, error) {
+	return marshalErrorCbor(e)
+}
+
+type StakeKeyInRewardsDeleg struct {
+	PredicateFailureBase
+	StakeCredential Credential
+}
+
+func (e *StakeKeyInRewardsDeleg) Error() string {
+	return fmt.Sprintf("StakeKeyInRewardsDELEG (%s)", e.StakeCredential.String())
+}
+
+func (e *StakeKeyInRewardsDeleg) MarshalJSON() ([]byte, error) {
+	return marshalErrorJson("StakeKeyInRewardsDELEG", e)
+}
+
+func (e *StakeKeyInRewardsDeleg) MarshalCBOR() ([]byte, error) {
+	return marshalErrorCbor(e)
+}
+
+type MIRTransferNotCurrentlyAllowed struct {
+	PredicateFailureBase
+}
+
+func (e *MIRTransferNotCurrentlyAllowed) Error() string {
+	return "MIRTransferNotCurrentlyAllowed"
+}
+
+func (e *MIRTransferNotCurrentlyAllowed) MarshalJSON() ([]byte, error) {
+	return marshalErrorJson("MIRTransferNotCurrentlyAllowed", e)
+}
+
+func (e *MIRTransferNotCurrentlyAllowed) MarshalCBOR() ([]byte, error) {
+	return marshalErrorCbor(e)
+}
+
+type MIRNegativesNotCurrentlyAllowed struct {
+	PredicateFailureBase
+}
+
+func (e *MIRNegativesNotCurrentlyAllowed) Error() string {
+	return "MIRNegativesNotCurrentlyAllowed"
+}
+
+func (e *MIRNegativesNotCurrentlyAllowed) MarshalJSON() ([]byte, error) {
+	return marshalErrorJson("MIRNegativesNotCurrentlyAllowed", e)
+}
+
+func (e *MIRNegativesNotCurrentlyAllowed) MarshalCBOR() ([]byte, error) {
+	return marshalErrorCbor(e)
+}
+
+type InsufficientForTransferDeleg struct {
+	PredicateFailureBase
+	Pot       uint8
+	Needed    uint64
+	Available uint64
+}
+
+func (e *InsufficientForTransferDeleg) Error() string {
+	return fmt.Sprintf("InsufficientForTransferDELEG (%s, Needed %d, Available %d)", mirPotName(e.Pot), e.Needed, e.Available)
+}
+
+func (e *InsufficientForTransferDeleg) MarshalJSON() ([]byte, error) {
+	return marshalErrorJson("InsufficientForTransferDELEG", e)
+}
+
+func (e *InsufficientForTransferDeleg) MarshalCBOR() ([]byte, error) {
+	return marshalErrorCbor(e)
+}
+
+type MIRProducesNegativeUpdate struct {
+	PredicateFailureBase
+}
+
+func (e *MIRProducesNegativeUpdate) Error() string {
+	return "MIRProducesNegativeUpdate"
+}
+
+func (e *MIRProducesNegativeUpdate) MarshalJSON() ([]byte, error) {
+	return marshalErrorJson("MIRProducesNegativeUpdate", e)
+}
+
+func (e *MIRProducesNegativeUpdate) MarshalCBOR() ([]byte, error) {
+	return marshalErrorCbor(e)
+}
+
+type MIRNegativeTransfer struct {
+	PredicateFailureBase
+	Pot    uint8
+	Amount uint64
+}
+
+func (e *MIRNegativeTransfer) Error() string {
+	return fmt.Sprintf("MIRNegativeTransfer (%s, Amount %d)", mirPotName(e.Pot), e.Amount)
+}
+
+func (e *MIRNegativeTransfer) MarshalJSON() ([]byte, error) {
+	return marshalErrorJson("MIRNegativeTransfer", e)
+}
+
+func (e *MIRNegativeTransfer) MarshalCBOR() ([]byte, error) {
+	return marshalErrorCbor(e)
+}
+
+func mirPotName(pot uint8) string {
+	switch pot {
+	case MOVE_INSTANTANEOUS_REWARD_SOURCE_RESERVES:
+		return "ReservesMIR"
+	case MOVE_INSTANTANEOUS_REWARD_SOURCE_TREASURY:
+		return "TreasuryMIR"
+	default:
+		return fmt.Sprintf("UnknownMIR %d", pot)
+	}
 }
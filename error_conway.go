@@ -0,0 +1,998 @@
+package ledger
+
+import (
+	"fmt"
+
+	"github.com/cloudstruct/go-cardano-ledger/cbor"
+)
+
+const (
+	CONWAY_LEDGER_FAILURE_UTXOW                       = 1
+	CONWAY_LEDGER_FAILURE_CERTS                       = 2
+	CONWAY_LEDGER_FAILURE_GOV                         = 3
+	CONWAY_LEDGER_FAILURE_WDRL_NOT_DELEGATED_TO_DREP  = 4
+	CONWAY_LEDGER_FAILURE_TREASURY_VALUE_MISMATCH     = 5
+	CONWAY_LEDGER_FAILURE_TX_REF_SCRIPTS_SIZE_TOO_BIG = 6
+	CONWAY_LEDGER_FAILURE_MEMPOOL_FAILURE             = 7
+
+	CONWAY_UTXOW_FAILURE_UTXO_FAILURE                        = 0
+	CONWAY_UTXOW_FAILURE_INVALID_WITNESSES_UTXOW             = 1
+	CONWAY_UTXOW_FAILURE_MISSING_VKEY_WITNESSES_UTXOW        = 2
+	CONWAY_UTXOW_FAILURE_MISSING_SCRIPT_WITNESSES_UTXOW      = 3
+	CONWAY_UTXOW_FAILURE_SCRIPT_WITNESS_NOT_VALIDATING_UTXOW = 4
+	CONWAY_UTXOW_FAILURE_MISSING_TX_BODY_METADATA_HASH       = 5
+	CONWAY_UTXOW_FAILURE_MISSING_TX_METADATA                 = 6
+	CONWAY_UTXOW_FAILURE_CONFLICTING_METADATA_HASH           = 7
+	CONWAY_UTXOW_FAILURE_INVALID_METADATA                    = 8
+	CONWAY_UTXOW_FAILURE_EXTRANEOUS_SCRIPT_WITNESSES_UTXOW   = 9
+	CONWAY_UTXOW_FAILURE_MISSING_REDEEMERS                   = 10
+	CONWAY_UTXOW_FAILURE_MISSING_REQUIRED_DATUMS             = 11
+	CONWAY_UTXOW_FAILURE_NOT_ALLOWED_SUPPLEMENTAL_DATUMS     = 12
+	CONWAY_UTXOW_FAILURE_PP_VIEW_HASHES_DONT_MATCH           = 13
+	CONWAY_UTXOW_FAILURE_UNSPENDABLE_UTXO_NO_DATUM_HASH      = 14
+	CONWAY_UTXOW_FAILURE_EXTRA_REDEEMERS                     = 15
+	CONWAY_UTXOW_FAILURE_MALFORMED_SCRIPT_WITNESSES          = 16
+	CONWAY_UTXOW_FAILURE_MALFORMED_REFERENCE_SCRIPTS         = 17
+
+	CONWAY_UTXO_FAILURE_UTXOS_FAILURE                    = 0
+	CONWAY_UTXO_FAILURE_BAD_INPUTS_UTXO                  = 1
+	CONWAY_UTXO_FAILURE_OUTSIDE_VALIDITY_INTERVAL_UTXO   = 2
+	CONWAY_UTXO_FAILURE_MAX_TX_SIZE_UTXO                 = 3
+	CONWAY_UTXO_FAILURE_INPUT_SET_EMPTY                  = 4
+	CONWAY_UTXO_FAILURE_FEE_TOO_SMALL_UTXO               = 5
+	CONWAY_UTXO_FAILURE_VALUE_NOT_CONSERVED_UTXO         = 6
+	CONWAY_UTXO_FAILURE_WRONG_NETWORK                    = 7
+	CONWAY_UTXO_FAILURE_WRONG_NETWORK_WITHDRAWAL         = 8
+	CONWAY_UTXO_FAILURE_OUTPUT_TOO_SMALL_UTXO            = 9
+	CONWAY_UTXO_FAILURE_OUTPUT_BOOT_ADDR_ATTRS_TOO_BIG   = 10
+	CONWAY_UTXO_FAILURE_OUTPUT_TOO_BIG_UTXO              = 11
+	CONWAY_UTXO_FAILURE_INSUFFICIENT_COLLATERAL          = 12
+	CONWAY_UTXO_FAILURE_SCRIPTS_NOT_PAID_UTXO            = 13
+	CONWAY_UTXO_FAILURE_EX_UNITS_TOO_BIG_UTXO            = 14
+	CONWAY_UTXO_FAILURE_COLLATERAL_CONTAINS_NON_ADA      = 15
+	CONWAY_UTXO_FAILURE_WRONG_NETWORK_IN_TX_BODY         = 16
+	CONWAY_UTXO_FAILURE_OUTSIDE_FORECAST                 = 17
+	CONWAY_UTXO_FAILURE_TOO_MANY_COLLATERAL_INPUTS       = 18
+	CONWAY_UTXO_FAILURE_NO_COLLATERAL_INPUTS             = 19
+	CONWAY_UTXO_FAILURE_INCORRECT_TOTAL_COLLATERAL_FIELD = 20
+	CONWAY_UTXO_FAILURE_BABBAGE_OUTPUT_TOO_SMALL_UTXO    = 21
+	CONWAY_UTXO_FAILURE_BABBAGE_NON_DISJOINT_REF_INPUTS  = 22
+
+	CONWAY_CERTS_FAILURE_WITHDRAWALS_NOT_IN_REWARDS = 0
+	CONWAY_CERTS_FAILURE_CERT_FAILURE               = 1
+
+	CONWAY_CERT_FAILURE_DELEG_FAILURE    = 1
+	CONWAY_CERT_FAILURE_POOL_FAILURE     = 2
+	CONWAY_CERT_FAILURE_GOV_CERT_FAILURE = 3
+
+	CONWAY_DELEG_FAILURE_INCORRECT_DEPOSIT                      = 1
+	CONWAY_DELEG_FAILURE_STAKE_KEY_REGISTERED                   = 2
+	CONWAY_DELEG_FAILURE_STAKE_KEY_NOT_REGISTERED               = 3
+	CONWAY_DELEG_FAILURE_STAKE_KEY_HAS_NON_ZERO_ACCOUNT_BALANCE = 4
+	CONWAY_DELEG_FAILURE_DELEGATEE_DREP_NOT_REGISTERED          = 5
+	CONWAY_DELEG_FAILURE_DELEGATEE_STAKE_POOL_NOT_REGISTERED    = 6
+
+	CONWAY_GOV_CERT_FAILURE_DREP_ALREADY_REGISTERED           = 0
+	CONWAY_GOV_CERT_FAILURE_DREP_NOT_REGISTERED               = 1
+	CONWAY_GOV_CERT_FAILURE_DREP_INCORRECT_DEPOSIT            = 2
+	CONWAY_GOV_CERT_FAILURE_COMMITTEE_HAS_PREVIOUSLY_RESIGNED = 3
+	CONWAY_GOV_CERT_FAILURE_DREP_INCORRECT_REFUND             = 4
+	CONWAY_GOV_CERT_FAILURE_COMMITTEE_IS_UNKNOWN              = 5
+
+	CONWAY_GOV_FAILURE_GOV_ACTIONS_DO_NOT_EXIST                         = 0
+	CONWAY_GOV_FAILURE_MALFORMED_PROPOSAL                               = 1
+	CONWAY_GOV_FAILURE_PROPOSAL_PROCEDURE_NETWORK_ID_MISMATCH           = 2
+	CONWAY_GOV_FAILURE_TREASURY_WITHDRAWALS_NETWORK_ID_MISMATCH         = 3
+	CONWAY_GOV_FAILURE_PROPOSAL_DEPOSIT_INCORRECT                       = 4
+	CONWAY_GOV_FAILURE_DISALLOWED_VOTERS                                = 5
+	CONWAY_GOV_FAILURE_CONFLICTING_COMMITTEE_UPDATE                     = 6
+	CONWAY_GOV_FAILURE_EXPIRATION_EPOCH_TOO_SMALL                       = 7
+	CONWAY_GOV_FAILURE_INVALID_PREV_GOV_ACTION_ID                       = 8
+	CONWAY_GOV_FAILURE_VOTING_ON_EXPIRED_GOV_ACTION                     = 9
+	CONWAY_GOV_FAILURE_PROPOSAL_CANT_FOLLOW                             = 10
+	CONWAY_GOV_FAILURE_INVALID_POLICY_HASH                              = 11
+	CONWAY_GOV_FAILURE_DISALLOWED_PROPOSAL_DURING_BOOTSTRAP             = 12
+	CONWAY_GOV_FAILURE_DISALLOWED_VOTES_DURING_BOOTSTRAP                = 13
+	CONWAY_GOV_FAILURE_VOTERS_DO_NOT_EXIST                              = 14
+	CONWAY_GOV_FAILURE_ZERO_TREASURY_WITHDRAWALS                        = 15
+	CONWAY_GOV_FAILURE_PROPOSAL_RETURN_ACCOUNT_DOES_NOT_EXIST           = 16
+	CONWAY_GOV_FAILURE_TREASURY_WITHDRAWAL_RETURN_ACCOUNTS_DO_NOT_EXIST = 17
+)
+
+type ConwayUtxowFailure struct {
+	PredicateFailureBase
+	Err error
+}
+
+func (e *ConwayUtxowFailure) UnmarshalCBOR(data []byte) error {
+	var tmpData wrappedPredicateFailure
+	if _, err := cbor.Decode(data, &tmpData); err != nil {
+		return err
+	}
+	e.Type = tmpData.Type
+	newErr, err := newFailureFromCbor(
+		tmpData.Err,
+		map[int]interface{}{
+			CONWAY_UTXOW_FAILURE_UTXO_FAILURE:                        &UtxoFailure{Era: ERA_ID_CONWAY},
+			CONWAY_UTXOW_FAILURE_INVALID_WITNESSES_UTXOW:             &InvalidWitnessesUtxow{},
+			CONWAY_UTXOW_FAILURE_MISSING_VKEY_WITNESSES_UTXOW:        &MissingVKeyWitnessesUtxow{},
+			CONWAY_UTXOW_FAILURE_MISSING_SCRIPT_WITNESSES_UTXOW:      &MissingScriptWitnessesUtxow{},
+			CONWAY_UTXOW_FAILURE_SCRIPT_WITNESS_NOT_VALIDATING_UTXOW: &ScriptWitnessNotValidatingUtxow{},
+			CONWAY_UTXOW_FAILURE_MISSING_TX_BODY_METADATA_HASH:       &MissingTxBodyMetadataHash{},
+			CONWAY_UTXOW_FAILURE_MISSING_TX_METADATA:                 &MissingTxMetadata{},
+			CONWAY_UTXOW_FAILURE_CONFLICTING_METADATA_HASH:           &ConflictingMetadataHash{},
+			CONWAY_UTXOW_FAILURE_INVALID_METADATA:                    &InvalidMetadata{},
+			CONWAY_UTXOW_FAILURE_EXTRANEOUS_SCRIPT_WITNESSES_UTXOW:   &ExtraneousScriptWitnessesUtxow{},
+			CONWAY_UTXOW_FAILURE_MISSING_REDEEMERS:                   &MissingRedeemers{},
+			CONWAY_UTXOW_FAILURE_MISSING_REQUIRED_DATUMS:             &MissingRequiredDatums{},
+			CONWAY_UTXOW_FAILURE_NOT_ALLOWED_SUPPLEMENTAL_DATUMS:     &NotAllowedSupplementalDatums{},
+			CONWAY_UTXOW_FAILURE_PP_VIEW_HASHES_DONT_MATCH:           &PPViewHashesDontMatch{},
+			CONWAY_UTXOW_FAILURE_UNSPENDABLE_UTXO_NO_DATUM_HASH:      &UnspendableUtxoNoDatumHash{},
+			CONWAY_UTXOW_FAILURE_EXTRA_REDEEMERS:                     &ExtraRedeemers{},
+			CONWAY_UTXOW_FAILURE_MALFORMED_SCRIPT_WITNESSES:          &MalformedScriptWitnesses{},
+			CONWAY_UTXOW_FAILURE_MALFORMED_REFERENCE_SCRIPTS:         &MalformedReferenceScripts{},
+		},
+	)
+	if err != nil {
+		return err
+	}
+	e.Err = newErr
+	return nil
+}
+
+func (e *ConwayUtxowFailure) Error() string {
+	return fmt.Sprintf("ConwayUtxowFailure (%s)", e.Err)
+}
+
+func (e *ConwayUtxowFailure) MarshalJSON() ([]byte, error) {
+	return marshalErrorJson("ConwayUtxowFailure", e)
+}
+
+func (e *ConwayUtxowFailure) Unwrap() error {
+	return e.Err
+}
+
+func (e *ConwayUtxowFailure) MarshalCBOR() ([]byte, error) {
+	return marshalErrorCbor(e)
+}
+
+func conwayUtxoFailureIdMap() map[int]interface{} {
+	return map[int]interface{}{
+		CONWAY_UTXO_FAILURE_UTXOS_FAILURE:                    &UtxosFailure{Era: ERA_ID_CONWAY},
+		CONWAY_UTXO_FAILURE_BAD_INPUTS_UTXO:                  &BadInputsUtxo{},
+		CONWAY_UTXO_FAILURE_OUTSIDE_VALIDITY_INTERVAL_UTXO:   &OutsideValidityIntervalUtxo{},
+		CONWAY_UTXO_FAILURE_MAX_TX_SIZE_UTXO:                 &MaxTxSizeUtxo{},
+		CONWAY_UTXO_FAILURE_INPUT_SET_EMPTY:                  &InputSetEmptyUtxo{},
+		CONWAY_UTXO_FAILURE_FEE_TOO_SMALL_UTXO:               &FeeTooSmallUtxo{},
+		CONWAY_UTXO_FAILURE_VALUE_NOT_CONSERVED_UTXO:         &ValueNotConservedUtxo{},
+		CONWAY_UTXO_FAILURE_WRONG_NETWORK:                    &WrongNetwork{},
+		CONWAY_UTXO_FAILURE_WRONG_NETWORK_WITHDRAWAL:         &WrongNetworkWithdrawal{},
+		CONWAY_UTXO_FAILURE_OUTPUT_TOO_SMALL_UTXO:            &OutputTooSmallUtxo{},
+		CONWAY_UTXO_FAILURE_OUTPUT_BOOT_ADDR_ATTRS_TOO_BIG:   &OutputBootAddrAttrsTooBig{},
+		CONWAY_UTXO_FAILURE_OUTPUT_TOO_BIG_UTXO:              &OutputTooBigUtxo{},
+		CONWAY_UTXO_FAILURE_INSUFFICIENT_COLLATERAL:          &InsufficientCollateral{},
+		CONWAY_UTXO_FAILURE_SCRIPTS_NOT_PAID_UTXO:            &ScriptsNotPaidUtxo{},
+		CONWAY_UTXO_FAILURE_EX_UNITS_TOO_BIG_UTXO:            &ExUnitsTooBigUtxo{},
+		CONWAY_UTXO_FAILURE_COLLATERAL_CONTAINS_NON_ADA:      &CollateralContainsNonADA{},
+		CONWAY_UTXO_FAILURE_WRONG_NETWORK_IN_TX_BODY:         &WrongNetworkInTxBody{},
+		CONWAY_UTXO_FAILURE_OUTSIDE_FORECAST:                 &OutsideForecast{},
+		CONWAY_UTXO_FAILURE_TOO_MANY_COLLATERAL_INPUTS:       &TooManyCollateralInputs{},
+		CONWAY_UTXO_FAILURE_NO_COLLATERAL_INPUTS:             &NoCollateralInputs{},
+		CONWAY_UTXO_FAILURE_INCORRECT_TOTAL_COLLATERAL_FIELD: &IncorrectTotalCollateralField{},
+		CONWAY_UTXO_FAILURE_BABBAGE_OUTPUT_TOO_SMALL_UTXO:    &BabbageOutputTooSmallUtxo{},
+		CONWAY_UTXO_FAILURE_BABBAGE_NON_DISJOINT_REF_INPUTS:  &BabbageNonDisjointRefInputs{},
+	}
+}
+
+type ConwayCertsFailure struct {
+	PredicateFailureBase
+	Err error
+}
+
+func (e *ConwayCertsFailure) UnmarshalCBOR(data []byte) error {
+	var tmpData wrappedPredicateFailure
+	if _, err := cbor.Decode(data, &tmpData); err != nil {
+		return err
+	}
+	e.Type = tmpData.Type
+	newErr, err := newFailureFromCbor(
+		tmpData.Err,
+		map[int]interface{}{
+			CONWAY_CERTS_FAILURE_WITHDRAWALS_NOT_IN_REWARDS: &WithdrawalsNotInRewardsCerts{},
+			CONWAY_CERTS_FAILURE_CERT_FAILURE:               &CertFailure{},
+		},
+	)
+	if err != nil {
+		return err
+	}
+	e.Err = newErr
+	return nil
+}
+
+func (e *ConwayCertsFailure) Error() string {
+	return fmt.Sprintf("ConwayCertsFailure (%s)", e.Err)
+}
+
+func (e *ConwayCertsFailure) MarshalJSON() ([]byte, error) {
+	return marshalErrorJson("ConwayCertsFailure", e)
+}
+
+func (e *ConwayCertsFailure) Unwrap() error {
+	return e.Err
+}
+
+func (e *ConwayCertsFailure) MarshalCBOR() ([]byte, error) {
+	return marshalErrorCbor(e)
+}
+
+type WithdrawalsNotInRewardsCerts struct {
+	PredicateFailureBase
+	Withdrawals map[cbor.ByteString]uint64
+}
+
+func (e *WithdrawalsNotInRewardsCerts) Error() string {
+	return fmt.Sprintf("WithdrawalsNotInRewardsCERTS (%v)", e.Withdrawals)
+}
+
+func (e *WithdrawalsNotInRewardsCerts) MarshalJSON() ([]byte, error) {
+	return marshalErrorJson("WithdrawalsNotInRewardsCERTS", e)
+}
+
+func (e *WithdrawalsNotInRewardsCerts) MarshalCBOR() ([]byte, error) {
+	return marshalErrorCbor(e)
+}
+
+type CertFailure struct {
+	PredicateFailureBase
+	Err error
+}
+
+func (e *CertFailure) UnmarshalCBOR(data []byte) error {
+	var tmpData wrappedPredicateFailure
+	if _, err := cbor.Decode(data, &tmpData); err != nil {
+		return err
+	}
+	e.Type = tmpData.Type
+	newErr, err := newFailureFromCbor(
+		tmpData.Err,
+		map[int]interface{}{
+			CONWAY_CERT_FAILURE_DELEG_FAILURE:    &DelegFailure{Era: ERA_ID_CONWAY},
+			CONWAY_CERT_FAILURE_POOL_FAILURE:     &PoolFailure{},
+			CONWAY_CERT_FAILURE_GOV_CERT_FAILURE: &GovCertFailure{},
+		},
+	)
+	if err != nil {
+		return err
+	}
+	e.Err = newErr
+	return nil
+}
+
+func (e *CertFailure) Error() string {
+	return fmt.Sprintf("CertFailure (%s)", e.Err)
+}
+
+func (e *CertFailure) MarshalJSON() ([]byte, error) {
+	return marshalErrorJson("CertFailure", e)
+}
+
+func (e *CertFailure) Unwrap() error {
+	return e.Err
+}
+
+func (e *CertFailure) MarshalCBOR() ([]byte, error) {
+	return marshalErrorCbor(e)
+}
+
+func conwayDelegFailureIdMap() map[int]interface{} {
+	return map[int]interface{}{
+		CONWAY_DELEG_FAILURE_INCORRECT_DEPOSIT:                      &IncorrectDepositDeleg{},
+		CONWAY_DELEG_FAILURE_STAKE_KEY_REGISTERED:                   &StakeKeyRegisteredDeleg{},
+		CONWAY_DELEG_FAILURE_STAKE_KEY_NOT_REGISTERED:               &StakeKeyNotRegisteredDeleg{},
+		CONWAY_DELEG_FAILURE_STAKE_KEY_HAS_NON_ZERO_ACCOUNT_BALANCE: &StakeKeyHasNonZeroRewardAccountBalanceDeleg{},
+		CONWAY_DELEG_FAILURE_DELEGATEE_DREP_NOT_REGISTERED:          &DelegateeDRepNotRegisteredDeleg{},
+		CONWAY_DELEG_FAILURE_DELEGATEE_STAKE_POOL_NOT_REGISTERED:    &DelegateeStakePoolNotRegisteredDeleg{},
+	}
+}
+
+type IncorrectDepositDeleg struct {
+	PredicateFailureBase
+	Amount uint64
+}
+
+func (e *IncorrectDepositDeleg) Error() string {
+	return fmt.Sprintf("IncorrectDepositDELEG (Coin %d)", e.Amount)
+}
+
+func (e *IncorrectDepositDeleg) MarshalJSON() ([]byte, error) {
+	return marshalErrorJson("IncorrectDepositDELEG", e)
+}
+
+func (e *IncorrectDepositDeleg) MarshalCBOR() ([]byte, error) {
+	return marshalErrorCbor(e)
+}
+
+type StakeKeyRegisteredDeleg struct {
+	PredicateFailureBase
+	StakeCredential Credential
+}
+
+func (e *StakeKeyRegisteredDeleg) Error() string {
+	return fmt.Sprintf("StakeKeyRegisteredDELEG (%s)", e.StakeCredential.String())
+}
+
+func (e *StakeKeyRegisteredDeleg) MarshalJSON() ([]byte, error) {
+	return marshalErrorJson("StakeKeyRegisteredDELEG", e)
+}
+
+func (e *StakeKeyRegisteredDeleg) MarshalCBOR() ([]byte, error) {
+	return marshalErrorCbor(e)
+}
+
+type StakeKeyHasNonZeroRewardAccountBalanceDeleg struct {
+	PredicateFailureBase
+	Balance uint64
+}
+
+func (e *StakeKeyHasNonZeroRewardAccountBalanceDeleg) Error() string {
+	return fmt.Sprintf("StakeKeyHasNonZeroRewardAccountBalanceDELEG (Coin %d)", e.Balance)
+}
+
+func (e *StakeKeyHasNonZeroRewardAccountBalanceDeleg) MarshalJSON() ([]byte, error) {
+	return marshalErrorJson("StakeKeyHasNonZeroRewardAccountBalanceDELEG", e)
+}
+
+func (e *StakeKeyHasNonZeroRewardAccountBalanceDeleg) MarshalCBOR() ([]byte, error) {
+	return marshalErrorCbor(e)
+}
+
+type DelegateeDRepNotRegisteredDeleg struct {
+	PredicateFailureBase
+	Drep Credential
+}
+
+func (e *DelegateeDRepNotRegisteredDeleg) Error() string {
+	return fmt.Sprintf("DelegateeDRepNotRegisteredDELEG (%s)", e.Drep.String())
+}
+
+func (e *DelegateeDRepNotRegisteredDeleg) MarshalJSON() ([]byte, error) {
+	return marshalErrorJson("DelegateeDRepNotRegisteredDELEG", e)
+}
+
+func (e *DelegateeDRepNotRegisteredDeleg) MarshalCBOR() ([]byte, error) {
+	return marshalErrorCbor(e)
+}
+
+type DelegateeStakePoolNotRegisteredDeleg struct {
+	PredicateFailureBase
+	PoolKeyHash Blake2b224
+}
+
+func (e *DelegateeStakePoolNotRegisteredDeleg) Error() string {
+	return fmt.Sprintf("DelegateeStakePoolNotRegisteredDELEG (KeyHash %s)", e.PoolKeyHash.String())
+}
+
+func (e *DelegateeStakePoolNotRegisteredDeleg) MarshalJSON() ([]byte, error) {
+	return marshalErrorJson("DelegateeStakePoolNotRegisteredDELEG", e)
+}
+
+func (e *DelegateeStakePoolNotRegisteredDeleg) MarshalCBOR() ([]byte, error) {
+	return marshalErrorCbor(e)
+}
+
+type GovCertFailure struct {
+	PredicateFailureBase
+	Err error
+}
+
+func (e *GovCertFailure) UnmarshalCBOR(data []byte) error {
+	var tmpData wrappedPredicateFailure
+	if _, err := cbor.Decode(data, &tmpData); err != nil {
+		return err
+	}
+	e.Type = tmpData.Type
+	newErr, err := newFailureFromCbor(
+		tmpData.Err,
+		map[int]interface{}{
+			CONWAY_GOV_CERT_FAILURE_DREP_ALREADY_REGISTERED:           &ConwayDRepAlreadyRegistered{},
+			CONWAY_GOV_CERT_FAILURE_DREP_NOT_REGISTERED:               &ConwayDRepNotRegistered{},
+			CONWAY_GOV_CERT_FAILURE_DREP_INCORRECT_DEPOSIT:            &ConwayDRepIncorrectDeposit{},
+			CONWAY_GOV_CERT_FAILURE_COMMITTEE_HAS_PREVIOUSLY_RESIGNED: &ConwayCommitteeHasPreviouslyResigned{},
+			CONWAY_GOV_CERT_FAILURE_DREP_INCORRECT_REFUND:             &ConwayDRepIncorrectRefund{},
+			CONWAY_GOV_CERT_FAILURE_COMMITTEE_IS_UNKNOWN:              &ConwayCommitteeIsUnknown{},
+		},
+	)
+	if err != nil {
+		return err
+	}
+	e.Err = newErr
+	return nil
+}
+
+func (e *GovCertFailure) Error() string {
+	return fmt.Sprintf("GovCertFailure (%s)", e.Err)
+}
+
+func (e *GovCertFailure) MarshalJSON() ([]byte, error) {
+	return marshalErrorJson("GovCertFailure", e)
+}
+
+func (e *GovCertFailure) Unwrap() error {
+	return e.Err
+}
+
+func (e *GovCertFailure) MarshalCBOR() ([]byte, error) {
+	return marshalErrorCbor(e)
+}
+
+type ConwayDRepAlreadyRegistered struct {
+	PredicateFailureBase
+	Credential Credential
+}
+
+func (e *ConwayDRepAlreadyRegistered) Error() string {
+	return fmt.Sprintf("ConwayDRepAlreadyRegistered (%s)", e.Credential.String())
+}
+
+func (e *ConwayDRepAlreadyRegistered) MarshalJSON() ([]byte, error) {
+	return marshalErrorJson("ConwayDRepAlreadyRegistered", e)
+}
+
+func (e *ConwayDRepAlreadyRegistered) MarshalCBOR() ([]byte, error) {
+	return marshalErrorCbor(e)
+}
+
+type ConwayDRepNotRegistered struct {
+	PredicateFailureBase
+	Credential Credential
+}
+
+func (e *ConwayDRepNotRegistered) Error() string {
+	return fmt.Sprintf("ConwayDRepNotRegistered (%s)", e.Credential.String())
+}
+
+func (e *ConwayDRepNotRegistered) MarshalJSON() ([]byte, error) {
+	return marshalErrorJson("ConwayDRepNotRegistered", e)
+}
+
+func (e *ConwayDRepNotRegistered) MarshalCBOR() ([]byte, error) {
+	return marshalErrorCbor(e)
+}
+
+type ConwayDRepIncorrectDeposit struct {
+	PredicateFailureBase
+	Supplied uint64
+	Expected uint64
+}
+
+func (e *ConwayDRepIncorrectDeposit) Error() string {
+	return fmt.Sprintf("ConwayDRepIncorrectDeposit (Supplied %d, Expected %d)", e.Supplied, e.Expected)
+}
+
+func (e *ConwayDRepIncorrectDeposit) MarshalJSON() ([]byte, error) {
+	return marshalErrorJson("ConwayDRepIncorrectDeposit", e)
+}
+
+func (e *ConwayDRepIncorrectDeposit) MarshalCBOR() ([]byte, error) {
+	return marshalErrorCbor(e)
+}
+
+type ConwayCommitteeHasPreviouslyResigned struct {
+	PredicateFailureBase
+	ColdCredential Credential
+}
+
+func (e *ConwayCommitteeHasPreviouslyResigned) Error() string {
+	return fmt.Sprintf("ConwayCommitteeHasPreviouslyResigned (%s)", e.ColdCredential.String())
+}
+
+func (e *ConwayCommitteeHasPreviouslyResigned) MarshalJSON() ([]byte, error) {
+	return marshalErrorJson("ConwayCommitteeHasPreviouslyResigned", e)
+}
+
+func (e *ConwayCommitteeHasPreviouslyResigned) MarshalCBOR() ([]byte, error) {
+	return marshalErrorCbor(e)
+}
+
+type ConwayDRepIncorrectRefund struct {
+	PredicateFailureBase
+	Supplied uint64
+	Expected uint64
+}
+
+func (e *ConwayDRepIncorrectRefund) Error() string {
+	return fmt.Sprintf("ConwayDRepIncorrectRefund (Supplied %d, Expected %d)", e.Supplied, e.Expected)
+}
+
+func (e *ConwayDRepIncorrectRefund) MarshalJSON() ([]byte, error) {
+	return marshalErrorJson("ConwayDRepIncorrectRefund", e)
+}
+
+func (e *ConwayDRepIncorrectRefund) MarshalCBOR() ([]byte, error) {
+	return marshalErrorCbor(e)
+}
+
+type ConwayCommitteeIsUnknown struct {
+	PredicateFailureBase
+	ColdCredential Credential
+}
+
+func (e *ConwayCommitteeIsUnknown) Error() string {
+	return fmt.Sprintf("ConwayCommitteeIsUnknown (%s)", e.ColdCredential.String())
+}
+
+func (e *ConwayCommitteeIsUnknown) MarshalJSON() ([]byte, error) {
+	return marshalErrorJson("ConwayCommitteeIsUnknown", e)
+}
+
+func (e *ConwayCommitteeIsUnknown) MarshalCBOR() ([]byte, error) {
+	return marshalErrorCbor(e)
+}
+
+type ConwayGovFailure struct {
+	PredicateFailureBase
+	Err error
+}
+
+func (e *ConwayGovFailure) UnmarshalCBOR(data []byte) error {
+	var tmpData wrappedPredicateFailure
+	if _, err := cbor.Decode(data, &tmpData); err != nil {
+		return err
+	}
+	e.Type = tmpData.Type
+	newErr, err := newFailureFromCbor(
+		tmpData.Err,
+		map[int]interface{}{
+			CONWAY_GOV_FAILURE_GOV_ACTIONS_DO_NOT_EXIST:                         &GovActionsDoNotExist{},
+			CONWAY_GOV_FAILURE_MALFORMED_PROPOSAL:                               &MalformedProposal{},
+			CONWAY_GOV_FAILURE_PROPOSAL_PROCEDURE_NETWORK_ID_MISMATCH:           &ProposalProcedureNetworkIdMismatch{},
+			CONWAY_GOV_FAILURE_TREASURY_WITHDRAWALS_NETWORK_ID_MISMATCH:         &TreasuryWithdrawalsNetworkIdMismatch{},
+			CONWAY_GOV_FAILURE_PROPOSAL_DEPOSIT_INCORRECT:                       &ProposalDepositIncorrect{},
+			CONWAY_GOV_FAILURE_DISALLOWED_VOTERS:                                &DisallowedVoters{},
+			CONWAY_GOV_FAILURE_CONFLICTING_COMMITTEE_UPDATE:                     &ConflictingCommitteeUpdate{},
+			CONWAY_GOV_FAILURE_EXPIRATION_EPOCH_TOO_SMALL:                       &ExpirationEpochTooSmall{},
+			CONWAY_GOV_FAILURE_INVALID_PREV_GOV_ACTION_ID:                       &InvalidPrevGovActionId{},
+			CONWAY_GOV_FAILURE_VOTING_ON_EXPIRED_GOV_ACTION:                     &VotingOnExpiredGovAction{},
+			CONWAY_GOV_FAILURE_PROPOSAL_CANT_FOLLOW:                             &ProposalCantFollow{},
+			CONWAY_GOV_FAILURE_INVALID_POLICY_HASH:                              &InvalidPolicyHash{},
+			CONWAY_GOV_FAILURE_DISALLOWED_PROPOSAL_DURING_BOOTSTRAP:             &DisallowedProposalDuringBootstrap{},
+			CONWAY_GOV_FAILURE_DISALLOWED_VOTES_DURING_BOOTSTRAP:                &DisallowedVotesDuringBootstrap{},
+			CONWAY_GOV_FAILURE_VOTERS_DO_NOT_EXIST:                              &VotersDoNotExist{},
+			CONWAY_GOV_FAILURE_ZERO_TREASURY_WITHDRAWALS:                        &ZeroTreasuryWithdrawals{},
+			CONWAY_GOV_FAILURE_PROPOSAL_RETURN_ACCOUNT_DOES_NOT_EXIST:           &ProposalReturnAccountDoesNotExist{},
+			CONWAY_GOV_FAILURE_TREASURY_WITHDRAWAL_RETURN_ACCOUNTS_DO_NOT_EXIST: &TreasuryWithdrawalReturnAccountsDoNotExist{},
+		},
+	)
+	if err != nil {
+		return err
+	}
+	e.Err = newErr
+	return nil
+}
+
+func (e *ConwayGovFailure) Error() string {
+	return fmt.Sprintf("ConwayGovFailure (%s)", e.Err)
+}
+
+func (e *ConwayGovFailure) MarshalJSON() ([]byte, error) {
+	return marshalErrorJson("ConwayGovFailure", e)
+}
+
+func (e *ConwayGovFailure) Unwrap() error {
+	return e.Err
+}
+
+func (e *ConwayGovFailure) MarshalCBOR() ([]byte, error) {
+	return marshalErrorCbor(e)
+}
+
+type GovActionsDoNotExist struct {
+	PredicateFailureBase
+	GovActionIds []GovActionId
+}
+
+func (e *GovActionsDoNotExist) Error() string {
+	ret := "GovActionsDoNotExist (["
+	for idx, govActionId := range e.GovActionIds {
+		ret = fmt.Sprintf("%s%s", ret, govActionId.String())
+		if idx < (len(e.GovActionIds) - 1) {
+			ret = fmt.Sprintf("%s, ", ret)
+		}
+	}
+	ret = fmt.Sprintf("%s])", ret)
+	return ret
+}
+
+func (e *GovActionsDoNotExist) MarshalJSON() ([]byte, error) {
+	return marshalErrorJson("GovActionsDoNotExist", e)
+}
+
+func (e *GovActionsDoNotExist) MarshalCBOR() ([]byte, error) {
+	return marshalErrorCbor(e)
+}
+
+type MalformedProposal struct {
+	PredicateFailureBase
+	GovAction GovActionWrapper
+}
+
+func (e *MalformedProposal) Error() string {
+	return fmt.Sprintf("MalformedProposal (GovActionType %d)", e.GovAction.Type)
+}
+
+func (e *MalformedProposal) MarshalJSON() ([]byte, error) {
+	return marshalErrorJson("MalformedProposal", e)
+}
+
+func (e *MalformedProposal) MarshalCBOR() ([]byte, error) {
+	return marshalErrorCbor(e)
+}
+
+type ProposalProcedureNetworkIdMismatch struct {
+	PredicateFailureBase
+	RewardAccount cbor.ByteString
+	NetworkId     uint
+}
+
+func (e *ProposalProcedureNetworkIdMismatch) Error() string {
+	return fmt.Sprintf("ProposalProcedureNetworkIdMismatch (RewardAccount %s, NetworkId %d)", e.RewardAccount, e.NetworkId)
+}
+
+func (e *ProposalProcedureNetworkIdMismatch) MarshalJSON() ([]byte, error) {
+	return marshalErrorJson("ProposalProcedureNetworkIdMismatch", e)
+}
+
+func (e *ProposalProcedureNetworkIdMismatch) MarshalCBOR() ([]byte, error) {
+	return marshalErrorCbor(e)
+}
+
+type TreasuryWithdrawalsNetworkIdMismatch struct {
+	PredicateFailureBase
+	RewardAccounts []cbor.ByteString
+	NetworkId      uint
+}
+
+func (e *TreasuryWithdrawalsNetworkIdMismatch) Error() string {
+	return fmt.Sprintf("TreasuryWithdrawalsNetworkIdMismatch (RewardAccounts %v, NetworkId %d)", e.RewardAccounts, e.NetworkId)
+}
+
+func (e *TreasuryWithdrawalsNetworkIdMismatch) MarshalJSON() ([]byte, error) {
+	return marshalErrorJson("TreasuryWithdrawalsNetworkIdMismatch", e)
+}
+
+func (e *TreasuryWithdrawalsNetworkIdMismatch) MarshalCBOR() ([]byte, error) {
+	return marshalErrorCbor(e)
+}
+
+type ProposalDepositIncorrect struct {
+	PredicateFailureBase
+	Supplied uint64
+	Expected uint64
+}
+
+func (e *ProposalDepositIncorrect) Error() string {
+	return fmt.Sprintf("ProposalDepositIncorrect (Supplied %d, Expected %d)", e.Supplied, e.Expected)
+}
+
+func (e *ProposalDepositIncorrect) MarshalJSON() ([]byte, error) {
+	return marshalErrorJson("ProposalDepositIncorrect", e)
+}
+
+func (e *ProposalDepositIncorrect) MarshalCBOR() ([]byte, error) {
+	return marshalErrorCbor(e)
+}
+
+type DisallowedVoters struct {
+	PredicateFailureBase
+	Voters []GovActionVoter
+}
+
+func (e *DisallowedVoters) Error() string {
+	return fmt.Sprintf("DisallowedVoters (%s)", formatGovActionVoters(e.Voters))
+}
+
+func (e *DisallowedVoters) MarshalJSON() ([]byte, error) {
+	return marshalErrorJson("DisallowedVoters", e)
+}
+
+func (e *DisallowedVoters) MarshalCBOR() ([]byte, error) {
+	return marshalErrorCbor(e)
+}
+
+// Helper type for failures that reference a voter along with the governance action being voted on
+type GovActionVoter struct {
+	cbor.StructAsArray
+	Voter       Voter
+	GovActionId GovActionId
+}
+
+func formatGovActionVoters(voters []GovActionVoter) string {
+	ret := "["
+	for idx, voter := range voters {
+		ret = fmt.Sprintf("%s(%s, %s)", ret, voter.Voter.String(), voter.GovActionId.String())
+		if idx < (len(voters) - 1) {
+			ret = fmt.Sprintf("%s, ", ret)
+		}
+	}
+	ret = fmt.Sprintf("%s]", ret)
+	return ret
+}
+
+type ConflictingCommitteeUpdate struct {
+	PredicateFailureBase
+	Credentials []Credential
+}
+
+func (e *ConflictingCommitteeUpdate) Error() string {
+	return fmt.Sprintf("ConflictingCommitteeUpdate (%v)", e.Credentials)
+}
+
+func (e *ConflictingCommitteeUpdate) MarshalJSON() ([]byte, error) {
+	return marshalErrorJson("ConflictingCommitteeUpdate", e)
+}
+
+func (e *ConflictingCommitteeUpdate) MarshalCBOR() ([]byte, error) {
+	return marshalErrorCbor(e)
+}
+
+type ExpirationEpochTooSmall struct {
+	PredicateFailureBase
+	Members map[Credential]uint64
+}
+
+func (e *ExpirationEpochTooSmall) Error() string {
+	return fmt.Sprintf("ExpirationEpochTooSmall (%v)", e.Members)
+}
+
+func (e *ExpirationEpochTooSmall) MarshalJSON() ([]byte, error) {
+	return marshalErrorJson("ExpirationEpochTooSmall", e)
+}
+
+func (e *ExpirationEpochTooSmall) MarshalCBOR() ([]byte, error) {
+	return marshalErrorCbor(e)
+}
+
+type InvalidPrevGovActionId struct {
+	PredicateFailureBase
+	Proposal ProposalProcedure
+}
+
+func (e *InvalidPrevGovActionId) Error() string {
+	return fmt.Sprintf("InvalidPrevGovActionId (GovActionType %d)", e.Proposal.GovAction.Type)
+}
+
+func (e *InvalidPrevGovActionId) MarshalJSON() ([]byte, error) {
+	return marshalErrorJson("InvalidPrevGovActionId", e)
+}
+
+func (e *InvalidPrevGovActionId) MarshalCBOR() ([]byte, error) {
+	return marshalErrorCbor(e)
+}
+
+type VotingOnExpiredGovAction struct {
+	PredicateFailureBase
+	Voters []GovActionVoter
+}
+
+func (e *VotingOnExpiredGovAction) Error() string {
+	return fmt.Sprintf("VotingOnExpiredGovAction (%s)", formatGovActionVoters(e.Voters))
+}
+
+func (e *VotingOnExpiredGovAction) MarshalJSON() ([]byte, error) {
+	return marshalErrorJson("VotingOnExpiredGovAction", e)
+}
+
+func (e *VotingOnExpiredGovAction) MarshalCBOR() ([]byte, error) {
+	return marshalErrorCbor(e)
+}
+
+type ProposalCantFollow struct {
+	PredicateFailureBase
+	// Encoded as an empty list when there is no previous governance action
+	PrevGovActionId []GovActionId
+	Supplied        ProtocolVersion
+	Expected        ProtocolVersion
+}
+
+func (e *ProposalCantFollow) Error() string {
+	return fmt.Sprintf("ProposalCantFollow (PrevGovActionId %v, Supplied %d.%d, Expected %d.%d)", e.PrevGovActionId, e.Supplied.Major, e.Supplied.Minor, e.Expected.Major, e.Expected.Minor)
+}
+
+func (e *ProposalCantFollow) MarshalJSON() ([]byte, error) {
+	return marshalErrorJson("ProposalCantFollow", e)
+}
+
+func (e *ProposalCantFollow) MarshalCBOR() ([]byte, error) {
+	return marshalErrorCbor(e)
+}
+
+type InvalidPolicyHash struct {
+	PredicateFailureBase
+	// These are encoded as an empty list when no policy hash is present
+	Supplied []Blake2b224
+	Expected []Blake2b224
+}
+
+func (e *InvalidPolicyHash) Error() string {
+	return fmt.Sprintf("InvalidPolicyHash (Supplied %v, Expected %v)", e.Supplied, e.Expected)
+}
+
+func (e *InvalidPolicyHash) MarshalJSON() ([]byte, error) {
+	return marshalErrorJson("InvalidPolicyHash", e)
+}
+
+func (e *InvalidPolicyHash) MarshalCBOR() ([]byte, error) {
+	return marshalErrorCbor(e)
+}
+
+type DisallowedProposalDuringBootstrap struct {
+	PredicateFailureBase
+	Proposal ProposalProcedure
+}
+
+func (e *DisallowedProposalDuringBootstrap) Error() string {
+	return fmt.Sprintf("DisallowedProposalDuringBootstrap (GovActionType %d)", e.Proposal.GovAction.Type)
+}
+
+func (e *DisallowedProposalDuringBootstrap) MarshalJSON() ([]byte, error) {
+	return marshalErrorJson("DisallowedProposalDuringBootstrap", e)
+}
+
+func (e *DisallowedProposalDuringBootstrap) MarshalCBOR() ([]byte, error) {
+	return marshalErrorCbor(e)
+}
+
+type DisallowedVotesDuringBootstrap struct {
+	PredicateFailureBase
+	Voters []GovActionVoter
+}
+
+func (e *DisallowedVotesDuringBootstrap) Error() string {
+	return fmt.Sprintf("DisallowedVotesDuringBootstrap (%s)", formatGovActionVoters(e.Voters))
+}
+
+func (e *DisallowedVotesDuringBootstrap) MarshalJSON() ([]byte, error) {
+	return marshalErrorJson("DisallowedVotesDuringBootstrap", e)
+}
+
+func (e *DisallowedVotesDuringBootstrap) MarshalCBOR() ([]byte, error) {
+	return marshalErrorCbor(e)
+}
+
+type VotersDoNotExist struct {
+	PredicateFailureBase
+	Voters []Voter
+}
+
+func (e *VotersDoNotExist) Error() string {
+	return fmt.Sprintf("VotersDoNotExist (%v)", e.Voters)
+}
+
+func (e *VotersDoNotExist) MarshalJSON() ([]byte, error) {
+	return marshalErrorJson("VotersDoNotExist", e)
+}
+
+func (e *VotersDoNotExist) MarshalCBOR() ([]byte, error) {
+	return marshalErrorCbor(e)
+}
+
+type ZeroTreasuryWithdrawals struct {
+	PredicateFailureBase
+	GovAction GovActionWrapper
+}
+
+func (e *ZeroTreasuryWithdrawals) Error() string {
+	return "ZeroTreasuryWithdrawals"
+}
+
+func (e *ZeroTreasuryWithdrawals) MarshalJSON() ([]byte, error) {
+	return marshalErrorJson("ZeroTreasuryWithdrawals", e)
+}
+
+func (e *ZeroTreasuryWithdrawals) MarshalCBOR() ([]byte, error) {
+	return marshalErrorCbor(e)
+}
+
+type ProposalReturnAccountDoesNotExist struct {
+	PredicateFailureBase
+	RewardAccount cbor.ByteString
+}
+
+func (e *ProposalReturnAccountDoesNotExist) Error() string {
+	return fmt.Sprintf("ProposalReturnAccountDoesNotExist (%s)", e.RewardAccount)
+}
+
+func (e *ProposalReturnAccountDoesNotExist) MarshalJSON() ([]byte, error) {
+	return marshalErrorJson("ProposalReturnAccountDoesNotExist", e)
+}
+
+func (e *ProposalReturnAccountDoesNotExist) MarshalCBOR() ([]byte, error) {
+	return marshalErrorCbor(e)
+}
+
+type TreasuryWithdrawalReturnAccountsDoNotExist struct {
+	PredicateFailureBase
+	RewardAccounts []cbor.ByteString
+}
+
+func (e *TreasuryWithdrawalReturnAccountsDoNotExist) Error() string {
+	return fmt.Sprintf("TreasuryWithdrawalReturnAccountsDoNotExist (%v)", e.RewardAccounts)
+}
+
+func (e *TreasuryWithdrawalReturnAccountsDoNotExist) MarshalJSON() ([]byte, error) {
+	return marshalErrorJson("TreasuryWithdrawalReturnAccountsDoNotExist", e)
+}
+
+func (e *TreasuryWithdrawalReturnAccountsDoNotExist) MarshalCBOR() ([]byte, error) {
+	return marshalErrorCbor(e)
+}
+
+type ConwayWdrlNotDelegatedToDRep struct {
+	PredicateFailureBase
+	KeyHashes []Blake2b224
+}
+
+func (e *ConwayWdrlNotDelegatedToDRep) Error() string {
+	return fmt.Sprintf("ConwayWdrlNotDelegatedToDRep (%v)", e.KeyHashes)
+}
+
+func (e *ConwayWdrlNotDelegatedToDRep) MarshalJSON() ([]byte, error) {
+	return marshalErrorJson("ConwayWdrlNotDelegatedToDRep", e)
+}
+
+func (e *ConwayWdrlNotDelegatedToDRep) MarshalCBOR() ([]byte, error) {
+	return marshalErrorCbor(e)
+}
+
+type ConwayTreasuryValueMismatch struct {
+	PredicateFailureBase
+	Actual    uint64
+	Submitted uint64
+}
+
+func (e *ConwayTreasuryValueMismatch) Error() string {
+	return fmt.Sprintf("ConwayTreasuryValueMismatch (Actual %d, Submitted %d)", e.Actual, e.Submitted)
+}
+
+func (e *ConwayTreasuryValueMismatch) MarshalJSON() ([]byte, error) {
+	return marshalErrorJson("ConwayTreasuryValueMismatch", e)
+}
+
+func (e *ConwayTreasuryValueMismatch) MarshalCBOR() ([]byte, error) {
+	return marshalErrorCbor(e)
+}
+
+type ConwayTxRefScriptsSizeTooBig struct {
+	PredicateFailureBase
+	ActualSize int
+	MaxSize    int
+}
+
+func (e *ConwayTxRefScriptsSizeTooBig) Error() string {
+	return fmt.Sprintf("ConwayTxRefScriptsSizeTooBig (ActualSize %d, MaxSize %d)", e.ActualSize, e.MaxSize)
+}
+
+func (e *ConwayTxRefScriptsSizeTooBig) MarshalJSON() ([]byte, error) {
+	return marshalErrorJson("ConwayTxRefScriptsSizeTooBig", e)
+}
+
+func (e *ConwayTxRefScriptsSizeTooBig) MarshalCBOR() ([]byte, error) {
+	return marshalErrorCbor(e)
+}
+
+type ConwayMempoolFailure struct {
+	PredicateFailureBase
+	Reason string
+}
+
+func (e *ConwayMempoolFailure) Error() string {
+	return fmt.Sprintf("ConwayMempoolFailure (%s)", e.Reason)
+}
+
+func (e *ConwayMempoolFailure) MarshalJSON() ([]byte, error) {
+	return marshalErrorJson("ConwayMempoolFailure", e)
+}
+
+func (e *ConwayMempoolFailure) MarshalCBOR() ([]byte, error) {
+	return marshalErrorCbor(e)
+}
@@ -0,0 +1,212 @@
+package ledger
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/cloudstruct/go-cardano-ledger/cbor"
+)
+
+// MarshalTxSubmitErrorJson returns the JSON response that cardano-submit-api returns when the node
+// rejects a transaction, for an error returned by NewTxSubmitErrorFromCbor. The error is nested in
+// TxSubmitFail, TxCmdTxSubmitValidationError and either TxValidationErrorInCardanoMode or
+// TxValidationEraMismatch, using "tag" and "contents" fields
+func MarshalTxSubmitErrorJson(err error) ([]byte, error) {
+	var validationErr map[string]interface{}
+	switch e := err.(type) {
+	case *ShelleyTxValidationError:
+		validationErr = map[string]interface{}{
+			"tag":      "TxValidationErrorInCardanoMode",
+			"contents": e,
+		}
+	case *EraMismatch:
+		validationErr = map[string]interface{}{
+			"tag":      "TxValidationEraMismatch",
+			"contents": e,
+		}
+	default:
+		return nil, fmt.Errorf("unsupported transaction submission error type: %T", err)
+	}
+	return json.Marshal(
+		map[string]interface{}{
+			"tag": "TxSubmitFail",
+			"contents": map[string]interface{}{
+				"tag":      "TxCmdTxSubmitValidationError",
+				"contents": validationErr,
+			},
+		},
+	)
+}
+
+// Helper function for generating the JSON representation of a ledger predicate failure. This
+// follows the aeson encoding of the ledger's Haskell types used by cardano-submit-api: the
+// constructor name is in the "tag" field, and the exported fields of the error are the constructor
+// arguments in the "contents" field, as a single value or as a list when there are several. Byte
+// strings and hashes are rendered as hex and nested failures as their own JSON representation.
+// The era used for decoding nested failures is omitted
+func marshalErrorJson(tag string, e interface{}) ([]byte, error) {
+	rv := reflect.ValueOf(e)
+	for rv.Kind() == reflect.Ptr {
+		rv = rv.Elem()
+	}
+	var contents []interface{}
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		// Embedded fields only contain the failure type ID used for CBOR
+		if field.Anonymous || !field.IsExported() || field.Name == "Era" {
+			continue
+		}
+		tmpValue, err := jsonValue(rv.Field(i))
+		if err != nil {
+			return nil, err
+		}
+		contents = append(contents, tmpValue)
+	}
+	ret := map[string]interface{}{
+		"tag": tag,
+	}
+	switch len(contents) {
+	case 0:
+	case 1:
+		ret["contents"] = contents[0]
+	default:
+		ret["contents"] = contents
+	}
+	return json.Marshal(ret)
+}
+
+// Helper function to add the exported fields of a struct to the map using lower camel case names,
+// which matches the aeson encoding of Haskell records
+func addStructFieldsJson(dest map[string]interface{}, rv reflect.Value) error {
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		if field.Anonymous || !field.IsExported() {
+			continue
+		}
+		tmpValue, err := jsonValue(rv.Field(i))
+		if err != nil {
+			return err
+		}
+		dest[jsonFieldName(field.Name)] = tmpValue
+	}
+	return nil
+}
+
+func jsonFieldName(name string) string {
+	r, size := utf8.DecodeRuneInString(name)
+	return string(unicode.ToLower(r)) + name[size:]
+}
+
+// Helper function to convert an arbitrary value into something that can be passed to json.Marshal
+func jsonValue(rv reflect.Value) (interface{}, error) {
+	if !rv.IsValid() {
+		return nil, nil
+	}
+	// Use the JSON representation for types that provide one, such as nested errors
+	if rv.CanAddr() && rv.Kind() != reflect.Ptr && rv.Kind() != reflect.Interface {
+		if marshaler, ok := rv.Addr().Interface().(json.Marshaler); ok {
+			return marshalerJsonValue(marshaler)
+		}
+	}
+	if rv.CanInterface() {
+		switch v := rv.Interface().(type) {
+		case json.Marshaler:
+			if rv.Kind() == reflect.Ptr && rv.IsNil() {
+				return nil, nil
+			}
+			return marshalerJsonValue(v)
+		case cbor.ByteString:
+			return v.String(), nil
+		case cbor.Value:
+			return jsonValue(reflect.ValueOf(v.Value))
+		}
+	}
+	switch rv.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if rv.IsNil() {
+			return nil, nil
+		}
+		return jsonValue(rv.Elem())
+	case reflect.Slice, reflect.Array:
+		if rv.Type().Elem().Kind() == reflect.Uint8 {
+			tmpBytes := make([]byte, rv.Len())
+			reflect.Copy(reflect.ValueOf(tmpBytes), rv)
+			return hex.EncodeToString(tmpBytes), nil
+		}
+		ret := []interface{}{}
+		for i := 0; i < rv.Len(); i++ {
+			tmpValue, err := jsonValue(rv.Index(i))
+			if err != nil {
+				return nil, err
+			}
+			ret = append(ret, tmpValue)
+		}
+		return ret, nil
+	case reflect.Map:
+		ret := map[string]interface{}{}
+		iter := rv.MapRange()
+		for iter.Next() {
+			tmpValue, err := jsonValue(iter.Value())
+			if err != nil {
+				return nil, err
+			}
+			ret[jsonMapKey(iter.Key())] = tmpValue
+		}
+		return ret, nil
+	case reflect.Struct:
+		// Anonymous structs are used for tuples, which are encoded as a list
+		if rv.Type().Name() == "" {
+			ret := []interface{}{}
+			for i := 0; i < rv.NumField(); i++ {
+				if rv.Type().Field(i).Anonymous || !rv.Type().Field(i).IsExported() {
+					continue
+				}
+				tmpValue, err := jsonValue(rv.Field(i))
+				if err != nil {
+					return nil, err
+				}
+				ret = append(ret, tmpValue)
+			}
+			return ret, nil
+		}
+		ret := map[string]interface{}{}
+		if err := addStructFieldsJson(ret, rv); err != nil {
+			return nil, err
+		}
+		return ret, nil
+	default:
+		if rv.CanInterface() {
+			return rv.Interface(), nil
+		}
+		return nil, nil
+	}
+}
+
+func marshalerJsonValue(marshaler json.Marshaler) (interface{}, error) {
+	tmpJson, err := marshaler.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	return json.RawMessage(tmpJson), nil
+}
+
+func jsonMapKey(rv reflect.Value) string {
+	for rv.Kind() == reflect.Interface && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if rv.CanInterface() {
+		if stringer, ok := rv.Interface().(fmt.Stringer); ok {
+			return stringer.String()
+		}
+	}
+	if rv.Kind() == reflect.Slice && rv.Type().Elem().Kind() == reflect.Uint8 {
+		return hex.EncodeToString(rv.Bytes())
+	}
+	return fmt.Sprintf("%v", rv.Interface())
+}
@@ -0,0 +1,523 @@
+package ledger_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/cloudstruct/go-cardano-ledger"
+	"github.com/cloudstruct/go-cardano-ledger/cbor"
+)
+
+type txSubmitErrorTestDefinition struct {
+	Name    string
+	CborHex string
+	Error   string
+}
+
+var txSubmitErrorTests = []txSubmitErrorTestDefinition{
+	{
+		Name:    "BabbageFeeTooSmall",
+		CborHex: "8182058182008202820183041a00030d401a000186a0",
+		Error:   "ShelleyTxValidationError ShelleyBasedEraBabbage (ApplyTxError ([UtxowFailure (UtxoFailure (FromAlonzoUtxoFail (FeeTooSmallUtxo (MinimumFee 200000, SuppliedFee 100000))))]))",
+	},
+	{
+		Name:    "ShelleyExpired",
+		CborHex: "81820181820082048301186418c8",
+		Error:   "ShelleyTxValidationError ShelleyBasedEraShelley (ApplyTxError ([UtxowFailure (UtxoFailure (ExpiredUtxo (Ttl 100, Slot 200)))]))",
+	},
+	// Shelley, Allegra and Mary use tags 6-9 for OutputTooSmallUTxO, UpdateFailure, WrongNetwork
+	// and WrongNetworkWithdrawal, which differs from the order used by Alonzo and later
+	{
+		Name:    "ShelleyOutputTooSmall",
+		CborHex: "818201818200820482068182581d61222222222222222222222222222222222222222222222222222222221903e8",
+		Error:   "ShelleyTxValidationError ShelleyBasedEraShelley (ApplyTxError ([UtxowFailure (UtxoFailure (OutputTooSmallUtxo ([TxOut (addr1vy3zyg3zyg3zyg3zyg3zyg3zyg3zyg3zyg3zyg3zyg3zygs44r503) (Coin 1000)])))]))",
+	},
+	{
+		Name:    "ShelleyUpdateFailure",
+		CborHex: "818201818200820482078401186418c801",
+		Error:   "ShelleyTxValidationError ShelleyBasedEraShelley (ApplyTxError ([UtxowFailure (UtxoFailure (UpdateFailure (PPUpdateWrongEpoch (CurrentEpoch 100, TargetEpoch 200, VotingPeriod 1))))]))",
+	},
+	{
+		Name:    "ShelleyWrongNetwork",
+		CborHex: "818201818200820483080081581d6122222222222222222222222222222222222222222222222222222222",
+		Error:   "ShelleyTxValidationError ShelleyBasedEraShelley (ApplyTxError ([UtxowFailure (UtxoFailure (WrongNetwork (ExpectedNetworkId 0, Addresses (fromList [addr1vy3zyg3zyg3zyg3zyg3zyg3zyg3zyg3zyg3zyg3zyg3zygs44r503]))))]))",
+	},
+	{
+		Name:    "ShelleyWrongNetworkWithdrawal",
+		CborHex: "818201818200820483090081581de133333333333333333333333333333333333333333333333333333333",
+		Error:   "ShelleyTxValidationError ShelleyBasedEraShelley (ApplyTxError ([UtxowFailure (UtxoFailure (WrongNetworkWithdrawal (ExpectedNetworkId 0, RewardAccounts (fromList [stake1uyenxvenxvenxvenxvenxvenxvenxvenxvenxvenxvenxvczes76j]))))]))",
+	},
+	{
+		Name:    "AllegraOutputTooSmall",
+		CborHex: "818202818200820482068182581d61222222222222222222222222222222222222222222222222222222221903e8",
+		Error:   "ShelleyTxValidationError ShelleyBasedEraAllegra (ApplyTxError ([UtxowFailure (UtxoFailure (OutputTooSmallUtxo ([TxOut (addr1vy3zyg3zyg3zyg3zyg3zyg3zyg3zyg3zyg3zyg3zyg3zygs44r503) (Coin 1000)])))]))",
+	},
+	{
+		Name:    "MaryWrongNetwork",
+		CborHex: "818203818200820483080081581d6122222222222222222222222222222222222222222222222222222222",
+		Error:   "ShelleyTxValidationError ShelleyBasedEraMary (ApplyTxError ([UtxowFailure (UtxoFailure (WrongNetwork (ExpectedNetworkId 0, Addresses (fromList [addr1vy3zyg3zyg3zyg3zyg3zyg3zyg3zyg3zyg3zyg3zyg3zygs44r503]))))]))",
+	},
+	{
+		Name:    "MaryStakeKeyNotRegistered",
+		CborHex: "8182038182018202820182018200581c000102030405060708090a0b0c0d0e0f101112131415161718191a1b",
+		Error:   "ShelleyTxValidationError ShelleyBasedEraMary (ApplyTxError ([DelegsFailure (DelplFailure (DelegFailure (StakeKeyNotRegisteredDELEG (KeyHashObj (KeyHash 000102030405060708090a0b0c0d0e0f101112131415161718191a1b)))))]))",
+	},
+	{
+		Name:    "AlonzoMissingVKeyWitnesses",
+		CborHex: "8182048182008200820181581c000102030405060708090a0b0c0d0e0f101112131415161718191a1b",
+		Error:   "ShelleyTxValidationError ShelleyBasedEraAlonzo (ApplyTxError ([UtxowFailure (ShelleyInAlonzoUtxowPredFailure (MissingVKeyWitnessesUTXOW ([000102030405060708090a0b0c0d0e0f101112131415161718191a1b])))]))",
+	},
+	{
+		Name:    "AlonzoStakePoolCostTooLow",
+		CborHex: "81820481820182028200830318641a1443fd00",
+		Error:   "ShelleyTxValidationError ShelleyBasedEraAlonzo (ApplyTxError ([DelegsFailure (DelplFailure (PoolFailure (StakePoolCostTooLowPOOL (SuppliedCost 100, MinimumCost 340000000))))]))",
+	},
+	{
+		Name:    "BabbagePlutusFailure",
+		CborHex: "8182058182008202820182078300f48201818301784a54686520322061726720706c7574757320736372697074206661696c732e0a53637269707420646562756767696e67206c6f67733a207472616365206f6e650a74726163652074776f0a41ab",
+		Error:   "ShelleyTxValidationError ShelleyBasedEraBabbage (ApplyTxError ([UtxowFailure (UtxoFailure (FromAlonzoUtxoFail (UtxosFailure (ValidationTagMismatch (IsValid false, FailedUnexpectedly ([PlutusFailure (\"The 2 arg plutus script fails.\\nScript debugging logs: trace one\\ntrace two\\n\")]))))))]))",
+	},
+	{
+		Name:    "ConwayFeeTooSmall",
+		CborHex: "818206818201820083051a00030d401a000186a0",
+		Error:   "ShelleyTxValidationError ShelleyBasedEraConway (ApplyTxError ([ConwayUtxowFailure (UtxoFailure (FeeTooSmallUtxo (MinimumFee 200000, SuppliedFee 100000)))]))",
+	},
+	{
+		Name:    "ConwayStakeKeyNotRegistered",
+		CborHex: "8182068182028201820182038200581c000102030405060708090a0b0c0d0e0f101112131415161718191a1b",
+		Error:   "ShelleyTxValidationError ShelleyBasedEraConway (ApplyTxError ([ConwayCertsFailure (CertFailure (DelegFailure (StakeKeyNotRegisteredDELEG (KeyHashObj (KeyHash 000102030405060708090a0b0c0d0e0f101112131415161718191a1b)))))]))",
+	},
+	{
+		Name:    "ConwayProposalDepositAndMissingWitness",
+		CborHex: "81820682820383041b000000174876e8001b0000000ba43b74008201820281581c000102030405060708090a0b0c0d0e0f101112131415161718191a1b",
+		Error:   "ShelleyTxValidationError ShelleyBasedEraConway (ApplyTxError ([ConwayGovFailure (ProposalDepositIncorrect (Supplied 100000000000, Expected 50000000000)), ConwayUtxowFailure (MissingVKeyWitnessesUTXOW ([000102030405060708090a0b0c0d0e0f101112131415161718191a1b]))]))",
+	},
+	{
+		Name:    "BabbageValueNotConservedMultiAsset",
+		CborHex: "818205818200820282018305821a002dc6c0a1581c11111111111111111111111111111111111111111111111111111111a145544f4b454e0a821a002ab980a1581c11111111111111111111111111111111111111111111111111111111a145544f4b454e05",
+		Error:   "ShelleyTxValidationError ShelleyBasedEraBabbage (ApplyTxError ([UtxowFailure (UtxoFailure (FromAlonzoUtxoFail (ValueNotConservedUtxo (Consumed (Coin 3000000, MultiAsset [11111111111111111111111111111111111111111111111111111111.544f4b454e: 10]), Produced (Coin 2800000, MultiAsset [11111111111111111111111111111111111111111111111111111111.544f4b454e: 5])))))]))",
+	},
+	{
+		Name:    "AlonzoScriptsNotPaid",
+		CborHex: "81820481820082008204820ea182582033333333333333333333333333333333333333333333333333333333333333330183581d61222222222222222222222222222222222222222222222222222222221a004c4b4058204444444444444444444444444444444444444444444444444444444444444444",
+		Error:   "ShelleyTxValidationError ShelleyBasedEraAlonzo (ApplyTxError ([UtxowFailure (ShelleyInAlonzoUtxowPredFailure (UtxoFailure (ScriptsNotPaidUtxo (UTxO (fromList [(TxIn (Utxo 3333333333333333333333333333333333333333333333333333333333333333, TxIx 1), TxOut (addr1vy3zyg3zyg3zyg3zyg3zyg3zyg3zyg3zyg3zyg3zyg3zygs44r503) (Coin 5000000) (DatumHash 4444444444444444444444444444444444444444444444444444444444444444))])))))]))",
+	},
+	{
+		Name:    "ConwayWrongNetwork",
+		CborHex: "8182068182018200830701d9010281581d6122222222222222222222222222222222222222222222222222222222",
+		Error:   "ShelleyTxValidationError ShelleyBasedEraConway (ApplyTxError ([ConwayUtxowFailure (UtxoFailure (WrongNetwork (ExpectedNetworkId 1, Addresses (fromList [addr1vy3zyg3zyg3zyg3zyg3zyg3zyg3zyg3zyg3zyg3zyg3zygs44r503]))))]))",
+	},
+}
+
+func TestTxSubmitErrorDecode(t *testing.T) {
+	for _, test := range txSubmitErrorTests {
+		cborData, err := hex.DecodeString(test.CborHex)
+		if err != nil {
+			t.Fatalf("%s: failed to decode CBOR hex: %s", test.Name, err)
+		}
+		txErr, err := ledger.NewTxSubmitErrorFromCbor(cborData)
+		if err != nil {
+			t.Fatalf("%s: failed to decode error: %s", test.Name, err)
+		}
+		if _, ok := txErr.(*ledger.ShelleyTxValidationError); !ok {
+			t.Fatalf("%s: did not get expected error type, got %T", test.Name, txErr)
+		}
+		if txErr.Error() != test.Error {
+			t.Fatalf("%s: did not get expected error string\n  got:    %s\n  wanted: %s", test.Name, txErr.Error(), test.Error)
+		}
+	}
+}
+
+func TestPlutusFailureLogs(t *testing.T) {
+	cborData, _ := hex.DecodeString("8182058182008202820182078300f48201818301784a54686520322061726720706c7574757320736372697074206661696c732e0a53637269707420646562756767696e67206c6f67733a207472616365206f6e650a74726163652074776f0a41ab")
+	txErr, err := ledger.NewTxSubmitErrorFromCbor(cborData)
+	if err != nil {
+		t.Fatalf("failed to decode error: %s", err)
+	}
+	utxowErr := txErr.(*ledger.ShelleyTxValidationError).Err.Failures[0].(*ledger.UtxowFailure)
+	utxosErr := utxowErr.Err.(*ledger.UtxoFailure).Err.(*ledger.FromAlonzoUtxoFail).Err.(*ledger.UtxosFailure)
+	tagErr, ok := utxosErr.Err.(*ledger.ValidationTagMismatch)
+	if !ok {
+		t.Fatalf("did not get expected error type, got %T", utxosErr.Err)
+	}
+	failure := tagErr.Description.Failures[0]
+	if !bytes.Equal(failure.PlutusDebug, []byte{0xab}) {
+		t.Fatalf("did not get expected Plutus debug data, got %x", failure.PlutusDebug)
+	}
+	logs := failure.Logs()
+	if len(logs) != 2 || logs[0] != "trace one" || logs[1] != "trace two" {
+		t.Fatalf("did not get expected script logs, got %v", logs)
+	}
+}
+
+func TestTxSubmitErrorJson(t *testing.T) {
+	testDefs := []struct {
+		CborHex string
+		Json    string
+	}{
+		{
+			CborHex: "8182058182008202820183041a00030d401a000186a0",
+			Json:    `{"era":"ShelleyBasedEraBabbage","error":[{"contents":{"contents":{"contents":{"contents":[200000,100000],"tag":"FeeTooSmallUTxO"},"tag":"AlonzoInBabbageUtxoPredFailure"},"tag":"UtxoFailure"},"tag":"UtxowFailure"}],"kind":"ShelleyTxValidationError"}`,
+		},
+		{
+			CborHex: "8182068182028201820182038200581c000102030405060708090a0b0c0d0e0f101112131415161718191a1b",
+			Json:    `{"era":"ShelleyBasedEraConway","error":[{"contents":{"contents":{"contents":{"contents":{"keyHash":"000102030405060708090a0b0c0d0e0f101112131415161718191a1b"},"tag":"StakeKeyNotRegisteredDELEG"},"tag":"DelegFailure"},"tag":"CertFailure"},"tag":"ConwayCertsFailure"}],"kind":"ShelleyTxValidationError"}`,
+		},
+		{
+			CborHex: "820506",
+			Json:    `{"ledgerEraName":"Babbage","otherEraName":"Conway"}`,
+		},
+	}
+	for _, test := range testDefs {
+		cborData, _ := hex.DecodeString(test.CborHex)
+		txErr, err := ledger.NewTxSubmitErrorFromCbor(cborData)
+		if err != nil {
+			t.Fatalf("failed to decode error: %s", err)
+		}
+		jsonData, err := json.Marshal(txErr)
+		if err != nil {
+			t.Fatalf("failed to marshal error to JSON: %s", err)
+		}
+		if string(jsonData) != test.Json {
+			t.Fatalf("did not get expected JSON\n  got:    %s\n  wanted: %s", jsonData, test.Json)
+		}
+	}
+}
+
+// Response body in the layout returned by cardano-submit-api when the node rejects a Babbage
+// transaction with a fee that is too small
+const testTxSubmitApiFeeTooSmallJson = `{
+  "tag": "TxSubmitFail",
+  "contents": {
+    "tag": "TxCmdTxSubmitValidationError",
+    "contents": {
+      "tag": "TxValidationErrorInCardanoMode",
+      "contents": {
+        "kind": "ShelleyTxValidationError",
+        "error": [
+          {
+            "tag": "UtxowFailure",
+            "contents": {
+              "tag": "UtxoFailure",
+              "contents": {
+                "tag": "AlonzoInBabbageUtxoPredFailure",
+                "contents": {
+                  "tag": "FeeTooSmallUTxO",
+                  "contents": [
+                    200000,
+                    100000
+                  ]
+                }
+              }
+            }
+          }
+        ],
+        "era": "ShelleyBasedEraBabbage"
+      }
+    }
+  }
+}`
+
+// Response body in the layout returned by cardano-submit-api when the transaction is for a
+// different era than the node
+const testTxSubmitApiEraMismatchJson = `{
+  "tag": "TxSubmitFail",
+  "contents": {
+    "tag": "TxCmdTxSubmitValidationError",
+    "contents": {
+      "tag": "TxValidationEraMismatch",
+      "contents": {
+        "ledgerEraName": "Babbage",
+        "otherEraName": "Conway"
+      }
+    }
+  }
+}`
+
+func TestMarshalTxSubmitErrorJson(t *testing.T) {
+	testDefs := []struct {
+		CborHex string
+		Json    string
+	}{
+		{
+			CborHex: "8182058182008202820183041a00030d401a000186a0",
+			Json:    testTxSubmitApiFeeTooSmallJson,
+		},
+		{
+			CborHex: "820506",
+			Json:    testTxSubmitApiEraMismatchJson,
+		},
+	}
+	for _, test := range testDefs {
+		cborData, _ := hex.DecodeString(test.CborHex)
+		txErr, err := ledger.NewTxSubmitErrorFromCbor(cborData)
+		if err != nil {
+			t.Fatalf("failed to decode error: %s", err)
+		}
+		jsonData, err := ledger.MarshalTxSubmitErrorJson(txErr)
+		if err != nil {
+			t.Fatalf("failed to marshal error to JSON: %s", err)
+		}
+		var expected bytes.Buffer
+		if err := json.Compact(&expected, []byte(test.Json)); err != nil {
+			t.Fatalf("failed to compact expected JSON: %s", err)
+		}
+		// Compare as decoded values, since the key order differs from the submit-api output
+		var got, wanted interface{}
+		if err := json.Unmarshal(jsonData, &got); err != nil {
+			t.Fatalf("failed to decode JSON: %s", err)
+		}
+		if err := json.Unmarshal(expected.Bytes(), &wanted); err != nil {
+			t.Fatalf("failed to decode expected JSON: %s", err)
+		}
+		if !reflect.DeepEqual(got, wanted) {
+			t.Fatalf("did not get expected JSON\n  got:    %s\n  wanted: %s", jsonData, expected.String())
+		}
+	}
+	if _, err := ledger.MarshalTxSubmitErrorJson(fmt.Errorf("some error")); err == nil {
+		t.Fatalf("did not get expected error for unsupported error type")
+	}
+}
+
+func TestTxSubmitErrorUnwrap(t *testing.T) {
+	for _, cborHex := range []string{
+		// Babbage
+		"8182058182008202820183041a00030d401a000186a0",
+		// Conway
+		"818206818201820083051a00030d401a000186a0",
+	} {
+		cborData, _ := hex.DecodeString(cborHex)
+		txErr, err := ledger.NewTxSubmitErrorFromCbor(cborData)
+		if err != nil {
+			t.Fatalf("failed to decode error: %s", err)
+		}
+		var feeTooSmall *ledger.FeeTooSmallUtxo
+		if !errors.As(txErr, &feeTooSmall) {
+			t.Fatalf("did not find FeeTooSmallUtxo in error chain: %s", txErr)
+		}
+		if feeTooSmall.MinimumFee != 200000 || feeTooSmall.SuppliedFee != 100000 {
+			t.Fatalf("did not get expected fee values, got: %s", feeTooSmall)
+		}
+		var badInputs *ledger.BadInputsUtxo
+		if errors.As(txErr, &badInputs) {
+			t.Fatalf("unexpectedly found BadInputsUtxo in error chain: %s", txErr)
+		}
+		// errors.Is only matches the failure itself
+		if !errors.Is(txErr, feeTooSmall) {
+			t.Fatalf("errors.Is did not match FeeTooSmallUtxo")
+		}
+		if errors.Is(txErr, &ledger.FeeTooSmallUtxo{}) {
+			t.Fatalf("errors.Is unexpectedly matched a different FeeTooSmallUtxo")
+		}
+		// The errors package only uses Unwrap() []error in Go 1.20 and later, so matching
+		// individual failures relies on the Is and As methods
+		applyTxErr := &txErr.(*ledger.ShelleyTxValidationError).Err
+		if !applyTxErr.Is(feeTooSmall) {
+			t.Fatalf("ApplyTxError.Is did not match FeeTooSmallUtxo")
+		}
+		feeTooSmall = nil
+		if !applyTxErr.As(&feeTooSmall) || feeTooSmall.MinimumFee != 200000 {
+			t.Fatalf("ApplyTxError.As did not find FeeTooSmallUtxo")
+		}
+		if applyTxErr.As(&badInputs) {
+			t.Fatalf("ApplyTxError.As unexpectedly found BadInputsUtxo")
+		}
+	}
+}
+
+func TestTxSubmitErrorCborRoundTrip(t *testing.T) {
+	for _, test := range txSubmitErrorTests {
+		cborData, _ := hex.DecodeString(test.CborHex)
+		txErr, err := ledger.NewTxSubmitErrorFromCbor(cborData)
+		if err != nil {
+			t.Fatalf("%s: failed to decode error: %s", test.Name, err)
+		}
+		encoded, err := cbor.Encode(txErr)
+		if err != nil {
+			t.Fatalf("%s: failed to encode error: %s", test.Name, err)
+		}
+		if !bytes.Equal(encoded, cborData) {
+			t.Fatalf("%s: CBOR did not round-trip\n  got:    %x\n  wanted: %x", test.Name, encoded, cborData)
+		}
+	}
+}
+
+func TestTxSubmitErrorCborEncodeModified(t *testing.T) {
+	cborData, _ := hex.DecodeString("8182058182008202820183041a00030d401a000186a0")
+	txErr, err := ledger.NewTxSubmitErrorFromCbor(cborData)
+	if err != nil {
+		t.Fatalf("failed to decode error: %s", err)
+	}
+	applyTxErr := &txErr.(*ledger.ShelleyTxValidationError).Err
+	var feeTooSmall *ledger.FeeTooSmallUtxo
+	if !errors.As(applyTxErr, &feeTooSmall) {
+		t.Fatalf("did not find FeeTooSmallUtxo in error chain: %s", txErr)
+	}
+	origFailureCbor, err := cbor.Encode(feeTooSmall)
+	if err != nil {
+		t.Fatalf("failed to encode failure: %s", err)
+	}
+	if hex.EncodeToString(origFailureCbor) != "83041a00030d401a000186a0" {
+		t.Fatalf("did not get expected CBOR for failure, got: %x", origFailureCbor)
+	}
+	// Nested failures are encoded from their fields, so changes are reflected in the CBOR
+	feeTooSmall.MinimumFee = 300000
+	failureCbor, err := cbor.Encode(feeTooSmall)
+	if err != nil {
+		t.Fatalf("failed to encode failure: %s", err)
+	}
+	if hex.EncodeToString(failureCbor) != "83041a000493e01a000186a0" {
+		t.Fatalf("did not get expected CBOR for modified failure, got: %x", failureCbor)
+	}
+	applyTxErrCbor, err := cbor.Encode(applyTxErr)
+	if err != nil {
+		t.Fatalf("failed to encode error: %s", err)
+	}
+	if hex.EncodeToString(applyTxErrCbor) != "8182008202820183041a000493e01a000186a0" {
+		t.Fatalf("did not get expected CBOR for modified error, got: %x", applyTxErrCbor)
+	}
+}
+
+func TestTxSubmitErrorCborEncode(t *testing.T) {
+	missingKeyHash := ledger.Blake2b224{}
+	for i := range missingKeyHash {
+		missingKeyHash[i] = byte(i)
+	}
+	testDefs := []struct {
+		Name    string
+		Err     error
+		CborHex string
+	}{
+		{
+			Name: "BabbageFeeTooSmall",
+			Err: &ledger.ShelleyTxValidationError{
+				Era: ledger.ERA_ID_BABBAGE,
+				Err: ledger.ApplyTxError{
+					Failures: []error{
+						&ledger.UtxowFailure{
+							PredicateFailureBase: ledger.PredicateFailureBase{Type: ledger.APPLY_TX_ERROR_UTXOW_FAILURE},
+							Err: &ledger.UtxoFailure{
+								PredicateFailureBase: ledger.PredicateFailureBase{Type: ledger.UTXOW_FAILURE_UTXO_FAILURE},
+								Err: &ledger.FromAlonzoUtxoFail{
+									PredicateFailureBase: ledger.PredicateFailureBase{Type: ledger.UTXO_FAILURE_FROM_ALONZO},
+									Err: &ledger.FeeTooSmallUtxo{
+										UtxoFailureErrorBase: ledger.UtxoFailureErrorBase{Type: ledger.UTXO_FAILURE_FEE_TOO_SMALL_UTXO},
+										MinimumFee:           200000,
+										SuppliedFee:          100000,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			CborHex: "8182058182008202820183041a00030d401a000186a0",
+		},
+		{
+			Name: "ConwayMissingVKeyWitnesses",
+			Err: &ledger.ShelleyTxValidationError{
+				Era: ledger.ERA_ID_CONWAY,
+				Err: ledger.ApplyTxError{
+					Failures: []error{
+						&ledger.ConwayUtxowFailure{
+							PredicateFailureBase: ledger.PredicateFailureBase{Type: ledger.CONWAY_LEDGER_FAILURE_UTXOW},
+							Err: &ledger.MissingVKeyWitnessesUtxow{
+								PredicateFailureBase: ledger.PredicateFailureBase{Type: ledger.CONWAY_UTXOW_FAILURE_MISSING_VKEY_WITNESSES_UTXOW},
+								KeyHashes:            []ledger.Blake2b224{missingKeyHash},
+							},
+						},
+					},
+				},
+			},
+			CborHex: "818206818201820281581c000102030405060708090a0b0c0d0e0f101112131415161718191a1b",
+		},
+	}
+	for _, test := range testDefs {
+		encoded, err := cbor.Encode(test.Err)
+		if err != nil {
+			t.Fatalf("%s: failed to encode error: %s", test.Name, err)
+		}
+		if hex.EncodeToString(encoded) != test.CborHex {
+			t.Fatalf("%s: did not get expected CBOR\n  got:    %x\n  wanted: %s", test.Name, encoded, test.CborHex)
+		}
+	}
+}
+
+func TestExplainError(t *testing.T) {
+	testDefs := []struct {
+		CborHex     string
+		Explanation string
+	}{
+		{
+			// Babbage FeeTooSmallUtxo
+			CborHex:     "8182058182008202820183041a00030d401a000186a0",
+			Explanation: "The transaction fee is too low. The minimum fee is 200000 lovelace (0.200000 ADA), but only 100000 lovelace (0.100000 ADA) was supplied. Increase the fee by at least 100000 lovelace (0.100000 ADA) and sign the transaction again.",
+		},
+		{
+			// Shelley ExpiredUtxo
+			CborHex:     "81820181820082048301186418c8",
+			Explanation: "The transaction has expired. Its TTL was slot 100, but the current slot is 200. Build the transaction again with a later TTL and sign it again.",
+		},
+		{
+			// Babbage OutsideValidityIntervalUtxo, before the start of the validity interval
+			CborHex:     "81820581820082028201830182811864f61832",
+			Explanation: "The transaction was submitted too early. It is not valid until slot 100, but the current slot is 50. Wait 50 slots before submitting it again.",
+		},
+		{
+			// Babbage InsufficientCollateral
+			CborHex:     "81820581820082028201830d1a001e84801a002dc6c0",
+			Explanation: "The collateral for this smart contract transaction is too low. At least 3000000 lovelace (3.000000 ADA) is required, but the collateral provides 2000000 lovelace. Add collateral inputs worth at least 1000000 lovelace (1.000000 ADA) more.",
+		},
+	}
+	for _, testDef := range testDefs {
+		cborData, _ := hex.DecodeString(testDef.CborHex)
+		txErr, err := ledger.NewTxSubmitErrorFromCbor(cborData)
+		if err != nil {
+			t.Fatalf("failed to decode error: %s", err)
+		}
+		if explanation := ledger.ExplainError(txErr); explanation != testDef.Explanation {
+			t.Fatalf("did not get expected explanation\n  got:      %s\n  expected: %s", explanation, testDef.Explanation)
+		}
+	}
+}
+
+func TestTxSubmitErrorUnknownEra(t *testing.T) {
+	testDefs := []struct {
+		CborHex string
+		Error   string
+		Json    string
+	}{
+		{
+			CborHex: "820509",
+			Error:   "The era of the node and the tx do not match. The node is running in the Babbage era, but the transaction is for the 9 era.",
+			Json:    `{"ledgerEraName":"Babbage","otherEraName":"9"}`,
+		},
+		{
+			CborHex: "8182098182008202820183041a00030d401a000186a0",
+			Error:   "ShelleyTxValidationError ShelleyBasedEra9 (ApplyTxError ([UtxowFailure (UtxoFailure (FromAlonzoUtxoFail (FeeTooSmallUtxo (MinimumFee 200000, SuppliedFee 100000))))]))",
+			Json:    `{"era":"ShelleyBasedEra9","error":[{"contents":{"contents":{"contents":{"contents":[200000,100000],"tag":"FeeTooSmallUTxO"},"tag":"AlonzoInBabbageUtxoPredFailure"},"tag":"UtxoFailure"},"tag":"UtxowFailure"}],"kind":"ShelleyTxValidationError"}`,
+		},
+	}
+	for _, test := range testDefs {
+		cborData, _ := hex.DecodeString(test.CborHex)
+		txErr, err := ledger.NewTxSubmitErrorFromCbor(cborData)
+		if err != nil {
+			t.Fatalf("failed to decode error: %s", err)
+		}
+		if txErr.Error() != test.Error {
+			t.Fatalf("did not get expected error string\n  got:    %s\n  wanted: %s", txErr.Error(), test.Error)
+		}
+		jsonData, err := json.Marshal(txErr)
+		if err != nil {
+			t.Fatalf("failed to marshal error to JSON: %s", err)
+		}
+		if string(jsonData) != test.Json {
+			t.Fatalf("did not get expected JSON\n  got:    %s\n  wanted: %s", jsonData, test.Json)
+		}
+	}
+}
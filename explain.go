@@ -0,0 +1,244 @@
+package ledger
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	LOVELACE_PER_ADA = 1_000_000
+)
+
+// ErrorExplainer is implemented by ledger error types that can provide a user-facing explanation
+// of the failure along with steps to resolve it
+type ErrorExplainer interface {
+	Explain() string
+}
+
+// ExplainError returns a user-facing explanation for a transaction submission error. Wrapper
+// errors are unwrapped until an error with its own explanation is found, and the explanations for
+// multiple failures are joined with newlines. Errors without an explanation fall back to the
+// output of Error()
+func ExplainError(err error) string {
+	if err == nil {
+		return ""
+	}
+	if explainer, ok := err.(ErrorExplainer); ok {
+		return explainer.Explain()
+	}
+	switch e := err.(type) {
+	case interface{ Unwrap() []error }:
+		var ret []string
+		for _, tmpErr := range e.Unwrap() {
+			ret = append(ret, ExplainError(tmpErr))
+		}
+		return strings.Join(ret, "\n")
+	case interface{ Unwrap() error }:
+		if tmpErr := e.Unwrap(); tmpErr != nil {
+			return ExplainError(tmpErr)
+		}
+	}
+	return fmt.Sprintf("The transaction was rejected by the ledger: %s", err.Error())
+}
+
+// Helper function to format a lovelace amount along with its value in ADA
+func formatLovelace(amount uint64) string {
+	return fmt.Sprintf("%d lovelace (%d.%06d ADA)", amount, amount/LOVELACE_PER_ADA, amount%LOVELACE_PER_ADA)
+}
+
+func (e *ShelleyTxValidationError) Explain() string {
+	return ExplainError(&e.Err)
+}
+
+func (e *ApplyTxError) Explain() string {
+	ret := make([]string, 0, len(e.Failures))
+	for _, failure := range e.Failures {
+		ret = append(ret, ExplainError(failure))
+	}
+	return strings.Join(ret, "\n")
+}
+
+func (e *FeeTooSmallUtxo) Explain() string {
+	var shortfall uint64
+	if e.MinimumFee > e.SuppliedFee {
+		shortfall = e.MinimumFee - e.SuppliedFee
+	}
+	return fmt.Sprintf(
+		"The transaction fee is too low. The minimum fee is %s, but only %s was supplied. Increase the fee by at least %s and sign the transaction again.",
+		formatLovelace(e.MinimumFee),
+		formatLovelace(e.SuppliedFee),
+		formatLovelace(shortfall),
+	)
+}
+
+func (e *OutsideValidityIntervalUtxo) Explain() string {
+	if e.ValidityInterval.InvalidHereafter != nil && e.Slot >= *e.ValidityInterval.InvalidHereafter {
+		return fmt.Sprintf(
+			"The transaction has expired. It was only valid before slot %d, but the current slot is %d. Build the transaction again with a later TTL and sign it again.",
+			*e.ValidityInterval.InvalidHereafter,
+			e.Slot,
+		)
+	}
+	if e.ValidityInterval.InvalidBefore != nil && e.Slot < *e.ValidityInterval.InvalidBefore {
+		return fmt.Sprintf(
+			"The transaction was submitted too early. It is not valid until slot %d, but the current slot is %d. Wait %d slots before submitting it again.",
+			*e.ValidityInterval.InvalidBefore,
+			e.Slot,
+			*e.ValidityInterval.InvalidBefore-e.Slot,
+		)
+	}
+	return fmt.Sprintf("The transaction is not valid at the current slot %d (%s). Check the validity interval of the transaction.", e.Slot, e.ValidityInterval.String())
+}
+
+func (e *ExpiredUtxo) Explain() string {
+	return fmt.Sprintf(
+		"The transaction has expired. Its TTL was slot %d, but the current slot is %d. Build the transaction again with a later TTL and sign it again.",
+		e.Ttl,
+		e.Slot,
+	)
+}
+
+func (e *InsufficientCollateral) Explain() string {
+	var missing uint64
+	if e.BalanceComputed < 0 {
+		missing = e.RequiredCollateral + uint64(-e.BalanceComputed)
+	} else if uint64(e.BalanceComputed) < e.RequiredCollateral {
+		missing = e.RequiredCollateral - uint64(e.BalanceComputed)
+	}
+	return fmt.Sprintf(
+		"The collateral for this smart contract transaction is too low. At least %s is required, but the collateral provides %d lovelace. Add collateral inputs worth at least %s more.",
+		formatLovelace(e.RequiredCollateral),
+		e.BalanceComputed,
+		formatLovelace(missing),
+	)
+}
+
+func (e *IncorrectTotalCollateralField) Explain() string {
+	return fmt.Sprintf(
+		"The total collateral declared in the transaction (%d lovelace) does not match the collateral actually provided (%d lovelace). Update the total collateral field to match.",
+		e.TotalCollateral,
+		e.ProvidedCollateral,
+	)
+}
+
+func (e *NoCollateralInputs) Explain() string {
+	return "This smart contract transaction does not include any collateral. Add a collateral input containing only ADA."
+}
+
+func (e *TooManyCollateralInputs) Explain() string {
+	return fmt.Sprintf(
+		"The transaction uses %d collateral inputs, but at most %d are allowed. Use fewer, larger ADA-only inputs as collateral.",
+		e.Supplied,
+		e.MaxAllowed,
+	)
+}
+
+func (e *CollateralContainsNonADA) Explain() string {
+	return "The collateral inputs contain native tokens. Use collateral inputs that contain only ADA, or add a collateral return output for the tokens."
+}
+
+func (e *BadInputsUtxo) Explain() string {
+	inputs := make([]string, 0, len(e.Inputs))
+	for _, input := range e.Inputs {
+		inputs = append(inputs, fmt.Sprintf("%s#%d", input.Utxo.String(), input.TxIx))
+	}
+	return fmt.Sprintf(
+		"Some of the transaction inputs have already been spent or do not exist: %s. Refresh the wallet balance and build the transaction again.",
+		strings.Join(inputs, ", "),
+	)
+}
+
+func (e *InputSetEmptyUtxo) Explain() string {
+	return "The transaction does not spend any inputs. Add at least one input to pay for the transaction."
+}
+
+func (e *ValueNotConservedUtxo) Explain() string {
+	ret := fmt.Sprintf(
+		"The transaction is not balanced. The inputs provide %s, but the outputs and fees require %s.",
+		e.Consumed.String(),
+		e.Produced.String(),
+	)
+	if e.Consumed.Coin < e.Produced.Coin {
+		ret = fmt.Sprintf("%s Add inputs worth at least %s more.", ret, formatLovelace(e.Produced.Coin-e.Consumed.Coin))
+	} else if e.Consumed.Coin > e.Produced.Coin {
+		ret = fmt.Sprintf("%s Add %s to the change output.", ret, formatLovelace(e.Consumed.Coin-e.Produced.Coin))
+	}
+	return ret
+}
+
+func (e *MaxTxSizeUtxo) Explain() string {
+	return fmt.Sprintf(
+		"The transaction is too large (%d bytes, maximum %d bytes). Reduce the size by at least %d bytes, for example by using fewer inputs or less metadata.",
+		e.ActualSize,
+		e.MaxSize,
+		e.ActualSize-e.MaxSize,
+	)
+}
+
+func (e *OutputTooSmallUtxo) Explain() string {
+	return fmt.Sprintf(
+		"%d transaction output(s) do not contain the minimum amount of ADA required by the protocol. Increase the ADA in each of these outputs.",
+		len(e.Outputs),
+	)
+}
+
+func (e *BabbageOutputTooSmallUtxo) Explain() string {
+	outputs := make([]string, 0, len(e.Outputs))
+	for _, output := range e.Outputs {
+		outputs = append(
+			outputs,
+			fmt.Sprintf(
+				"the output to %s contains %s but requires at least %s",
+				output.Output.Address.String(),
+				formatLovelace(output.Output.Amount.Coin),
+				formatLovelace(output.MinAmount),
+			),
+		)
+	}
+	return fmt.Sprintf(
+		"Some transaction outputs do not contain the minimum amount of ADA required by the protocol: %s. Increase the ADA in each of these outputs.",
+		strings.Join(outputs, "; "),
+	)
+}
+
+func (e *WrongNetwork) Explain() string {
+	return fmt.Sprintf(
+		"The transaction sends funds to addresses for a different network. Expected network ID %d, but got: %s. Check that the recipient addresses are for the correct network.",
+		e.ExpectedNetworkId,
+		formatAddresses(e.Addresses),
+	)
+}
+
+func (e *WrongNetworkInTxBody) Explain() string {
+	return fmt.Sprintf(
+		"The transaction was built for network ID %d, but was submitted to network ID %d. Build the transaction for the correct network.",
+		e.TransactionNetworkId,
+		e.ActualNetworkId,
+	)
+}
+
+func (e *MissingVKeyWitnessesUtxow) Explain() string {
+	keyHashes := make([]string, 0, len(e.KeyHashes))
+	for _, keyHash := range e.KeyHashes {
+		keyHashes = append(keyHashes, keyHash.String())
+	}
+	return fmt.Sprintf(
+		"The transaction is missing required signatures for the following key hashes: %s. Sign the transaction with the corresponding keys.",
+		strings.Join(keyHashes, ", "),
+	)
+}
+
+func (e *InvalidWitnessesUtxow) Explain() string {
+	return "One or more signatures on the transaction are invalid. This usually means that the transaction was modified after it was signed. Sign the transaction again."
+}
+
+func (e *MissingScriptWitnessesUtxow) Explain() string {
+	return "The transaction is missing scripts required to spend its inputs or mint its tokens. Attach the scripts, or reference them using reference inputs."
+}
+
+func (e *StakeKeyNotRegisteredDeleg) Explain() string {
+	return fmt.Sprintf(
+		"The stake key %s is not registered. Register the stake key before delegating or withdrawing rewards.",
+		e.StakeCredential.Hash.String(),
+	)
+}
@@ -0,0 +1,160 @@
+package ledger
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/cloudstruct/go-cardano-ledger/cbor"
+)
+
+const (
+	// Reference scripts are priced in tiers of this many bytes, with the price per byte increasing
+	// by the multiplier below for each tier
+	refScriptFeeSizeIncrement = 25600
+	// The multiplier is 1.2, represented as a fraction
+	refScriptFeeMultiplierNumerator   = 6
+	refScriptFeeMultiplierDenominator = 5
+)
+
+// CalculateMinFee returns the minimum fee required by the ledger for a transaction, which is the
+// same value reported in FeeTooSmallUtxo when a transaction is rejected for its fee. The fee is
+// calculated from the size of the transaction's original CBOR (or its encoded size, if it was not
+// decoded from CBOR), the execution units of its redeemers, and the size of the reference scripts
+// in the UTxOs it spends or references. The resolved inputs are used to find reference scripts,
+// and any inputs missing from them are assumed to have no reference script
+func CalculateMinFee(tx interface{}, params BabbageProtocolParameters, resolvedInputs ...UnspentOutput) (uint64, error) {
+	var redeemers []Redeemer
+	var inputs []ShelleyTransactionInput
+	switch t := tx.(type) {
+	case *ShelleyTransaction, *AllegraTransaction, *MaryTransaction:
+	case *AlonzoTransaction:
+		redeemers = t.WitnessSet.Redeemers
+	case *BabbageTransaction:
+		redeemers = t.WitnessSet.Redeemers
+		inputs = append(inputs, t.Body.Inputs...)
+		inputs = append(inputs, t.Body.ReferenceInputs...)
+	case *ConwayTransaction:
+		if t.WitnessSet.Redeemers != nil {
+			redeemers = t.WitnessSet.Redeemers.Redeemers
+		}
+		inputs = append(inputs, t.Body.Inputs...)
+		inputs = append(inputs, t.Body.ReferenceInputs...)
+	default:
+		return 0, fmt.Errorf("unsupported transaction type: %T", tx)
+	}
+	txSize, err := transactionSize(tx.(cbor.DecodeStoreCborInterface))
+	if err != nil {
+		return 0, err
+	}
+	fee := params.MinFeeA*txSize + params.MinFeeB
+	var totalExUnits ExUnits
+	for _, redeemer := range redeemers {
+		totalExUnits.Memory += redeemer.ExUnits.Memory
+		totalExUnits.Steps += redeemer.ExUnits.Steps
+	}
+	fee += CalculateScriptFee(totalExUnits, params.ExecutionCosts)
+	// Each input and reference input is counted, even if the same UTxO appears in both
+	utxoMap := make(map[ShelleyTransactionInput]BabbageTransactionOutput, len(resolvedInputs))
+	for _, utxo := range resolvedInputs {
+		utxoMap[utxo.Input] = utxo.Output
+	}
+	var refScriptSize uint64
+	for _, input := range inputs {
+		output, ok := utxoMap[input]
+		if !ok {
+			continue
+		}
+		scriptSize, err := referenceScriptSize(output)
+		if err != nil {
+			return 0, fmt.Errorf("failed to decode reference script for input %s#%d: %w", input.Id.String(), input.Index, err)
+		}
+		refScriptSize += scriptSize
+	}
+	fee += CalculateRefScriptFee(refScriptSize, params.MinFeeRefScriptCostPerByte)
+	return fee, nil
+}
+
+// CalculateScriptFee returns the fee for the specified execution units, rounded up to the next
+// lovelace
+func CalculateScriptFee(exUnits ExUnits, prices ExUnitPrices) uint64 {
+	fee := new(big.Rat)
+	fee.Add(
+		rationalMul(prices.MemPrice, exUnits.Memory),
+		rationalMul(prices.StepPrice, exUnits.Steps),
+	)
+	// Round up
+	ret := new(big.Int).Quo(fee.Num(), fee.Denom())
+	if new(big.Int).Mul(ret, fee.Denom()).Cmp(fee.Num()) != 0 {
+		ret.Add(ret, big.NewInt(1))
+	}
+	return ret.Uint64()
+}
+
+// CalculateRefScriptFee returns the fee for reference scripts of the specified total size. The
+// price per byte increases by a factor of 1.2 for each 25KiB of reference scripts, and the result
+// is rounded down to the nearest lovelace
+func CalculateRefScriptFee(refScriptSize uint64, costPerByte UnitInterval) uint64 {
+	fee := new(big.Rat)
+	tierPrice := rationalMul(costPerByte, 1)
+	multiplier := big.NewRat(refScriptFeeMultiplierNumerator, refScriptFeeMultiplierDenominator)
+	for refScriptSize >= refScriptFeeSizeIncrement {
+		fee.Add(fee, new(big.Rat).Mul(tierPrice, new(big.Rat).SetUint64(refScriptFeeSizeIncrement)))
+		tierPrice.Mul(tierPrice, multiplier)
+		refScriptSize -= refScriptFeeSizeIncrement
+	}
+	fee.Add(fee, new(big.Rat).Mul(tierPrice, new(big.Rat).SetUint64(refScriptSize)))
+	return new(big.Int).Quo(fee.Num(), fee.Denom()).Uint64()
+}
+
+// Helper function to return the size of a transaction from its original CBOR, if available
+func transactionSize(tx cbor.DecodeStoreCborInterface) (uint64, error) {
+	if txCbor := tx.Cbor(); txCbor != nil {
+		return uint64(len(txCbor)), nil
+	}
+	txCbor, err := cbor.Encode(tx)
+	if err != nil {
+		return 0, err
+	}
+	return uint64(len(txCbor)), nil
+}
+
+// Helper function to return the size of the reference script in an output, if any. The size of a
+// Plutus script is the size of its serialized script bytes, and the size of a native script is the
+// size of its CBOR
+func referenceScriptSize(output BabbageTransactionOutput) (uint64, error) {
+	if output.ScriptRef == nil {
+		return 0, nil
+	}
+	var script []cbor.RawMessage
+	if _, err := cbor.Decode(output.ScriptRef.Bytes(), &script); err != nil {
+		return 0, err
+	}
+	if len(script) != 2 {
+		return 0, fmt.Errorf("invalid script reference")
+	}
+	var scriptType uint
+	if _, err := cbor.Decode(script[0], &scriptType); err != nil {
+		return 0, err
+	}
+	// Native script
+	if scriptType == 0 {
+		return uint64(len(script[1])), nil
+	}
+	var scriptBytes []byte
+	if _, err := cbor.Decode(script[1], &scriptBytes); err != nil {
+		return 0, err
+	}
+	return uint64(len(scriptBytes)), nil
+}
+
+// Helper function to multiply a rational by an integer. A zero denominator is treated as zero
+func rationalMul(r UnitInterval, value uint64) *big.Rat {
+	if r.Denominator == 0 {
+		return new(big.Rat)
+	}
+	ret := new(big.Rat).SetFrac(
+		new(big.Int).SetUint64(r.Numerator),
+		new(big.Int).SetUint64(r.Denominator),
+	)
+	return ret.Mul(ret, new(big.Rat).SetUint64(value))
+}
@@ -0,0 +1,172 @@
+package ledger_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/cloudstruct/go-cardano-ledger"
+	"github.com/cloudstruct/go-cardano-ledger/cbor"
+)
+
+var testExUnitPrices = ledger.ExUnitPrices{
+	MemPrice:  ledger.UnitInterval{Numerator: 577, Denominator: 10000},
+	StepPrice: ledger.UnitInterval{Numerator: 721, Denominator: 10000000},
+}
+
+func TestCalculateScriptFee(t *testing.T) {
+	testDefs := []struct {
+		exUnits     ledger.ExUnits
+		expectedFee uint64
+	}{
+		{
+			exUnits:     ledger.ExUnits{},
+			expectedFee: 0,
+		},
+		{
+			exUnits:     ledger.ExUnits{Memory: 1000000, Steps: 500000000},
+			expectedFee: 93750,
+		},
+		// Fractional fees are rounded up
+		{
+			exUnits:     ledger.ExUnits{Memory: 1, Steps: 1},
+			expectedFee: 1,
+		},
+	}
+	for _, testDef := range testDefs {
+		if fee := ledger.CalculateScriptFee(testDef.exUnits, testExUnitPrices); fee != testDef.expectedFee {
+			t.Fatalf("did not get expected fee for %d memory and %d steps: got %d, expected %d", testDef.exUnits.Memory, testDef.exUnits.Steps, fee, testDef.expectedFee)
+		}
+	}
+}
+
+func TestCalculateRefScriptFee(t *testing.T) {
+	costPerByte := ledger.UnitInterval{Numerator: 15, Denominator: 1}
+	testDefs := []struct {
+		size        uint64
+		expectedFee uint64
+	}{
+		{
+			size:        0,
+			expectedFee: 0,
+		},
+		{
+			size:        1000,
+			expectedFee: 15000,
+		},
+		// The second tier is priced at 1.2x the base price
+		{
+			size:        30000,
+			expectedFee: 25600*15 + 4400*18,
+		},
+		// The third tier is priced at 1.44x the base price, and the result is rounded down
+		{
+			size:        51201,
+			expectedFee: 25600*15 + 25600*18 + 21,
+		},
+	}
+	for _, testDef := range testDefs {
+		if fee := ledger.CalculateRefScriptFee(testDef.size, costPerByte); fee != testDef.expectedFee {
+			t.Fatalf("did not get expected fee for %d bytes: got %d, expected %d", testDef.size, fee, testDef.expectedFee)
+		}
+	}
+}
+
+func TestCalculateMinFee(t *testing.T) {
+	addr := testAddress(t, "addr1vx2fxv2umyhttkxyxp8x0dlpdt3k6cwng5pxj3jhsydzers66hrl8")
+	utxo := testUtxo(addr, 0x01, 0, ledger.MaryValue{Coin: 10000000})
+	// A reference input containing a 100 byte Plutus V2 script
+	refScript, err := cbor.Encode([]interface{}{2, bytes.Repeat([]byte{0x01}, 100)})
+	if err != nil {
+		t.Fatalf("failed to encode script: %s", err)
+	}
+	scriptRef := cbor.WrappedCbor(refScript)
+	refUtxo := testUtxo(addr, 0x02, 0, ledger.MaryValue{Coin: 5000000})
+	refUtxo.Output.ScriptRef = &scriptRef
+	tmpTx := ledger.BabbageTransaction{IsValid: true}
+	tmpTx.Body.Inputs = []ledger.ShelleyTransactionInput{utxo.Input}
+	tmpTx.Body.ReferenceInputs = []ledger.ShelleyTransactionInput{refUtxo.Input}
+	tmpTx.Body.Outputs = []ledger.BabbageTransactionOutput{{Address: addr, Amount: ledger.MaryValue{Coin: 9000000}}}
+	tmpTx.Body.Fee = 1000000
+	tmpTx.WitnessSet.Redeemers = []ledger.Redeemer{
+		{
+			Tag:     ledger.REDEEMER_TAG_SPEND,
+			Index:   0,
+			Data:    cbor.RawMessage{0x00},
+			ExUnits: ledger.ExUnits{Memory: 1000000, Steps: 500000000},
+		},
+	}
+	txCbor, err := cbor.Encode(&tmpTx)
+	if err != nil {
+		t.Fatalf("failed to encode transaction: %s", err)
+	}
+	tx, err := ledger.NewBabbageTransactionFromCbor(txCbor)
+	if err != nil {
+		t.Fatalf("failed to decode transaction: %s", err)
+	}
+	params := testProtocolParams
+	params.ExecutionCosts = testExUnitPrices
+	params.MinFeeRefScriptCostPerByte = ledger.UnitInterval{Numerator: 15, Denominator: 1}
+	sizeFee := params.MinFeeA*uint64(len(txCbor)) + params.MinFeeB
+	fee, err := ledger.CalculateMinFee(tx, params)
+	if err != nil {
+		t.Fatalf("failed to calculate fee: %s", err)
+	}
+	if expectedFee := sizeFee + 93750; fee != expectedFee {
+		t.Fatalf("did not get expected fee: got %d, expected %d", fee, expectedFee)
+	}
+	// The reference script is only counted when the reference input is resolved
+	fee, err = ledger.CalculateMinFee(tx, params, utxo, refUtxo)
+	if err != nil {
+		t.Fatalf("failed to calculate fee: %s", err)
+	}
+	if expectedFee := sizeFee + 93750 + 1500; fee != expectedFee {
+		t.Fatalf("did not get expected fee: got %d, expected %d", fee, expectedFee)
+	}
+}
+
+func TestConwayRedeemersDecode(t *testing.T) {
+	testDefs := []struct {
+		name        string
+		witnessHex  string
+		expectedLen int
+	}{
+		{
+			name:        "List",
+			witnessHex:  "a105818400000082186418c8",
+			expectedLen: 1,
+		},
+		{
+			name:        "Map",
+			witnessHex:  "a105a2820000820082186418c8820101820182190100190200",
+			expectedLen: 2,
+		},
+	}
+	for _, testDef := range testDefs {
+		witnessCbor, err := hex.DecodeString(testDef.witnessHex)
+		if err != nil {
+			t.Fatalf("%s: failed to decode hex: %s", testDef.name, err)
+		}
+		var witnessSet ledger.ConwayTransactionWitnessSet
+		if _, err := cbor.Decode(witnessCbor, &witnessSet); err != nil {
+			t.Fatalf("%s: failed to decode witness set: %s", testDef.name, err)
+		}
+		if witnessSet.Redeemers == nil {
+			t.Fatalf("%s: redeemers were not decoded", testDef.name)
+		}
+		redeemers := witnessSet.Redeemers.Redeemers
+		if len(redeemers) != testDef.expectedLen {
+			t.Fatalf("%s: expected %d redeemers, got %d", testDef.name, testDef.expectedLen, len(redeemers))
+		}
+		if redeemers[0].ExUnits.Memory != 100 || redeemers[0].ExUnits.Steps != 200 {
+			t.Fatalf("%s: did not get expected execution units: %+v", testDef.name, redeemers[0].ExUnits)
+		}
+		tmpCbor, err := cbor.Encode(&witnessSet)
+		if err != nil {
+			t.Fatalf("%s: failed to encode witness set: %s", testDef.name, err)
+		}
+		if !bytes.Equal(tmpCbor, witnessCbor) {
+			t.Fatalf("%s: witness set did not round-trip: got %x, expected %x", testDef.name, tmpCbor, witnessCbor)
+		}
+	}
+}
@@ -3,12 +3,15 @@ module github.com/cloudstruct/go-cardano-ledger
 go 1.18
 
 require (
+	filippo.io/edwards25519 v1.0.0
 	github.com/fxamacker/cbor/v2 v2.4.0
 	github.com/jinzhu/copier v0.3.5
+	github.com/tyler-smith/go-bip39 v1.1.0
+	go.etcd.io/bbolt v1.3.8
 	golang.org/x/crypto v0.0.0-20220824171710-5757bc0c5503
 )
 
 require (
 	github.com/x448/float16 v0.8.4 // indirect
-	golang.org/x/sys v0.1.0 // indirect
+	golang.org/x/sys v0.7.0 // indirect
 )
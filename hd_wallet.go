@@ -0,0 +1,237 @@
+package ledger
+
+import (
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/tyler-smith/go-bip39"
+	"golang.org/x/crypto/pbkdf2"
+)
+
+const (
+	HARDENED_KEY_START = 0x80000000
+
+	CIP1852_PURPOSE   = 1852
+	CIP1852_COIN_TYPE = 1815
+
+	CIP1852_ROLE_EXTERNAL = 0
+	CIP1852_ROLE_INTERNAL = 1
+	CIP1852_ROLE_STAKE    = 2
+	CIP1852_ROLE_DREP     = 3
+
+	icarusPbkdf2Iterations = 4096
+)
+
+// NewMasterKeyFromMnemonic returns the Icarus master key for a BIP39 mnemonic and optional
+// password, which is the root key used by Shelley-era wallets
+func NewMasterKeyFromMnemonic(mnemonic string, password string) (*ExtendedSigningKey, error) {
+	entropy, err := bip39.EntropyFromMnemonic(mnemonic)
+	if err != nil {
+		return nil, fmt.Errorf("invalid mnemonic: %s", err)
+	}
+	return NewMasterKeyFromEntropy(entropy, password)
+}
+
+// NewMasterKeyFromEntropy returns the Icarus master key for the entropy of a BIP39 mnemonic and
+// optional password
+func NewMasterKeyFromEntropy(entropy []byte, password string) (*ExtendedSigningKey, error) {
+	key := pbkdf2.Key(
+		[]byte(password),
+		entropy,
+		icarusPbkdf2Iterations,
+		ED25519_EXTENDED_KEY_SIZE+ED25519_CHAIN_CODE_SIZE,
+		sha512.New,
+	)
+	key[0] &= 0xf8
+	key[31] &= 0x1f
+	key[31] |= 0x40
+	return NewExtendedSigningKey(key)
+}
+
+// Derive returns the child key at the specified index using BIP32-Ed25519 derivation. Indexes
+// starting at HARDENED_KEY_START produce hardened keys
+func (k *ExtendedSigningKey) Derive(index uint32) (*ExtendedSigningKey, error) {
+	if k.chainCode == nil {
+		return nil, fmt.Errorf("key does not have a chain code")
+	}
+	var indexBytes [4]byte
+	binary.LittleEndian.PutUint32(indexBytes[:], index)
+	zHmac := hmac.New(sha512.New, k.chainCode)
+	ccHmac := hmac.New(sha512.New, k.chainCode)
+	if index >= HARDENED_KEY_START {
+		zHmac.Write([]byte{0x00})
+		zHmac.Write(k.key)
+		ccHmac.Write([]byte{0x01})
+		ccHmac.Write(k.key)
+	} else {
+		zHmac.Write([]byte{0x02})
+		zHmac.Write(k.publicKey)
+		ccHmac.Write([]byte{0x03})
+		ccHmac.Write(k.publicKey)
+	}
+	zHmac.Write(indexBytes[:])
+	ccHmac.Write(indexBytes[:])
+	z := zHmac.Sum(nil)
+	cc := ccHmac.Sum(nil)
+	childKey := make([]byte, 0, ED25519_EXTENDED_KEY_SIZE+ED25519_CHAIN_CODE_SIZE)
+	// kL = 8 * zL[:28] + parent kL
+	var carry uint16
+	for i := 0; i < 32; i++ {
+		tmp := uint16(k.key[i]) + carry
+		if i < 28 {
+			tmp += uint16(z[i]) << 3
+		}
+		childKey = append(childKey, byte(tmp))
+		carry = tmp >> 8
+	}
+	// kR = zR + parent kR (mod 2^256)
+	carry = 0
+	for i := 32; i < 64; i++ {
+		tmp := uint16(k.key[i]) + uint16(z[i]) + carry
+		childKey = append(childKey, byte(tmp))
+		carry = tmp >> 8
+	}
+	childKey = append(childKey, cc[32:]...)
+	return NewExtendedSigningKey(childKey)
+}
+
+// DerivePath returns the key at the specified derivation path, such as "m/1852'/1815'/0'/0/0".
+// Hardened indexes are marked with either ' or H
+func (k *ExtendedSigningKey) DerivePath(path string) (*ExtendedSigningKey, error) {
+	parts := strings.Split(path, "/")
+	if len(parts) == 0 || parts[0] != "m" {
+		return nil, fmt.Errorf("invalid derivation path: %s", path)
+	}
+	ret := k
+	for _, part := range parts[1:] {
+		var offset uint32
+		if strings.HasSuffix(part, "'") || strings.HasSuffix(part, "H") {
+			offset = HARDENED_KEY_START
+			part = part[:len(part)-1]
+		}
+		index, err := strconv.ParseUint(part, 10, 32)
+		if err != nil || index >= HARDENED_KEY_START {
+			return nil, fmt.Errorf("invalid derivation path: %s", path)
+		}
+		ret, err = ret.Derive(uint32(index) + offset)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return ret, nil
+}
+
+// KeyHash returns the hash of the public key, as used in addresses and required signers
+func (k *ExtendedSigningKey) KeyHash() Blake2b224 {
+	return NewBlake2b224Hash(k.publicKey)
+}
+
+// Cip1852Wallet derives keys and addresses from a master key using the CIP-1852 derivation paths
+// (m/1852'/1815'/account'/role/index)
+type Cip1852Wallet struct {
+	masterKey *ExtendedSigningKey
+}
+
+// NewCip1852Wallet returns a Cip1852Wallet using the specified master key
+func NewCip1852Wallet(masterKey *ExtendedSigningKey) *Cip1852Wallet {
+	return &Cip1852Wallet{masterKey: masterKey}
+}
+
+// NewCip1852WalletFromMnemonic returns a Cip1852Wallet for a BIP39 mnemonic and optional password
+func NewCip1852WalletFromMnemonic(mnemonic string, password string) (*Cip1852Wallet, error) {
+	masterKey, err := NewMasterKeyFromMnemonic(mnemonic, password)
+	if err != nil {
+		return nil, err
+	}
+	return NewCip1852Wallet(masterKey), nil
+}
+
+// AccountKey returns the key for the specified account (m/1852'/1815'/account')
+func (w *Cip1852Wallet) AccountKey(account uint32) (*ExtendedSigningKey, error) {
+	if account >= HARDENED_KEY_START {
+		return nil, fmt.Errorf("invalid account index: %d", account)
+	}
+	key := w.masterKey
+	for _, index := range []uint32{CIP1852_PURPOSE, CIP1852_COIN_TYPE, account} {
+		var err error
+		key, err = key.Derive(index + HARDENED_KEY_START)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return key, nil
+}
+
+// Key returns the key for the specified account, role and index
+func (w *Cip1852Wallet) Key(account uint32, role uint32, index uint32) (*ExtendedSigningKey, error) {
+	accountKey, err := w.AccountKey(account)
+	if err != nil {
+		return nil, err
+	}
+	roleKey, err := accountKey.Derive(role)
+	if err != nil {
+		return nil, err
+	}
+	return roleKey.Derive(index)
+}
+
+// PaymentKey returns the external payment key for the specified account and index
+func (w *Cip1852Wallet) PaymentKey(account uint32, index uint32) (*ExtendedSigningKey, error) {
+	return w.Key(account, CIP1852_ROLE_EXTERNAL, index)
+}
+
+// ChangeKey returns the internal (change) payment key for the specified account and index
+func (w *Cip1852Wallet) ChangeKey(account uint32, index uint32) (*ExtendedSigningKey, error) {
+	return w.Key(account, CIP1852_ROLE_INTERNAL, index)
+}
+
+// StakeKey returns the stake key for the specified account
+func (w *Cip1852Wallet) StakeKey(account uint32) (*ExtendedSigningKey, error) {
+	return w.Key(account, CIP1852_ROLE_STAKE, 0)
+}
+
+// DRepKey returns the DRep key for the specified account, as defined in CIP-105
+func (w *Cip1852Wallet) DRepKey(account uint32) (*ExtendedSigningKey, error) {
+	return w.Key(account, CIP1852_ROLE_DREP, 0)
+}
+
+// BaseAddress returns the base address for the external payment key with the specified account
+// and index, delegated to the account's stake key
+func (w *Cip1852Wallet) BaseAddress(networkId uint8, account uint32, index uint32) (Address, error) {
+	paymentKey, err := w.PaymentKey(account, index)
+	if err != nil {
+		return Address{}, err
+	}
+	stakeKey, err := w.StakeKey(account)
+	if err != nil {
+		return Address{}, err
+	}
+	paymentKeyHash := paymentKey.KeyHash()
+	stakeKeyHash := stakeKey.KeyHash()
+	return NewAddressFromParts(ADDRESS_TYPE_KEY_KEY, networkId, paymentKeyHash[:], stakeKeyHash[:])
+}
+
+// EnterpriseAddress returns the enterprise address for the external payment key with the
+// specified account and index
+func (w *Cip1852Wallet) EnterpriseAddress(networkId uint8, account uint32, index uint32) (Address, error) {
+	paymentKey, err := w.PaymentKey(account, index)
+	if err != nil {
+		return Address{}, err
+	}
+	paymentKeyHash := paymentKey.KeyHash()
+	return NewAddressFromParts(ADDRESS_TYPE_KEY_NONE, networkId, paymentKeyHash[:], nil)
+}
+
+// RewardAddress returns the reward address for the stake key of the specified account
+func (w *Cip1852Wallet) RewardAddress(networkId uint8, account uint32) (Address, error) {
+	stakeKey, err := w.StakeKey(account)
+	if err != nil {
+		return Address{}, err
+	}
+	stakeKeyHash := stakeKey.KeyHash()
+	return NewAddressFromParts(ADDRESS_TYPE_NONE_KEY, networkId, nil, stakeKeyHash[:])
+}
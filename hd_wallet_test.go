@@ -0,0 +1,101 @@
+package ledger_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/cloudstruct/go-cardano-ledger"
+)
+
+const testMnemonic = "test walk nut penalty hip pave soap entry language right filter choice"
+
+func TestMasterKeyFromMnemonic(t *testing.T) {
+	// Test vector from CIP-0003
+	key, err := ledger.NewMasterKeyFromMnemonic("eight country switch draw meat scout mystery blade tip drift useless good keep usage title", "")
+	if err != nil {
+		t.Fatalf("failed to create master key: %s", err)
+	}
+	expectedChainCode := "23f7fdcd4a10c6cd2c7393ac61d877873e248f417634aa3d812af327ffe9d620"
+	if chainCode := hex.EncodeToString(key.ChainCode()); chainCode != expectedChainCode {
+		t.Fatalf("did not get expected chain code: got %s, expected %s", chainCode, expectedChainCode)
+	}
+	if _, err := ledger.NewMasterKeyFromMnemonic("test walk nut penalty hip pave soap entry language right filter filter", ""); err == nil {
+		t.Fatalf("did not get expected error for invalid mnemonic")
+	}
+}
+
+func TestCip1852Wallet(t *testing.T) {
+	wallet, err := ledger.NewCip1852WalletFromMnemonic(testMnemonic, "")
+	if err != nil {
+		t.Fatalf("failed to create wallet: %s", err)
+	}
+	testDefs := []struct {
+		name         string
+		address      func() (ledger.Address, error)
+		expectedAddr string
+	}{
+		{
+			name: "Base",
+			address: func() (ledger.Address, error) {
+				return wallet.BaseAddress(ledger.ADDRESS_NETWORK_ID_MAINNET, 0, 0)
+			},
+			expectedAddr: "addr1qx2fxv2umyhttkxyxp8x0dlpdt3k6cwng5pxj3jhsydzer3jcu5d8ps7zex2k2xt3uqxgjqnnj83ws8lhrn648jjxtwqfjkjv7",
+		},
+		// This matches the enterprise address from the CIP-0019 test vectors
+		{
+			name: "Enterprise",
+			address: func() (ledger.Address, error) {
+				return wallet.EnterpriseAddress(ledger.ADDRESS_NETWORK_ID_MAINNET, 0, 0)
+			},
+			expectedAddr: "addr1vx2fxv2umyhttkxyxp8x0dlpdt3k6cwng5pxj3jhsydzers66hrl8",
+		},
+		{
+			name: "Reward",
+			address: func() (ledger.Address, error) {
+				return wallet.RewardAddress(ledger.ADDRESS_NETWORK_ID_MAINNET, 0)
+			},
+			expectedAddr: "stake1uyevw2xnsc0pvn9t9r9c7qryfqfeerchgrlm3ea2nefr9hqxdekzz",
+		},
+	}
+	for _, testDef := range testDefs {
+		addr, err := testDef.address()
+		if err != nil {
+			t.Fatalf("%s: failed to derive address: %s", testDef.name, err)
+		}
+		if addr.String() != testDef.expectedAddr {
+			t.Fatalf("%s: did not get expected address: got %s, expected %s", testDef.name, addr.String(), testDef.expectedAddr)
+		}
+	}
+	// Keys derived from the path match the keys from the wallet
+	masterKey, err := ledger.NewMasterKeyFromMnemonic(testMnemonic, "")
+	if err != nil {
+		t.Fatalf("failed to create master key: %s", err)
+	}
+	pathKey, err := masterKey.DerivePath("m/1852'/1815'/0'/3/0")
+	if err != nil {
+		t.Fatalf("failed to derive key: %s", err)
+	}
+	drepKey, err := wallet.DRepKey(0)
+	if err != nil {
+		t.Fatalf("failed to derive key: %s", err)
+	}
+	if !bytes.Equal(pathKey.PublicKey(), drepKey.PublicKey()) || pathKey.KeyHash() != drepKey.KeyHash() {
+		t.Fatalf("key derived from path does not match DRep key")
+	}
+	// Different accounts produce different keys
+	otherKey, err := wallet.PaymentKey(1, 0)
+	if err != nil {
+		t.Fatalf("failed to derive key: %s", err)
+	}
+	paymentKey, err := wallet.PaymentKey(0, 0)
+	if err != nil {
+		t.Fatalf("failed to derive key: %s", err)
+	}
+	if otherKey.KeyHash() == paymentKey.KeyHash() {
+		t.Fatalf("payment keys for different accounts are the same")
+	}
+	if _, err := masterKey.DerivePath("m/1852'/x"); err == nil {
+		t.Fatalf("did not get expected error for invalid derivation path")
+	}
+}
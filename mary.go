@@ -1,7 +1,10 @@
 package ledger
 
 import (
+	"encoding/json"
 	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/cloudstruct/go-cardano-ledger/cbor"
 )
@@ -29,7 +32,7 @@ func (b *MaryBlock) UnmarshalCBOR(cborData []byte) error {
 	return b.UnmarshalCborGeneric(cborData, b)
 }
 
-func (b *MaryBlock) Hash() string {
+func (b *MaryBlock) Hash() Blake2b256 {
 	return b.Header.Hash()
 }
 
@@ -47,8 +50,8 @@ func (b *MaryBlock) Era() Era {
 
 func (b *MaryBlock) Transactions() []TransactionBody {
 	ret := []TransactionBody{}
-	for _, v := range b.TransactionBodies {
-		ret = append(ret, &v)
+	for idx := range b.TransactionBodies {
+		ret = append(ret, &b.TransactionBodies[idx])
 	}
 	return ret
 }
@@ -65,8 +68,7 @@ type MaryTransactionBody struct {
 	AllegraTransactionBody
 	//Outputs []MaryTransactionOutput `cbor:"1,keyasint,omitempty"`
 	Outputs []cbor.Value `cbor:"1,keyasint,omitempty"`
-	// TODO: further parsing of this field
-	Mint cbor.Value `cbor:"9,keyasint,omitempty"`
+	Mint    MintAssets   `cbor:"9,keyasint,omitempty"`
 }
 
 func (b *MaryTransactionBody) UnmarshalCBOR(cborData []byte) error {
@@ -75,11 +77,16 @@ func (b *MaryTransactionBody) UnmarshalCBOR(cborData []byte) error {
 
 type MaryTransaction struct {
 	cbor.StructAsArray
+	cbor.DecodeStoreCbor
 	Body       MaryTransactionBody
 	WitnessSet ShelleyTransactionWitnessSet
 	Metadata   cbor.Value
 }
 
+func (t *MaryTransaction) UnmarshalCBOR(cborData []byte) error {
+	return t.UnmarshalCborGeneric(cborData, t)
+}
+
 // TODO: support both forms
 /*
 transaction_output = [address, amount : value]
@@ -89,6 +96,81 @@ value = coin / [coin,multiasset<uint>]
 
 type MaryTransactionOutput cbor.Value
 
+// MultiAsset represents a set of native asset quantities, keyed by policy ID and asset name
+type MultiAsset map[Blake2b224]map[cbor.ByteString]uint64
+
+// MintAssets represents the native assets minted (positive quantity) or burned (negative
+// quantity) by a transaction, keyed by policy ID and asset name
+type MintAssets map[Blake2b224]map[cbor.ByteString]int64
+
+// MaryValue represents a transaction output value, which is either a bare coin amount or a
+// coin amount along with native assets
+type MaryValue struct {
+	Coin   uint64
+	Assets MultiAsset
+}
+
+func (v *MaryValue) UnmarshalCBOR(data []byte) error {
+	// A bare coin amount is encoded as an unsigned integer
+	if len(data) > 0 && (data[0]&cbor.CBOR_TYPE_MASK) != cbor.CBOR_TYPE_ARRAY {
+		var tmpCoin uint64
+		if _, err := cbor.Decode(data, &tmpCoin); err != nil {
+			return err
+		}
+		v.Coin = tmpCoin
+		v.Assets = nil
+		return nil
+	}
+	var tmpValue struct {
+		cbor.StructAsArray
+		Coin   uint64
+		Assets MultiAsset
+	}
+	if _, err := cbor.Decode(data, &tmpValue); err != nil {
+		return err
+	}
+	v.Coin = tmpValue.Coin
+	v.Assets = tmpValue.Assets
+	return nil
+}
+
+func (v MaryValue) MarshalCBOR() ([]byte, error) {
+	if len(v.Assets) == 0 {
+		return cbor.Encode(v.Coin)
+	}
+	return cbor.Encode([]interface{}{v.Coin, v.Assets})
+}
+
+// MarshalJSON returns the JSON representation of the value, using the same layout as cardano-cli
+func (v MaryValue) MarshalJSON() ([]byte, error) {
+	ret := map[string]interface{}{
+		"lovelace": v.Coin,
+	}
+	for policyId, assets := range v.Assets {
+		tmpAssets := map[string]uint64{}
+		for assetName, amount := range assets {
+			tmpAssets[assetName.String()] = amount
+		}
+		ret[policyId.String()] = tmpAssets
+	}
+	return json.Marshal(ret)
+}
+
+func (v MaryValue) String() string {
+	if len(v.Assets) == 0 {
+		return fmt.Sprintf("Coin %d", v.Coin)
+	}
+	var assets []string
+	for policyId, policyAssets := range v.Assets {
+		for assetName, amount := range policyAssets {
+			assets = append(assets, fmt.Sprintf("%s.%s: %d", policyId.String(), assetName.String(), amount))
+		}
+	}
+	// Sort the assets to give consistent output
+	sort.Strings(assets)
+	return fmt.Sprintf("Coin %d, MultiAsset [%s]", v.Coin, strings.Join(assets, ", "))
+}
+
 func NewMaryBlockFromCbor(data []byte) (*MaryBlock, error) {
 	var maryBlock MaryBlock
 	if _, err := cbor.Decode(data, &maryBlock); err != nil {
@@ -0,0 +1,97 @@
+package ledger
+
+import (
+	"github.com/cloudstruct/go-cardano-ledger/cbor"
+)
+
+const (
+	// Size of the fixed overhead added to the serialized size of an output when calculating the
+	// minimum lovelace for the output
+	BABBAGE_MIN_UTXO_OVERHEAD_BYTES = 160
+
+	// Sizes in 8-byte words used by the Mary and Alonzo minimum UTxO calculations
+	maryUtxoEntrySizeWithoutVal = 27
+	alonzoCoinSize              = 2
+	alonzoDataHashSize          = 10
+	// Sizes in bytes of the components of a multi-asset value
+	multiAssetBaseSizeWords = 6
+	multiAssetAssetSize     = 12
+	multiAssetPolicyIdSize  = 28
+	multiAssetWordSizeBytes = 8
+)
+
+// MinUTxO returns the minimum lovelace required by the ledger for a transaction output. The
+// calculation is chosen based on which protocol parameters are set:
+//
+//   - AdaPerUtxoByte (Babbage onward) uses the serialized size of the output, including any datum
+//     and reference script
+//   - CoinsPerUtxoWord (Alonzo) uses the size of the value and datum hash in 8-byte words
+//   - MinUtxoValue (Shelley through Mary) uses the fixed minimum, scaled up for native assets
+//
+// For outputs decoded from CBOR, the size of the original CBOR is used, as the ledger does
+func MinUTxO(output BabbageTransactionOutput, params BabbageProtocolParameters) (uint64, error) {
+	if params.AdaPerUtxoByte > 0 {
+		return minUtxoBabbage(output, params.AdaPerUtxoByte)
+	}
+	if params.CoinsPerUtxoWord > 0 {
+		entrySize := maryUtxoEntrySizeWithoutVal + multiAssetSize(output.Amount.Assets)
+		if len(output.Amount.Assets) == 0 {
+			entrySize = maryUtxoEntrySizeWithoutVal + alonzoCoinSize
+		}
+		if output.DatumHash != nil {
+			entrySize += alonzoDataHashSize
+		}
+		return entrySize * params.CoinsPerUtxoWord, nil
+	}
+	if len(output.Amount.Assets) == 0 {
+		return params.MinUtxoValue, nil
+	}
+	minCoin := (params.MinUtxoValue / maryUtxoEntrySizeWithoutVal) * (maryUtxoEntrySizeWithoutVal + multiAssetSize(output.Amount.Assets))
+	if minCoin < params.MinUtxoValue {
+		minCoin = params.MinUtxoValue
+	}
+	return minCoin, nil
+}
+
+// Helper function to calculate the minimum lovelace from the serialized size of the output. For
+// new outputs, the output is evaluated with enough lovelace to cover the minimum, since the size
+// of the encoded lovelace amount affects the result
+func minUtxoBabbage(output BabbageTransactionOutput, adaPerUtxoByte uint64) (uint64, error) {
+	if outputCbor := output.Cbor(); outputCbor != nil {
+		return (BABBAGE_MIN_UTXO_OVERHEAD_BYTES + uint64(len(outputCbor))) * adaPerUtxoByte, nil
+	}
+	tmpOutput := output
+	var minCoin uint64
+	for {
+		outputCbor, err := cbor.Encode(&tmpOutput)
+		if err != nil {
+			return 0, err
+		}
+		newMinCoin := (BABBAGE_MIN_UTXO_OVERHEAD_BYTES + uint64(len(outputCbor))) * adaPerUtxoByte
+		if newMinCoin <= tmpOutput.Amount.Coin || newMinCoin == minCoin {
+			return newMinCoin, nil
+		}
+		minCoin = newMinCoin
+		tmpOutput.Amount.Coin = newMinCoin
+	}
+}
+
+// Helper function to calculate the size of a multi-asset value in 8-byte words, as defined in the
+// Mary ledger spec. The asset name lengths are counted once for each distinct asset name
+func multiAssetSize(assets MultiAsset) uint64 {
+	var numAssets, numPolicies uint64
+	assetNames := map[cbor.ByteString]bool{}
+	for _, policyAssets := range assets {
+		numPolicies++
+		for assetName := range policyAssets {
+			numAssets++
+			assetNames[assetName] = true
+		}
+	}
+	var assetNameLengths uint64
+	for assetName := range assetNames {
+		assetNameLengths += uint64(len(assetName.Bytes()))
+	}
+	sizeBytes := numAssets*multiAssetAssetSize + assetNameLengths + numPolicies*multiAssetPolicyIdSize
+	return multiAssetBaseSizeWords + (sizeBytes+multiAssetWordSizeBytes-1)/multiAssetWordSizeBytes
+}
@@ -0,0 +1,131 @@
+package ledger_test
+
+import (
+	"testing"
+
+	"github.com/cloudstruct/go-cardano-ledger"
+	"github.com/cloudstruct/go-cardano-ledger/cbor"
+)
+
+func TestMinUTxO(t *testing.T) {
+	addr := testAddress(t, "addr1vx2fxv2umyhttkxyxp8x0dlpdt3k6cwng5pxj3jhsydzers66hrl8")
+	var policyId2 ledger.Blake2b224
+	policyId2[0] = 0x22
+	emptyAssetName := cbor.NewByteString(nil)
+	oneCharAssetName := cbor.NewByteString([]byte("a"))
+	var datumHash ledger.Blake2b256
+	shelleyParams := ledger.BabbageProtocolParameters{MinUtxoValue: 1000000}
+	alonzoParams := ledger.BabbageProtocolParameters{CoinsPerUtxoWord: 34482}
+	babbageParams := ledger.BabbageProtocolParameters{AdaPerUtxoByte: 4310}
+	testDefs := []struct {
+		name           string
+		output         ledger.BabbageTransactionOutput
+		params         ledger.BabbageProtocolParameters
+		expectedAmount uint64
+	}{
+		{
+			name:           "ShelleyAdaOnly",
+			output:         ledger.BabbageTransactionOutput{Address: addr},
+			params:         shelleyParams,
+			expectedAmount: 1000000,
+		},
+		// The following Mary cases are from the examples in the Mary ledger spec
+		{
+			name: "MaryOnePolicyEmptyName",
+			output: ledger.BabbageTransactionOutput{
+				Address: addr,
+				Amount:  ledger.MaryValue{Assets: ledger.MultiAsset{testPolicyId: {emptyAssetName: 1}}},
+			},
+			params:         shelleyParams,
+			expectedAmount: 1407406,
+		},
+		{
+			name: "MaryOnePolicyOneCharName",
+			output: ledger.BabbageTransactionOutput{
+				Address: addr,
+				Amount:  ledger.MaryValue{Assets: ledger.MultiAsset{testPolicyId: {oneCharAssetName: 1}}},
+			},
+			params:         shelleyParams,
+			expectedAmount: 1444443,
+		},
+		{
+			name: "MaryTwoPoliciesEmptyName",
+			output: ledger.BabbageTransactionOutput{
+				Address: addr,
+				Amount: ledger.MaryValue{
+					Assets: ledger.MultiAsset{
+						testPolicyId: {emptyAssetName: 1},
+						policyId2:    {emptyAssetName: 1},
+					},
+				},
+			},
+			params:         shelleyParams,
+			expectedAmount: 1592591,
+		},
+		{
+			name: "MaryTwoPoliciesOneCharName",
+			output: ledger.BabbageTransactionOutput{
+				Address: addr,
+				Amount: ledger.MaryValue{
+					Assets: ledger.MultiAsset{
+						testPolicyId: {oneCharAssetName: 1},
+						policyId2:    {oneCharAssetName: 1},
+					},
+				},
+			},
+			params:         shelleyParams,
+			expectedAmount: 1629628,
+		},
+		{
+			name:           "AlonzoAdaOnly",
+			output:         ledger.BabbageTransactionOutput{Address: addr},
+			params:         alonzoParams,
+			expectedAmount: 999978,
+		},
+		{
+			name: "AlonzoAssetsAndDatumHash",
+			output: ledger.BabbageTransactionOutput{
+				Address:   addr,
+				Amount:    ledger.MaryValue{Assets: ledger.MultiAsset{testPolicyId: {oneCharAssetName: 1}}},
+				DatumHash: &datumHash,
+			},
+			params:         alonzoParams,
+			expectedAmount: 1689618,
+		},
+		{
+			name:           "BabbageAdaOnly",
+			output:         ledger.BabbageTransactionOutput{Address: addr},
+			params:         babbageParams,
+			expectedAmount: (160 + 39) * 4310,
+		},
+	}
+	for _, testDef := range testDefs {
+		amount, err := ledger.MinUTxO(testDef.output, testDef.params)
+		if err != nil {
+			t.Fatalf("%s: failed to calculate minimum UTxO: %s", testDef.name, err)
+		}
+		if amount != testDef.expectedAmount {
+			t.Fatalf("%s: did not get expected amount: got %d, expected %d", testDef.name, amount, testDef.expectedAmount)
+		}
+	}
+}
+
+func TestMinUTxOOriginalCbor(t *testing.T) {
+	addr := testAddress(t, "addr1vx2fxv2umyhttkxyxp8x0dlpdt3k6cwng5pxj3jhsydzers66hrl8")
+	// The legacy array format is smaller than the map format
+	outputCbor, err := cbor.Encode([]interface{}{addr, uint64(2000000)})
+	if err != nil {
+		t.Fatalf("failed to encode output: %s", err)
+	}
+	var output ledger.BabbageTransactionOutput
+	if _, err := cbor.Decode(outputCbor, &output); err != nil {
+		t.Fatalf("failed to decode output: %s", err)
+	}
+	amount, err := ledger.MinUTxO(output, ledger.BabbageProtocolParameters{AdaPerUtxoByte: 4310})
+	if err != nil {
+		t.Fatalf("failed to calculate minimum UTxO: %s", err)
+	}
+	if expectedAmount := uint64(160+len(outputCbor)) * 4310; amount != expectedAmount {
+		t.Fatalf("did not get expected amount: got %d, expected %d", amount, expectedAmount)
+	}
+}
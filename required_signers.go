@@ -0,0 +1,286 @@
+package ledger
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+
+	"github.com/cloudstruct/go-cardano-ledger/cbor"
+)
+
+const (
+	NATIVE_SCRIPT_TYPE_PUBKEY         = 0
+	NATIVE_SCRIPT_TYPE_ALL            = 1
+	NATIVE_SCRIPT_TYPE_ANY            = 2
+	NATIVE_SCRIPT_TYPE_N_OF_K         = 3
+	NATIVE_SCRIPT_TYPE_INVALID_BEFORE = 4
+	NATIVE_SCRIPT_TYPE_INVALID_AFTER  = 5
+
+	// Prefix byte for the script hash of a native script
+	nativeScriptHashPrefix = 0x00
+)
+
+// RequiredSigners returns the key hashes of every key that may need to sign a transaction. This
+// includes the payment keys for spent inputs and collateral, the keys for certificates,
+// withdrawals and votes, the explicit required signers, and the keys in any native scripts used by
+// the transaction. The inputs spent and referenced by the transaction are looked up in the
+// resolved UTxOs. Inputs at Byron addresses require bootstrap witnesses, and are not included
+func RequiredSigners(tx interface{}, utxos []UnspentOutput) ([]Blake2b224, error) {
+	var inputs, collateral, referenceInputs []ShelleyTransactionInput
+	var certificates []CertificateWrapper
+	var withdrawals map[cbor.ByteString]uint64
+	var mint MintAssets
+	var requiredSigners []Blake2b224
+	var voters []Voter
+	var witnessSet ShelleyTransactionWitnessSet
+	switch t := tx.(type) {
+	case *ShelleyTransaction:
+		inputs, certificates, withdrawals = t.Body.Inputs, t.Body.Certificates, t.Body.Withdrawals
+		witnessSet = t.WitnessSet
+	case *AllegraTransaction:
+		inputs, certificates, withdrawals = t.Body.Inputs, t.Body.Certificates, t.Body.Withdrawals
+		witnessSet = t.WitnessSet
+	case *MaryTransaction:
+		inputs, certificates, withdrawals = t.Body.Inputs, t.Body.Certificates, t.Body.Withdrawals
+		mint = t.Body.Mint
+		witnessSet = t.WitnessSet
+	case *AlonzoTransaction:
+		inputs, certificates, withdrawals = t.Body.Inputs, t.Body.Certificates, t.Body.Withdrawals
+		mint, collateral, requiredSigners = t.Body.Mint, t.Body.Collateral, t.Body.RequiredSigners
+		witnessSet = t.WitnessSet.ShelleyTransactionWitnessSet
+	case *BabbageTransaction:
+		inputs, certificates, withdrawals = t.Body.Inputs, t.Body.Certificates, t.Body.Withdrawals
+		mint, collateral, requiredSigners = t.Body.Mint, t.Body.Collateral, t.Body.RequiredSigners
+		referenceInputs = t.Body.ReferenceInputs
+		witnessSet = t.WitnessSet.ShelleyTransactionWitnessSet
+	case *ConwayTransaction:
+		inputs, certificates, withdrawals = t.Body.Inputs, t.Body.Certificates, t.Body.Withdrawals
+		mint, collateral, requiredSigners = t.Body.Mint, t.Body.Collateral, t.Body.RequiredSigners
+		referenceInputs = t.Body.ReferenceInputs
+		for voter := range t.Body.VotingProcedures {
+			voters = append(voters, voter)
+		}
+		witnessSet = t.WitnessSet.ShelleyTransactionWitnessSet
+	default:
+		return nil, fmt.Errorf("unsupported transaction type: %T", tx)
+	}
+	utxoMap := make(map[ShelleyTransactionInput]BabbageTransactionOutput, len(utxos))
+	for _, utxo := range utxos {
+		utxoMap[utxo.Input] = utxo.Output
+	}
+	keyHashes := map[Blake2b224]bool{}
+	// Script hashes that the transaction needs a script for
+	scriptHashes := map[Blake2b224]bool{}
+	addCredential := func(cred *Credential) {
+		if cred == nil {
+			return
+		}
+		if cred.Type == CREDENTIAL_TYPE_ADDR_KEY_HASH {
+			keyHashes[cred.Hash] = true
+		} else {
+			scriptHashes[cred.Hash] = true
+		}
+	}
+	for _, input := range append(inputs[:len(inputs):len(inputs)], collateral...) {
+		output, ok := utxoMap[input]
+		if !ok {
+			return nil, fmt.Errorf("input not found in UTxOs: %s#%d", input.Id.String(), input.Index)
+		}
+		if output.Address.Type() == ADDRESS_TYPE_BYRON {
+			continue
+		}
+		addCredential(output.Address.PaymentCredential())
+	}
+	for _, cert := range certificates {
+		for _, cred := range certificateWitnessCredentials(cert.Certificate) {
+			cred := cred
+			addCredential(&cred)
+		}
+	}
+	for rewardAccount := range withdrawals {
+		addr, err := NewAddressFromBytes(rewardAccount.Bytes())
+		if err != nil {
+			return nil, fmt.Errorf("invalid withdrawal reward account: %s", err)
+		}
+		addCredential(addr.StakeCredential())
+	}
+	for policyId := range mint {
+		scriptHashes[policyId] = true
+	}
+	for _, voter := range voters {
+		switch voter.Type {
+		case VOTER_TYPE_CONSTITUTIONAL_COMMITTEE_HOT_KEY_HASH, VOTER_TYPE_DREP_KEY_HASH, VOTER_TYPE_STAKING_POOL_KEY_HASH:
+			keyHashes[voter.Hash] = true
+		default:
+			scriptHashes[voter.Hash] = true
+		}
+	}
+	for _, keyHash := range requiredSigners {
+		keyHashes[keyHash] = true
+	}
+	// Every native script in the witness set is used by the transaction
+	for _, script := range witnessSet.MultisigScripts {
+		scriptCbor, err := cbor.Encode(script)
+		if err != nil {
+			return nil, err
+		}
+		tmpKeyHashes, err := nativeScriptKeyHashes(scriptCbor)
+		if err != nil {
+			return nil, err
+		}
+		for _, keyHash := range tmpKeyHashes {
+			keyHashes[keyHash] = true
+		}
+	}
+	// Native reference scripts are only used if the transaction needs a script with their hash
+	for _, input := range append(inputs[:len(inputs):len(inputs)], referenceInputs...) {
+		output, ok := utxoMap[input]
+		if !ok {
+			return nil, fmt.Errorf("input not found in UTxOs: %s#%d", input.Id.String(), input.Index)
+		}
+		if output.ScriptRef == nil {
+			continue
+		}
+		var scriptRef []cbor.RawMessage
+		if _, err := cbor.Decode(output.ScriptRef.Bytes(), &scriptRef); err != nil || len(scriptRef) != 2 {
+			return nil, fmt.Errorf("invalid reference script for input %s#%d", input.Id.String(), input.Index)
+		}
+		var scriptType uint
+		if _, err := cbor.Decode(scriptRef[0], &scriptType); err != nil {
+			return nil, err
+		}
+		if scriptType != 0 {
+			continue
+		}
+		if !scriptHashes[NewBlake2b224Hash(append([]byte{nativeScriptHashPrefix}, scriptRef[1]...))] {
+			continue
+		}
+		tmpKeyHashes, err := nativeScriptKeyHashes(scriptRef[1])
+		if err != nil {
+			return nil, err
+		}
+		for _, keyHash := range tmpKeyHashes {
+			keyHashes[keyHash] = true
+		}
+	}
+	ret := make([]Blake2b224, 0, len(keyHashes))
+	for keyHash := range keyHashes {
+		ret = append(ret, keyHash)
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		return bytes.Compare(ret[i][:], ret[j][:]) < 0
+	})
+	return ret, nil
+}
+
+// Helper function to return the credentials that must authorize a certificate. Pool key hashes
+// are returned as key hash credentials
+func certificateWitnessCredentials(cert Certificate) []Credential {
+	var ret []Credential
+	switch c := cert.(type) {
+	case *StakeDeregistrationCertificate:
+		ret = append(ret, c.StakeCredential)
+	case *StakeDelegationCertificate:
+		ret = append(ret, c.StakeCredential)
+	case *RegistrationCertificate:
+		ret = append(ret, c.StakeCredential)
+	case *DeregistrationCertificate:
+		ret = append(ret, c.StakeCredential)
+	case *VoteDelegationCertificate:
+		ret = append(ret, c.StakeCredential)
+	case *StakeVoteDelegationCertificate:
+		ret = append(ret, c.StakeCredential)
+	case *StakeRegistrationDelegationCertificate:
+		ret = append(ret, c.StakeCredential)
+	case *VoteRegistrationDelegationCertificate:
+		ret = append(ret, c.StakeCredential)
+	case *StakeVoteRegistrationDelegationCertificate:
+		ret = append(ret, c.StakeCredential)
+	case *AuthCommitteeHotCertificate:
+		ret = append(ret, c.ColdCredential)
+	case *ResignCommitteeColdCertificate:
+		ret = append(ret, c.ColdCredential)
+	case *RegistrationDrepCertificate:
+		ret = append(ret, c.DrepCredential)
+	case *DeregistrationDrepCertificate:
+		ret = append(ret, c.DrepCredential)
+	case *UpdateDrepCertificate:
+		ret = append(ret, c.DrepCredential)
+	case *PoolRegistrationCertificate:
+		ret = append(ret, Credential{Type: CREDENTIAL_TYPE_ADDR_KEY_HASH, Hash: c.Operator})
+		for _, owner := range c.PoolOwners {
+			ret = append(ret, Credential{Type: CREDENTIAL_TYPE_ADDR_KEY_HASH, Hash: owner})
+		}
+	case *PoolRetirementCertificate:
+		ret = append(ret, Credential{Type: CREDENTIAL_TYPE_ADDR_KEY_HASH, Hash: c.PoolKeyHash})
+	}
+	return ret
+}
+
+// Helper function to return the key hashes that must sign a certificate
+func certificateWitnessKeyHashes(cert Certificate) []Blake2b224 {
+	var ret []Blake2b224
+	for _, cred := range certificateWitnessCredentials(cert) {
+		if cred.Type == CREDENTIAL_TYPE_ADDR_KEY_HASH {
+			ret = append(ret, cred.Hash)
+		}
+	}
+	return ret
+}
+
+// Helper function to return the key hashes in a native script. All of the key hashes are returned
+// for "any" and "n of k" scripts, since any of them may be used to sign
+func nativeScriptKeyHashes(scriptCbor []byte) ([]Blake2b224, error) {
+	var items []cbor.RawMessage
+	if _, err := cbor.Decode(scriptCbor, &items); err != nil {
+		return nil, fmt.Errorf("invalid native script: %s", err)
+	}
+	if len(items) == 0 {
+		return nil, fmt.Errorf("invalid native script")
+	}
+	var scriptType uint
+	if _, err := cbor.Decode(items[0], &scriptType); err != nil {
+		return nil, fmt.Errorf("invalid native script: %s", err)
+	}
+	var subScripts cbor.RawMessage
+	switch scriptType {
+	case NATIVE_SCRIPT_TYPE_PUBKEY:
+		var keyHash []byte
+		if len(items) != 2 {
+			return nil, fmt.Errorf("invalid native script")
+		}
+		if _, err := cbor.Decode(items[1], &keyHash); err != nil || len(keyHash) != ADDRESS_HASH_SIZE {
+			return nil, fmt.Errorf("invalid key hash in native script")
+		}
+		var ret Blake2b224
+		copy(ret[:], keyHash)
+		return []Blake2b224{ret}, nil
+	case NATIVE_SCRIPT_TYPE_ALL, NATIVE_SCRIPT_TYPE_ANY:
+		if len(items) != 2 {
+			return nil, fmt.Errorf("invalid native script")
+		}
+		subScripts = items[1]
+	case NATIVE_SCRIPT_TYPE_N_OF_K:
+		if len(items) != 3 {
+			return nil, fmt.Errorf("invalid native script")
+		}
+		subScripts = items[2]
+	case NATIVE_SCRIPT_TYPE_INVALID_BEFORE, NATIVE_SCRIPT_TYPE_INVALID_AFTER:
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("unknown native script type: %d", scriptType)
+	}
+	var scripts []cbor.RawMessage
+	if _, err := cbor.Decode(subScripts, &scripts); err != nil {
+		return nil, fmt.Errorf("invalid native script: %s", err)
+	}
+	var ret []Blake2b224
+	for _, script := range scripts {
+		tmpKeyHashes, err := nativeScriptKeyHashes(script)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, tmpKeyHashes...)
+	}
+	return ret, nil
+}
@@ -0,0 +1,152 @@
+package ledger_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/cloudstruct/go-cardano-ledger"
+	"github.com/cloudstruct/go-cardano-ledger/cbor"
+)
+
+// Helper function to return a key hash filled with the specified byte
+func testKeyHash(b byte) ledger.Blake2b224 {
+	var ret ledger.Blake2b224
+	copy(ret[:], bytes.Repeat([]byte{b}, len(ret)))
+	return ret
+}
+
+func TestRequiredSigners(t *testing.T) {
+	paymentKeyHash := testKeyHash(0x01)
+	stakeKeyHash := testKeyHash(0x02)
+	collateralKeyHash := testKeyHash(0x03)
+	rewardKeyHash := testKeyHash(0x04)
+	poolKeyHash := testKeyHash(0x05)
+	explicitKeyHash := testKeyHash(0x06)
+	witnessScriptKeyHashes := []ledger.Blake2b224{testKeyHash(0x07), testKeyHash(0x08)}
+	refScriptKeyHash := testKeyHash(0x09)
+	unusedRefScriptKeyHash := testKeyHash(0x0a)
+	baseAddr, err := ledger.NewAddressFromParts(ledger.ADDRESS_TYPE_KEY_KEY, 1, paymentKeyHash[:], stakeKeyHash[:])
+	if err != nil {
+		t.Fatalf("failed to build address: %s", err)
+	}
+	collateralAddr, err := ledger.NewAddressFromParts(ledger.ADDRESS_TYPE_KEY_NONE, 1, collateralKeyHash[:], nil)
+	if err != nil {
+		t.Fatalf("failed to build address: %s", err)
+	}
+	rewardAddr, err := ledger.NewAddressFromParts(ledger.ADDRESS_TYPE_NONE_KEY, 1, nil, rewardKeyHash[:])
+	if err != nil {
+		t.Fatalf("failed to build address: %s", err)
+	}
+	utxo := testUtxo(baseAddr, 0x01, 0, ledger.MaryValue{Coin: 10000000})
+	collateralUtxo := testUtxo(collateralAddr, 0x02, 0, ledger.MaryValue{Coin: 5000000})
+	// A reference input with a native script used as the minting policy
+	refScript, err := cbor.Encode([]interface{}{ledger.NATIVE_SCRIPT_TYPE_PUBKEY, refScriptKeyHash[:]})
+	if err != nil {
+		t.Fatalf("failed to encode script: %s", err)
+	}
+	scriptRef, err := cbor.Encode([]interface{}{0, cbor.RawMessage(refScript)})
+	if err != nil {
+		t.Fatalf("failed to encode script: %s", err)
+	}
+	wrappedScriptRef := cbor.WrappedCbor(scriptRef)
+	refUtxo := testUtxo(collateralAddr, 0x03, 0, ledger.MaryValue{Coin: 5000000})
+	refUtxo.Output.ScriptRef = &wrappedScriptRef
+	// A reference input with a native script that the transaction does not use
+	unusedScript, err := cbor.Encode([]interface{}{ledger.NATIVE_SCRIPT_TYPE_PUBKEY, unusedRefScriptKeyHash[:]})
+	if err != nil {
+		t.Fatalf("failed to encode script: %s", err)
+	}
+	unusedScriptRef, err := cbor.Encode([]interface{}{0, cbor.RawMessage(unusedScript)})
+	if err != nil {
+		t.Fatalf("failed to encode script: %s", err)
+	}
+	wrappedUnusedScriptRef := cbor.WrappedCbor(unusedScriptRef)
+	unusedRefUtxo := testUtxo(collateralAddr, 0x04, 0, ledger.MaryValue{Coin: 5000000})
+	unusedRefUtxo.Output.ScriptRef = &wrappedUnusedScriptRef
+	// A native script in the witness set requiring one key and either of two others
+	witnessScriptCbor, err := cbor.Encode(
+		[]interface{}{
+			ledger.NATIVE_SCRIPT_TYPE_ALL,
+			[]interface{}{
+				[]interface{}{ledger.NATIVE_SCRIPT_TYPE_PUBKEY, witnessScriptKeyHashes[0][:]},
+				[]interface{}{
+					ledger.NATIVE_SCRIPT_TYPE_N_OF_K,
+					1,
+					[]interface{}{
+						[]interface{}{ledger.NATIVE_SCRIPT_TYPE_PUBKEY, witnessScriptKeyHashes[1][:]},
+						[]interface{}{ledger.NATIVE_SCRIPT_TYPE_INVALID_AFTER, 1000},
+					},
+				},
+			},
+		},
+	)
+	if err != nil {
+		t.Fatalf("failed to encode script: %s", err)
+	}
+	var witnessScript interface{}
+	if _, err := cbor.Decode(witnessScriptCbor, &witnessScript); err != nil {
+		t.Fatalf("failed to decode script: %s", err)
+	}
+	tx := &ledger.BabbageTransaction{IsValid: true}
+	tx.Body.Inputs = []ledger.ShelleyTransactionInput{utxo.Input}
+	tx.Body.Collateral = []ledger.ShelleyTransactionInput{collateralUtxo.Input}
+	tx.Body.ReferenceInputs = []ledger.ShelleyTransactionInput{refUtxo.Input, unusedRefUtxo.Input}
+	tx.Body.Withdrawals = map[cbor.ByteString]uint64{
+		cbor.NewByteString(rewardAddr.Bytes()): 1000000,
+	}
+	tx.Body.Certificates = []ledger.CertificateWrapper{
+		{
+			Type: ledger.CERTIFICATE_TYPE_STAKE_DELEGATION,
+			Certificate: &ledger.StakeDelegationCertificate{
+				StakeCredential: ledger.Credential{
+					Type: ledger.CREDENTIAL_TYPE_ADDR_KEY_HASH,
+					Hash: stakeKeyHash,
+				},
+				PoolKeyHash: poolKeyHash,
+			},
+		},
+		{
+			Type: ledger.CERTIFICATE_TYPE_POOL_RETIREMENT,
+			Certificate: &ledger.PoolRetirementCertificate{
+				PoolKeyHash: poolKeyHash,
+				Epoch:       500,
+			},
+		},
+	}
+	tx.Body.Mint = ledger.MintAssets{
+		ledger.NewBlake2b224Hash(append([]byte{0x00}, refScript...)): {
+			cbor.NewByteString([]byte("token")): 1,
+		},
+	}
+	tx.Body.RequiredSigners = []ledger.Blake2b224{explicitKeyHash}
+	tx.WitnessSet.MultisigScripts = []interface{}{witnessScript}
+	utxos := []ledger.UnspentOutput{utxo, collateralUtxo, refUtxo, unusedRefUtxo}
+	signers, err := ledger.RequiredSigners(tx, utxos)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	// The results are sorted, which matches the order of the test key hashes
+	expectedSigners := []ledger.Blake2b224{
+		paymentKeyHash,
+		stakeKeyHash,
+		collateralKeyHash,
+		rewardKeyHash,
+		poolKeyHash,
+		explicitKeyHash,
+		witnessScriptKeyHashes[0],
+		witnessScriptKeyHashes[1],
+		refScriptKeyHash,
+	}
+	if len(signers) != len(expectedSigners) {
+		t.Fatalf("did not get expected number of signers: got %d, expected %d", len(signers), len(expectedSigners))
+	}
+	for idx, signer := range signers {
+		if signer != expectedSigners[idx] {
+			t.Fatalf("did not get expected signer at index %d: got %s, expected %s", idx, signer.String(), expectedSigners[idx].String())
+		}
+	}
+	// All inputs must be resolved
+	if _, err := ledger.RequiredSigners(tx, []ledger.UnspentOutput{utxo}); err == nil {
+		t.Fatalf("did not get expected error for unresolved collateral")
+	}
+}
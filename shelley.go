@@ -1,6 +1,7 @@
 package ledger
 
 import (
+	"crypto/ed25519"
 	"fmt"
 
 	"github.com/cloudstruct/go-cardano-ledger/cbor"
@@ -29,7 +30,7 @@ func (b *ShelleyBlock) UnmarshalCBOR(cborData []byte) error {
 	return b.UnmarshalCborGeneric(cborData, b)
 }
 
-func (b *ShelleyBlock) Hash() string {
+func (b *ShelleyBlock) Hash() Blake2b256 {
 	return b.Header.Hash()
 }
 
@@ -47,8 +48,8 @@ func (b *ShelleyBlock) Era() Era {
 
 func (b *ShelleyBlock) Transactions() []TransactionBody {
 	ret := []TransactionBody{}
-	for _, v := range b.TransactionBodies {
-		ret = append(ret, &v)
+	for idx := range b.TransactionBodies {
+		ret = append(ret, &b.TransactionBodies[idx])
 	}
 	return ret
 }
@@ -56,7 +57,7 @@ func (b *ShelleyBlock) Transactions() []TransactionBody {
 type ShelleyBlockHeader struct {
 	cbor.StructAsArray
 	cbor.DecodeStoreCbor
-	hash string
+	hash *Blake2b256
 	Body struct {
 		cbor.StructAsArray
 		BlockNumber          uint64
@@ -82,11 +83,12 @@ func (h *ShelleyBlockHeader) UnmarshalCBOR(cborData []byte) error {
 	return h.UnmarshalCborGeneric(cborData, h)
 }
 
-func (h *ShelleyBlockHeader) Hash() string {
-	if h.hash == "" {
-		h.hash = generateBlockHeaderHash(h.Cbor(), nil)
+func (h *ShelleyBlockHeader) Hash() Blake2b256 {
+	if h.hash == nil {
+		tmpHash := generateBlockHeaderHash(h.Cbor(), nil)
+		h.hash = &tmpHash
 	}
-	return h.hash
+	return *h.hash
 }
 
 func (h *ShelleyBlockHeader) BlockNumber() uint64 {
@@ -103,17 +105,14 @@ func (h *ShelleyBlockHeader) Era() Era {
 
 type ShelleyTransactionBody struct {
 	cbor.DecodeStoreCbor
-	hash    string
-	Inputs  []ShelleyTransactionInput  `cbor:"0,keyasint,omitempty"`
-	Outputs []ShelleyTransactionOutput `cbor:"1,keyasint,omitempty"`
-	Fee     uint64                     `cbor:"2,keyasint,omitempty"`
-	Ttl     uint64                     `cbor:"3,keyasint,omitempty"`
-	// TODO: figure out how to parse properly
-	Certificates []cbor.Value `cbor:"4,keyasint,omitempty"`
-	// TODO: figure out how to parse this correctly
-	// We keep the raw CBOR because it can contain a map with []byte keys, which
-	// Go does not allow
-	Withdrawals cbor.Value `cbor:"5,keyasint,omitempty"`
+	hash         string
+	Inputs       []ShelleyTransactionInput  `cbor:"0,keyasint,omitempty"`
+	Outputs      []ShelleyTransactionOutput `cbor:"1,keyasint,omitempty"`
+	Fee          uint64                     `cbor:"2,keyasint,omitempty"`
+	Ttl          uint64                     `cbor:"3,keyasint,omitempty"`
+	Certificates []CertificateWrapper       `cbor:"4,keyasint,omitempty"`
+	// Withdrawals are keyed by reward account
+	Withdrawals map[cbor.ByteString]uint64 `cbor:"5,keyasint,omitempty"`
 	Update      struct {
 		cbor.StructAsArray
 		ProtocolParamUpdates cbor.Value
@@ -146,18 +145,44 @@ type ShelleyTransactionOutput struct {
 }
 
 type ShelleyTransactionWitnessSet struct {
-	VkeyWitnesses      []interface{} `cbor:"0,keyasint,omitempty"`
+	VkeyWitnesses      []VkeyWitness `cbor:"0,keyasint,omitempty"`
 	MultisigScripts    []interface{} `cbor:"1,keyasint,omitempty"`
 	BootstrapWitnesses []interface{} `cbor:"2,keyasint,omitempty"`
 }
 
+// VkeyWitness is a signature of the transaction body hash along with the verification key
+type VkeyWitness struct {
+	cbor.StructAsArray
+	Vkey      []byte
+	Signature []byte
+}
+
+// KeyHash returns the hash of the verification key, as used in addresses and required signers
+func (w VkeyWitness) KeyHash() Blake2b224 {
+	return NewBlake2b224Hash(w.Vkey)
+}
+
+// Verify returns whether the signature is valid for the specified message, which is normally the
+// transaction body hash
+func (w VkeyWitness) Verify(message []byte) bool {
+	if len(w.Vkey) != ed25519.PublicKeySize {
+		return false
+	}
+	return ed25519.Verify(ed25519.PublicKey(w.Vkey), message, w.Signature)
+}
+
 type ShelleyTransaction struct {
 	cbor.StructAsArray
+	cbor.DecodeStoreCbor
 	Body       ShelleyTransactionBody
 	WitnessSet ShelleyTransactionWitnessSet
 	Metadata   cbor.Value
 }
 
+func (t *ShelleyTransaction) UnmarshalCBOR(cborData []byte) error {
+	return t.UnmarshalCborGeneric(cborData, t)
+}
+
 func NewShelleyBlockFromCbor(data []byte) (*ShelleyBlock, error) {
 	var shelleyBlock ShelleyBlock
 	if _, err := cbor.Decode(data, &shelleyBlock); err != nil {
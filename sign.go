@@ -0,0 +1,246 @@
+package ledger
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/sha512"
+	"encoding/hex"
+	"fmt"
+
+	"filippo.io/edwards25519"
+	"github.com/cloudstruct/go-cardano-ledger/cbor"
+	"golang.org/x/crypto/blake2b"
+)
+
+const (
+	ED25519_EXTENDED_KEY_SIZE = 64
+	ED25519_CHAIN_CODE_SIZE   = 32
+
+	// Key of the verification key witnesses in the transaction witness set
+	witnessSetVkeyWitnessesKey = 0
+)
+
+// SigningKey is implemented by keys that can sign transactions
+type SigningKey interface {
+	PublicKey() []byte
+	Sign(message []byte) []byte
+}
+
+// Ed25519SigningKey is a normal Ed25519 signing key, such as a payment key generated by
+// cardano-cli
+type Ed25519SigningKey struct {
+	key ed25519.PrivateKey
+}
+
+// NewEd25519SigningKey returns an Ed25519SigningKey from either a 32-byte seed or a 64-byte
+// private key consisting of the seed and the public key
+func NewEd25519SigningKey(key []byte) (*Ed25519SigningKey, error) {
+	switch len(key) {
+	case ed25519.SeedSize:
+		return &Ed25519SigningKey{key: ed25519.NewKeyFromSeed(key)}, nil
+	case ed25519.PrivateKeySize:
+		tmpKey := ed25519.NewKeyFromSeed(key[:ed25519.SeedSize])
+		if !bytes.Equal(tmpKey[ed25519.SeedSize:], key[ed25519.SeedSize:]) {
+			return nil, fmt.Errorf("public key does not match private key")
+		}
+		return &Ed25519SigningKey{key: tmpKey}, nil
+	default:
+		return nil, fmt.Errorf("invalid key length: %d", len(key))
+	}
+}
+
+func (k *Ed25519SigningKey) PublicKey() []byte {
+	return []byte(k.key.Public().(ed25519.PublicKey))
+}
+
+func (k *Ed25519SigningKey) Sign(message []byte) []byte {
+	return ed25519.Sign(k.key, message)
+}
+
+// ExtendedSigningKey is a BIP32-Ed25519 extended signing key, such as a key derived from a wallet
+// recovery phrase. The signatures it produces are normal Ed25519 signatures
+type ExtendedSigningKey struct {
+	key       []byte
+	chainCode []byte
+	publicKey []byte
+}
+
+// NewExtendedSigningKey returns an ExtendedSigningKey from a 64-byte extended private key,
+// optionally followed by the 32-byte chain code. The 128-byte format used by cardano-cli, which
+// contains the public key between the private key and the chain code, is also accepted
+func NewExtendedSigningKey(key []byte) (*ExtendedSigningKey, error) {
+	var chainCode []byte
+	switch len(key) {
+	case ED25519_EXTENDED_KEY_SIZE:
+	case ED25519_EXTENDED_KEY_SIZE + ED25519_CHAIN_CODE_SIZE:
+		chainCode = key[ED25519_EXTENDED_KEY_SIZE:]
+	case ED25519_EXTENDED_KEY_SIZE + ed25519.PublicKeySize + ED25519_CHAIN_CODE_SIZE:
+		chainCode = key[ED25519_EXTENDED_KEY_SIZE+ed25519.PublicKeySize:]
+	default:
+		return nil, fmt.Errorf("invalid key length: %d", len(key))
+	}
+	ret := &ExtendedSigningKey{
+		key: make([]byte, ED25519_EXTENDED_KEY_SIZE),
+	}
+	copy(ret.key, key[:ED25519_EXTENDED_KEY_SIZE])
+	if chainCode != nil {
+		ret.chainCode = make([]byte, ED25519_CHAIN_CODE_SIZE)
+		copy(ret.chainCode, chainCode)
+	}
+	scalar, err := ret.scalar()
+	if err != nil {
+		return nil, err
+	}
+	ret.publicKey = new(edwards25519.Point).ScalarBaseMult(scalar).Bytes()
+	if len(key) == ED25519_EXTENDED_KEY_SIZE+ed25519.PublicKeySize+ED25519_CHAIN_CODE_SIZE {
+		if !bytes.Equal(ret.publicKey, key[ED25519_EXTENDED_KEY_SIZE:ED25519_EXTENDED_KEY_SIZE+ed25519.PublicKeySize]) {
+			return nil, fmt.Errorf("public key does not match private key")
+		}
+	}
+	return ret, nil
+}
+
+func (k *ExtendedSigningKey) PublicKey() []byte {
+	return k.publicKey
+}
+
+// ChainCode returns the chain code for the key, or nil if the key was created without one
+func (k *ExtendedSigningKey) ChainCode() []byte {
+	return k.chainCode
+}
+
+func (k *ExtendedSigningKey) Sign(message []byte) []byte {
+	// The scalar is validated when the key is created
+	scalar, _ := k.scalar()
+	// The nonce is derived from the second half of the extended key, as in RFC 8032
+	tmpHash := sha512.New()
+	tmpHash.Write(k.key[32:])
+	tmpHash.Write(message)
+	nonce, _ := edwards25519.NewScalar().SetUniformBytes(tmpHash.Sum(nil))
+	r := new(edwards25519.Point).ScalarBaseMult(nonce).Bytes()
+	tmpHash.Reset()
+	tmpHash.Write(r)
+	tmpHash.Write(k.publicKey)
+	tmpHash.Write(message)
+	challenge, _ := edwards25519.NewScalar().SetUniformBytes(tmpHash.Sum(nil))
+	s := edwards25519.NewScalar().MultiplyAdd(challenge, scalar, nonce)
+	return append(r, s.Bytes()...)
+}
+
+// Helper function to return the scalar from the first half of the extended key. The value is not
+// clamped, since keys derived using BIP32-Ed25519 do not keep the bits set by clamping
+func (k *ExtendedSigningKey) scalar() (*edwards25519.Scalar, error) {
+	var wideKey [64]byte
+	copy(wideKey[:], k.key[:32])
+	return edwards25519.NewScalar().SetUniformBytes(wideKey[:])
+}
+
+// SignTransactionBody signs the hash of a transaction body and returns the resulting witness
+func SignTransactionBody(body TransactionBody, key SigningKey) (VkeyWitness, error) {
+	bodyHash, err := hex.DecodeString(body.Hash())
+	if err != nil {
+		return VkeyWitness{}, err
+	}
+	return VkeyWitness{
+		Vkey:      key.PublicKey(),
+		Signature: key.Sign(bodyHash),
+	}, nil
+}
+
+// SignTransaction signs the CBOR for a transaction with the specified keys and returns the CBOR for
+// the signed transaction. The original CBOR for the transaction body, metadata and all other
+// witnesses is kept as-is
+func SignTransaction(txCbor []byte, keys ...SigningKey) ([]byte, error) {
+	txItems, _, err := decodeTransactionWitnessSet(txCbor)
+	if err != nil {
+		return nil, err
+	}
+	bodyHash := blake2b.Sum256(txItems[0])
+	witnesses := make([]VkeyWitness, 0, len(keys))
+	for _, key := range keys {
+		witnesses = append(
+			witnesses,
+			VkeyWitness{
+				Vkey:      key.PublicKey(),
+				Signature: key.Sign(bodyHash[:]),
+			},
+		)
+	}
+	return AddVkeyWitnesses(txCbor, witnesses...)
+}
+
+// AddVkeyWitnesses adds the witnesses to the CBOR for a transaction and returns the CBOR for the
+// updated transaction. Witnesses for verification keys that already have a witness are ignored.
+// The original CBOR for the transaction body, metadata and all other witnesses is kept as-is
+func AddVkeyWitnesses(txCbor []byte, witnesses ...VkeyWitness) ([]byte, error) {
+	txItems, witnessSet, err := decodeTransactionWitnessSet(txCbor)
+	if err != nil {
+		return nil, err
+	}
+	vkeyWitnesses, useSetTag, err := decodeVkeyWitnesses(witnessSet)
+	if err != nil {
+		return nil, err
+	}
+	for _, witness := range witnesses {
+		var exists bool
+		for _, tmpWitness := range vkeyWitnesses {
+			if bytes.Equal(tmpWitness.Vkey, witness.Vkey) {
+				exists = true
+				break
+			}
+		}
+		if !exists {
+			vkeyWitnesses = append(vkeyWitnesses, witness)
+		}
+	}
+	var vkeyWitnessesCbor []byte
+	if useSetTag {
+		vkeyWitnessesCbor, err = cbor.Encode(cbor.Tag{Number: cbor.CBOR_TAG_SET, Content: vkeyWitnesses})
+	} else {
+		vkeyWitnessesCbor, err = cbor.Encode(vkeyWitnesses)
+	}
+	if err != nil {
+		return nil, err
+	}
+	witnessSet[witnessSetVkeyWitnessesKey] = vkeyWitnessesCbor
+	witnessSetCbor, err := cbor.Encode(witnessSet)
+	if err != nil {
+		return nil, err
+	}
+	txItems[1] = witnessSetCbor
+	return cbor.Encode(txItems)
+}
+
+// Helper function to decode the top-level items of a transaction and the items of its witness set
+func decodeTransactionWitnessSet(txCbor []byte) ([]cbor.RawMessage, map[uint64]cbor.RawMessage, error) {
+	var txItems []cbor.RawMessage
+	if _, err := cbor.Decode(txCbor, &txItems); err != nil {
+		return nil, nil, fmt.Errorf("decode error: %s", err)
+	}
+	if len(txItems) < 3 {
+		return nil, nil, fmt.Errorf("invalid transaction")
+	}
+	witnessSet := map[uint64]cbor.RawMessage{}
+	if _, err := cbor.Decode(txItems[1], &witnessSet); err != nil {
+		return nil, nil, fmt.Errorf("decode error: %s", err)
+	}
+	return txItems, witnessSet, nil
+}
+
+// Helper function to decode the verification key witnesses from a witness set. It also returns
+// whether the witnesses are encoded as a set (tag 258), which is allowed starting in Conway
+func decodeVkeyWitnesses(witnessSet map[uint64]cbor.RawMessage) ([]VkeyWitness, bool, error) {
+	tmpCbor, ok := witnessSet[witnessSetVkeyWitnessesKey]
+	if !ok {
+		return nil, false, nil
+	}
+	var vkeyWitnesses []VkeyWitness
+	if _, err := cbor.Decode(tmpCbor, &vkeyWitnesses); err != nil {
+		return nil, false, fmt.Errorf("decode error: %s", err)
+	}
+	var tmpTag cbor.RawTag
+	if _, err := cbor.Decode(tmpCbor, &tmpTag); err == nil && tmpTag.Number == cbor.CBOR_TAG_SET {
+		return vkeyWitnesses, true, nil
+	}
+	return vkeyWitnesses, false, nil
+}
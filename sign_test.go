@@ -0,0 +1,153 @@
+package ledger_test
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/sha512"
+	"encoding/hex"
+	"testing"
+
+	"github.com/cloudstruct/go-cardano-ledger"
+	"github.com/cloudstruct/go-cardano-ledger/cbor"
+)
+
+// Test vector 1 from RFC 8032
+const (
+	testSigningKeySeedHex  = "9d61b19deffd5a60ba844af492ec2cc44449c5697b326919703bac031cae7f60"
+	testSigningKeyPubHex   = "d75a980182b10ab7d54bfed3c964073a0ee172f3daa62325af021a68f707511a"
+	testSigningKeyEmptySig = "e5564300c360ac729086e2cc806e828a84877f1eb8e5d974d873e065224901555fb8821590a33bacc61e39701cf9b46bd25bf5f0595bbe24655141438e7a100b"
+)
+
+func testSigningKey(t *testing.T) *ledger.Ed25519SigningKey {
+	seed, _ := hex.DecodeString(testSigningKeySeedHex)
+	key, err := ledger.NewEd25519SigningKey(seed)
+	if err != nil {
+		t.Fatalf("failed to create signing key: %s", err)
+	}
+	return key
+}
+
+func TestEd25519SigningKey(t *testing.T) {
+	key := testSigningKey(t)
+	if pubKey := hex.EncodeToString(key.PublicKey()); pubKey != testSigningKeyPubHex {
+		t.Fatalf("did not get expected public key: got %s, expected %s", pubKey, testSigningKeyPubHex)
+	}
+	if sig := hex.EncodeToString(key.Sign(nil)); sig != testSigningKeyEmptySig {
+		t.Fatalf("did not get expected signature: got %s, expected %s", sig, testSigningKeyEmptySig)
+	}
+}
+
+func TestExtendedSigningKey(t *testing.T) {
+	seed, _ := hex.DecodeString(testSigningKeySeedHex)
+	// An extended key built from the expanded Ed25519 seed produces the same signatures
+	extendedKey := sha512.Sum512(seed)
+	extendedKey[0] &= 248
+	extendedKey[31] &= 127
+	extendedKey[31] |= 64
+	chainCode := bytes.Repeat([]byte{0x01}, 32)
+	key, err := ledger.NewExtendedSigningKey(append(extendedKey[:], chainCode...))
+	if err != nil {
+		t.Fatalf("failed to create signing key: %s", err)
+	}
+	if pubKey := hex.EncodeToString(key.PublicKey()); pubKey != testSigningKeyPubHex {
+		t.Fatalf("did not get expected public key: got %s, expected %s", pubKey, testSigningKeyPubHex)
+	}
+	if !bytes.Equal(key.ChainCode(), chainCode) {
+		t.Fatalf("did not get expected chain code: %x", key.ChainCode())
+	}
+	message := []byte("test message")
+	if sig := key.Sign(message); !bytes.Equal(sig, ed25519.Sign(ed25519.NewKeyFromSeed(seed), message)) {
+		t.Fatalf("did not get expected signature: %x", sig)
+	}
+	// The cardano-cli format contains the public key before the chain code
+	cliKey := append(append(extendedKey[:], key.PublicKey()...), chainCode...)
+	if _, err := ledger.NewExtendedSigningKey(cliKey); err != nil {
+		t.Fatalf("failed to create signing key from cardano-cli format: %s", err)
+	}
+	cliKey[64] ^= 0xff
+	if _, err := ledger.NewExtendedSigningKey(cliKey); err == nil {
+		t.Fatalf("did not get expected error for mismatched public key")
+	}
+}
+
+func TestSignTransaction(t *testing.T) {
+	key := testSigningKey(t)
+	keyHash := ledger.VkeyWitness{Vkey: key.PublicKey()}.KeyHash()
+	addr, err := ledger.NewAddressFromParts(ledger.ADDRESS_TYPE_KEY_NONE, ledger.ADDRESS_NETWORK_ID_MAINNET, keyHash[:], nil)
+	if err != nil {
+		t.Fatalf("failed to build address: %s", err)
+	}
+	utxos := []ledger.UnspentOutput{testUtxo(addr, 0x01, 0, ledger.MaryValue{Coin: 10000000})}
+	tx, err := ledger.NewTxBuilder(testProtocolParams).
+		AddUtxos(utxos...).
+		AddOutputs(ledger.BabbageTransactionOutput{Address: addr, Amount: ledger.MaryValue{Coin: 5000000}}).
+		SetChangeAddress(addr).
+		SetMetadata(map[uint64]interface{}{674: map[string]interface{}{"msg": []string{"test"}}}).
+		Build()
+	if err != nil {
+		t.Fatalf("failed to build transaction: %s", err)
+	}
+	signedTxCbor, err := ledger.SignTransaction(tx.Cbor(), key)
+	if err != nil {
+		t.Fatalf("failed to sign transaction: %s", err)
+	}
+	// Signing again with the same key does not add another witness
+	signedTxCbor, err = ledger.SignTransaction(signedTxCbor, key)
+	if err != nil {
+		t.Fatalf("failed to sign transaction: %s", err)
+	}
+	signedTx, err := ledger.NewBabbageTransactionFromCbor(signedTxCbor)
+	if err != nil {
+		t.Fatalf("failed to decode transaction: %s", err)
+	}
+	if !bytes.Equal(signedTx.Body.Cbor(), tx.Body.Cbor()) {
+		t.Fatalf("transaction body changed when signing")
+	}
+	var origItems, signedItems []cbor.RawMessage
+	if _, err := cbor.Decode(tx.Cbor(), &origItems); err != nil {
+		t.Fatalf("failed to decode transaction: %s", err)
+	}
+	if _, err := cbor.Decode(signedTxCbor, &signedItems); err != nil {
+		t.Fatalf("failed to decode transaction: %s", err)
+	}
+	if !bytes.Equal(origItems[3], signedItems[3]) {
+		t.Fatalf("transaction metadata changed when signing")
+	}
+	if len(signedTx.WitnessSet.VkeyWitnesses) != 1 {
+		t.Fatalf("expected 1 witness, got %d", len(signedTx.WitnessSet.VkeyWitnesses))
+	}
+	bodyHash, _ := hex.DecodeString(tx.Body.Hash())
+	signedWitness := signedTx.WitnessSet.VkeyWitnesses[0]
+	if !signedWitness.Verify(bodyHash) {
+		t.Fatalf("witness signature is not valid")
+	}
+	if signedWitness.KeyHash() != addr.PaymentCredential().Hash {
+		t.Fatalf("witness key hash does not match address")
+	}
+	// Signing the body directly produces the same witness
+	bodyWitness, err := ledger.SignTransactionBody(&tx.Body, key)
+	if err != nil {
+		t.Fatalf("failed to sign transaction body: %s", err)
+	}
+	if !bytes.Equal(bodyWitness.Signature, signedWitness.Signature) {
+		t.Fatalf("did not get expected signature from transaction body")
+	}
+}
+
+func TestAddVkeyWitnessesSetTag(t *testing.T) {
+	// A transaction with an empty body and a single witness encoded as a set
+	txCbor, _ := hex.DecodeString("84a0a100d9010281825820" + testSigningKeyPubHex + "5840" + testSigningKeyEmptySig + "f5f6")
+	seed := bytes.Repeat([]byte{0x02}, 32)
+	key, err := ledger.NewEd25519SigningKey(seed)
+	if err != nil {
+		t.Fatalf("failed to create signing key: %s", err)
+	}
+	signedTxCbor, err := ledger.SignTransaction(txCbor, key)
+	if err != nil {
+		t.Fatalf("failed to sign transaction: %s", err)
+	}
+	// Map with one key, followed by key 0 and tag 258
+	if !bytes.HasPrefix(signedTxCbor, []byte{0x84, 0xa0, 0xa1, 0x00, 0xd9, 0x01, 0x02, 0x82}) {
+		t.Fatalf("witnesses were not encoded as a set: %x", signedTxCbor)
+	}
+}
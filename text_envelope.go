@@ -0,0 +1,361 @@
+package ledger
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/cloudstruct/go-cardano-ledger/cbor"
+)
+
+const (
+	TEXT_ENVELOPE_TYPE_PAYMENT_SIGNING_KEY               = "PaymentSigningKeyShelley_ed25519"
+	TEXT_ENVELOPE_TYPE_PAYMENT_VERIFICATION_KEY          = "PaymentVerificationKeyShelley_ed25519"
+	TEXT_ENVELOPE_TYPE_PAYMENT_EXTENDED_SIGNING_KEY      = "PaymentExtendedSigningKeyShelley_ed25519_bip32"
+	TEXT_ENVELOPE_TYPE_PAYMENT_EXTENDED_VERIFICATION_KEY = "PaymentExtendedVerificationKeyShelley_ed25519_bip32"
+	TEXT_ENVELOPE_TYPE_STAKE_SIGNING_KEY                 = "StakeSigningKeyShelley_ed25519"
+	TEXT_ENVELOPE_TYPE_STAKE_VERIFICATION_KEY            = "StakeVerificationKeyShelley_ed25519"
+	TEXT_ENVELOPE_TYPE_STAKE_EXTENDED_SIGNING_KEY        = "StakeExtendedSigningKeyShelley_ed25519_bip32"
+	TEXT_ENVELOPE_TYPE_STAKE_EXTENDED_VERIFICATION_KEY   = "StakeExtendedVerificationKeyShelley_ed25519_bip32"
+	TEXT_ENVELOPE_TYPE_DREP_SIGNING_KEY                  = "DRepSigningKey_ed25519"
+	TEXT_ENVELOPE_TYPE_DREP_VERIFICATION_KEY             = "DRepVerificationKey_ed25519"
+	TEXT_ENVELOPE_TYPE_DREP_EXTENDED_SIGNING_KEY         = "DRepExtendedSigningKey_ed25519_bip32"
+	TEXT_ENVELOPE_TYPE_DREP_EXTENDED_VERIFICATION_KEY    = "DRepExtendedVerificationKey_ed25519_bip32"
+
+	// Transaction types are followed by the era name, such as "Tx BabbageEra" or "TxBodyBabbage"
+	TEXT_ENVELOPE_TYPE_PREFIX_TX             = "Tx "
+	TEXT_ENVELOPE_TYPE_PREFIX_WITNESSED_TX   = "Witnessed Tx "
+	TEXT_ENVELOPE_TYPE_PREFIX_UNWITNESSED_TX = "Unwitnessed Tx "
+	TEXT_ENVELOPE_TYPE_PREFIX_TX_BODY        = "TxBody"
+	TEXT_ENVELOPE_TYPE_PREFIX_TX_UNSIGNED    = "TxUnsigned"
+	TEXT_ENVELOPE_TYPE_PREFIX_TX_WITNESS     = "TxWitness "
+
+	TEXT_ENVELOPE_DESCRIPTION_TX = "Ledger Cddl Format"
+
+	// Key witnesses in witness files are prefixed with this type, with bootstrap witnesses using 1
+	textEnvelopeWitnessTypeKey = 0
+)
+
+type textEnvelopeKeyType struct {
+	signing     bool
+	extended    bool
+	description string
+}
+
+var textEnvelopeKeyTypes = map[string]textEnvelopeKeyType{
+	TEXT_ENVELOPE_TYPE_PAYMENT_SIGNING_KEY:               {signing: true, description: "Payment Signing Key"},
+	TEXT_ENVELOPE_TYPE_PAYMENT_VERIFICATION_KEY:          {description: "Payment Verification Key"},
+	TEXT_ENVELOPE_TYPE_PAYMENT_EXTENDED_SIGNING_KEY:      {signing: true, extended: true, description: "Payment Signing Key"},
+	TEXT_ENVELOPE_TYPE_PAYMENT_EXTENDED_VERIFICATION_KEY: {extended: true, description: "Payment Verification Key"},
+	TEXT_ENVELOPE_TYPE_STAKE_SIGNING_KEY:                 {signing: true, description: "Stake Signing Key"},
+	TEXT_ENVELOPE_TYPE_STAKE_VERIFICATION_KEY:            {description: "Stake Verification Key"},
+	TEXT_ENVELOPE_TYPE_STAKE_EXTENDED_SIGNING_KEY:        {signing: true, extended: true, description: "Stake Signing Key"},
+	TEXT_ENVELOPE_TYPE_STAKE_EXTENDED_VERIFICATION_KEY:   {extended: true, description: "Stake Verification Key"},
+	TEXT_ENVELOPE_TYPE_DREP_SIGNING_KEY:                  {signing: true, description: "Delegate Representative Signing Key"},
+	TEXT_ENVELOPE_TYPE_DREP_VERIFICATION_KEY:             {description: "Delegate Representative Verification Key"},
+	TEXT_ENVELOPE_TYPE_DREP_EXTENDED_SIGNING_KEY:         {signing: true, extended: true, description: "Delegate Representative Signing Key"},
+	TEXT_ENVELOPE_TYPE_DREP_EXTENDED_VERIFICATION_KEY:    {extended: true, description: "Delegate Representative Verification Key"},
+}
+
+// TextEnvelope is the JSON format used by cardano-cli for key, transaction and witness files
+type TextEnvelope struct {
+	Type        string `json:"type"`
+	Description string `json:"description"`
+	CborHex     string `json:"cborHex"`
+}
+
+// NewTextEnvelope returns a TextEnvelope with the specified type, description and CBOR payload
+func NewTextEnvelope(envelopeType string, description string, cborData []byte) *TextEnvelope {
+	return &TextEnvelope{
+		Type:        envelopeType,
+		Description: description,
+		CborHex:     hex.EncodeToString(cborData),
+	}
+}
+
+// NewTextEnvelopeFromJson decodes a TextEnvelope from JSON
+func NewTextEnvelopeFromJson(data []byte) (*TextEnvelope, error) {
+	var ret TextEnvelope
+	if err := json.Unmarshal(data, &ret); err != nil {
+		return nil, fmt.Errorf("decode error: %s", err)
+	}
+	if ret.Type == "" {
+		return nil, fmt.Errorf("text envelope does not have a type")
+	}
+	return &ret, nil
+}
+
+// ReadTextEnvelopeFile reads a TextEnvelope from a file
+func ReadTextEnvelopeFile(path string) (*TextEnvelope, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return NewTextEnvelopeFromJson(data)
+}
+
+// NewSigningKeyTextEnvelope returns a TextEnvelope for a signing key. The envelope type must be one
+// of the signing key types, and must be an extended key type for an ExtendedSigningKey
+func NewSigningKeyTextEnvelope(envelopeType string, key SigningKey) (*TextEnvelope, error) {
+	keyType, ok := textEnvelopeKeyTypes[envelopeType]
+	if !ok || !keyType.signing {
+		return nil, fmt.Errorf("not a signing key type: %s", envelopeType)
+	}
+	var keyBytes []byte
+	switch k := key.(type) {
+	case *Ed25519SigningKey:
+		if keyType.extended {
+			return nil, fmt.Errorf("cannot use Ed25519 key with type %s", envelopeType)
+		}
+		keyBytes = k.key.Seed()
+	case *ExtendedSigningKey:
+		if !keyType.extended {
+			return nil, fmt.Errorf("cannot use extended key with type %s", envelopeType)
+		}
+		if k.chainCode == nil {
+			return nil, fmt.Errorf("key does not have a chain code")
+		}
+		keyBytes = append(keyBytes, k.key...)
+		keyBytes = append(keyBytes, k.publicKey...)
+		keyBytes = append(keyBytes, k.chainCode...)
+	default:
+		return nil, fmt.Errorf("unsupported signing key type: %T", key)
+	}
+	cborData, err := cbor.Encode(keyBytes)
+	if err != nil {
+		return nil, err
+	}
+	return NewTextEnvelope(envelopeType, keyType.description, cborData), nil
+}
+
+// NewVerificationKeyTextEnvelope returns a TextEnvelope for the verification key of a signing key.
+// Extended verification keys include the chain code, which requires an ExtendedSigningKey
+func NewVerificationKeyTextEnvelope(envelopeType string, key SigningKey) (*TextEnvelope, error) {
+	keyType, ok := textEnvelopeKeyTypes[envelopeType]
+	if !ok || keyType.signing {
+		return nil, fmt.Errorf("not a verification key type: %s", envelopeType)
+	}
+	keyBytes := key.PublicKey()
+	if keyType.extended {
+		extendedKey, ok := key.(*ExtendedSigningKey)
+		if !ok || extendedKey.chainCode == nil {
+			return nil, fmt.Errorf("type %s requires an extended key with a chain code", envelopeType)
+		}
+		keyBytes = append(append([]byte{}, keyBytes...), extendedKey.chainCode...)
+	}
+	cborData, err := cbor.Encode(keyBytes)
+	if err != nil {
+		return nil, err
+	}
+	return NewTextEnvelope(envelopeType, keyType.description, cborData), nil
+}
+
+// NewTransactionTextEnvelope returns a TextEnvelope for a transaction in the specified era, such as
+// "Babbage"
+func NewTransactionTextEnvelope(eraName string, txCbor []byte) (*TextEnvelope, error) {
+	if GetEraByName(eraName) == nil {
+		return nil, fmt.Errorf("unknown era: %s", eraName)
+	}
+	return NewTextEnvelope(TEXT_ENVELOPE_TYPE_PREFIX_TX+eraName+"Era", TEXT_ENVELOPE_DESCRIPTION_TX, txCbor), nil
+}
+
+// NewTransactionBodyTextEnvelope returns a TextEnvelope for a transaction body in the specified era.
+// Like cardano-cli, the CBOR may be either the transaction body or the full unsigned transaction
+func NewTransactionBodyTextEnvelope(eraName string, cborData []byte) (*TextEnvelope, error) {
+	if GetEraByName(eraName) == nil {
+		return nil, fmt.Errorf("unknown era: %s", eraName)
+	}
+	return NewTextEnvelope(TEXT_ENVELOPE_TYPE_PREFIX_TX_BODY+eraName, TEXT_ENVELOPE_DESCRIPTION_TX, cborData), nil
+}
+
+// NewWitnessTextEnvelope returns a TextEnvelope for a key witness in the specified era, as produced
+// by "cardano-cli transaction witness"
+func NewWitnessTextEnvelope(eraName string, witness VkeyWitness) (*TextEnvelope, error) {
+	if GetEraByName(eraName) == nil {
+		return nil, fmt.Errorf("unknown era: %s", eraName)
+	}
+	cborData, err := cbor.Encode([]interface{}{textEnvelopeWitnessTypeKey, witness})
+	if err != nil {
+		return nil, err
+	}
+	return NewTextEnvelope(TEXT_ENVELOPE_TYPE_PREFIX_TX_WITNESS+eraName+"Era", "", cborData), nil
+}
+
+// Cbor returns the decoded CBOR payload
+func (e *TextEnvelope) Cbor() ([]byte, error) {
+	ret, err := hex.DecodeString(e.CborHex)
+	if err != nil {
+		return nil, fmt.Errorf("invalid CBOR hex: %s", err)
+	}
+	return ret, nil
+}
+
+// MarshalJSON encodes the TextEnvelope using the same layout as cardano-cli
+func (e *TextEnvelope) MarshalJSON() ([]byte, error) {
+	// Use a type without the MarshalJSON function to avoid recursion
+	type tmpTextEnvelope TextEnvelope
+	return json.MarshalIndent((*tmpTextEnvelope)(e), "", "    ")
+}
+
+// WriteFile writes the TextEnvelope to a file. Files for signing keys are only readable by the
+// owner
+func (e *TextEnvelope) WriteFile(path string) error {
+	data, err := e.MarshalJSON()
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+	var perm os.FileMode = 0644
+	if keyType, ok := textEnvelopeKeyTypes[e.Type]; ok && keyType.signing {
+		perm = 0600
+	}
+	return os.WriteFile(path, data, perm)
+}
+
+// SigningKey returns the signing key from a signing key TextEnvelope
+func (e *TextEnvelope) SigningKey() (SigningKey, error) {
+	keyType, ok := textEnvelopeKeyTypes[e.Type]
+	if !ok || !keyType.signing {
+		return nil, fmt.Errorf("not a signing key type: %s", e.Type)
+	}
+	keyBytes, err := e.keyBytes()
+	if err != nil {
+		return nil, err
+	}
+	if keyType.extended {
+		return NewExtendedSigningKey(keyBytes)
+	}
+	return NewEd25519SigningKey(keyBytes)
+}
+
+// VerificationKey returns the verification key from a signing or verification key TextEnvelope.
+// The chain code is not included for extended keys
+func (e *TextEnvelope) VerificationKey() ([]byte, error) {
+	keyType, ok := textEnvelopeKeyTypes[e.Type]
+	if !ok {
+		return nil, fmt.Errorf("not a key type: %s", e.Type)
+	}
+	if keyType.signing {
+		key, err := e.SigningKey()
+		if err != nil {
+			return nil, err
+		}
+		return key.PublicKey(), nil
+	}
+	keyBytes, err := e.keyBytes()
+	if err != nil {
+		return nil, err
+	}
+	expectedSize := 32
+	if keyType.extended {
+		expectedSize += ED25519_CHAIN_CODE_SIZE
+	}
+	if len(keyBytes) != expectedSize {
+		return nil, fmt.Errorf("invalid key length: %d", len(keyBytes))
+	}
+	return keyBytes[:32], nil
+}
+
+// Transaction decodes the transaction from a transaction TextEnvelope. This uses the era from the
+// envelope type with NewTransactionFromCbor
+func (e *TextEnvelope) Transaction() (interface{}, error) {
+	era, err := e.transactionEra()
+	if err != nil {
+		return nil, err
+	}
+	cborData, err := e.Cbor()
+	if err != nil {
+		return nil, err
+	}
+	return NewTransactionFromCbor(uint(era.Id), cborData)
+}
+
+// TransactionBody decodes the transaction body from a transaction or transaction body TextEnvelope.
+// This uses the era from the envelope type with NewTransactionBodyFromCbor
+func (e *TextEnvelope) TransactionBody() (interface{}, error) {
+	era, err := e.transactionEra()
+	if err != nil {
+		return nil, err
+	}
+	cborData, err := e.Cbor()
+	if err != nil {
+		return nil, err
+	}
+	// Extract the body from a full transaction
+	if len(cborData) > 0 && cborData[0]&cbor.CBOR_TYPE_MASK == cbor.CBOR_TYPE_ARRAY {
+		var txItems []cbor.RawMessage
+		if _, err := cbor.Decode(cborData, &txItems); err != nil {
+			return nil, fmt.Errorf("decode error: %s", err)
+		}
+		if len(txItems) == 0 {
+			return nil, fmt.Errorf("invalid transaction")
+		}
+		cborData = txItems[0]
+	}
+	return NewTransactionBodyFromCbor(uint(era.Id), cborData)
+}
+
+// Witness decodes the key witness from a witness TextEnvelope
+func (e *TextEnvelope) Witness() (VkeyWitness, error) {
+	if !strings.HasPrefix(e.Type, TEXT_ENVELOPE_TYPE_PREFIX_TX_WITNESS) {
+		return VkeyWitness{}, fmt.Errorf("not a witness type: %s", e.Type)
+	}
+	cborData, err := e.Cbor()
+	if err != nil {
+		return VkeyWitness{}, err
+	}
+	var tmpWitness struct {
+		cbor.StructAsArray
+		Type    uint
+		Witness cbor.RawMessage
+	}
+	if _, err := cbor.Decode(cborData, &tmpWitness); err != nil {
+		return VkeyWitness{}, fmt.Errorf("decode error: %s", err)
+	}
+	if tmpWitness.Type != textEnvelopeWitnessTypeKey {
+		return VkeyWitness{}, fmt.Errorf("unsupported witness type: %d", tmpWitness.Type)
+	}
+	var ret VkeyWitness
+	if _, err := cbor.Decode(tmpWitness.Witness, &ret); err != nil {
+		return VkeyWitness{}, fmt.Errorf("decode error: %s", err)
+	}
+	return ret, nil
+}
+
+// Helper function to decode the key bytes from the CBOR payload
+func (e *TextEnvelope) keyBytes() ([]byte, error) {
+	cborData, err := e.Cbor()
+	if err != nil {
+		return nil, err
+	}
+	var ret []byte
+	if _, err := cbor.Decode(cborData, &ret); err != nil {
+		return nil, fmt.Errorf("decode error: %s", err)
+	}
+	return ret, nil
+}
+
+// Helper function to determine the era from a transaction or transaction body envelope type
+func (e *TextEnvelope) transactionEra() (*Era, error) {
+	var eraName string
+	for _, prefix := range []string{TEXT_ENVELOPE_TYPE_PREFIX_TX, TEXT_ENVELOPE_TYPE_PREFIX_WITNESSED_TX, TEXT_ENVELOPE_TYPE_PREFIX_UNWITNESSED_TX} {
+		if strings.HasPrefix(e.Type, prefix) && strings.HasSuffix(e.Type, "Era") {
+			eraName = strings.TrimSuffix(strings.TrimPrefix(e.Type, prefix), "Era")
+		}
+	}
+	for _, prefix := range []string{TEXT_ENVELOPE_TYPE_PREFIX_TX_BODY, TEXT_ENVELOPE_TYPE_PREFIX_TX_UNSIGNED} {
+		if strings.HasPrefix(e.Type, prefix) {
+			eraName = strings.TrimPrefix(e.Type, prefix)
+		}
+	}
+	if eraName == "" {
+		return nil, fmt.Errorf("not a transaction type: %s", e.Type)
+	}
+	era := GetEraByName(eraName)
+	if era == nil || era.Id == ERA_ID_BYRON {
+		return nil, fmt.Errorf("unsupported era for type: %s", e.Type)
+	}
+	return era, nil
+}
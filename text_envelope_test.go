@@ -0,0 +1,190 @@
+package ledger_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/cloudstruct/go-cardano-ledger"
+)
+
+func TestTextEnvelopeSigningKey(t *testing.T) {
+	envelopeJson := `{
+    "type": "PaymentSigningKeyShelley_ed25519",
+    "description": "Payment Signing Key",
+    "cborHex": "5820` + testSigningKeySeedHex + `"
+}`
+	envelope, err := ledger.NewTextEnvelopeFromJson([]byte(envelopeJson))
+	if err != nil {
+		t.Fatalf("failed to decode text envelope: %s", err)
+	}
+	key, err := envelope.SigningKey()
+	if err != nil {
+		t.Fatalf("failed to decode signing key: %s", err)
+	}
+	if pubKey := hex.EncodeToString(key.PublicKey()); pubKey != testSigningKeyPubHex {
+		t.Fatalf("did not get expected public key: got %s, expected %s", pubKey, testSigningKeyPubHex)
+	}
+	// The envelope is encoded the same way as cardano-cli
+	tmpEnvelope, err := ledger.NewSigningKeyTextEnvelope(ledger.TEXT_ENVELOPE_TYPE_PAYMENT_SIGNING_KEY, key)
+	if err != nil {
+		t.Fatalf("failed to create text envelope: %s", err)
+	}
+	tmpJson, err := tmpEnvelope.MarshalJSON()
+	if err != nil {
+		t.Fatalf("failed to encode text envelope: %s", err)
+	}
+	if string(tmpJson) != envelopeJson {
+		t.Fatalf("text envelope did not round-trip: got %s, expected %s", tmpJson, envelopeJson)
+	}
+	// The verification key can be written and read
+	vkeyEnvelope, err := ledger.NewVerificationKeyTextEnvelope(ledger.TEXT_ENVELOPE_TYPE_PAYMENT_VERIFICATION_KEY, key)
+	if err != nil {
+		t.Fatalf("failed to create text envelope: %s", err)
+	}
+	if vkeyEnvelope.CborHex != "5820"+testSigningKeyPubHex {
+		t.Fatalf("did not get expected verification key CBOR: %s", vkeyEnvelope.CborHex)
+	}
+	vkey, err := vkeyEnvelope.VerificationKey()
+	if err != nil {
+		t.Fatalf("failed to decode verification key: %s", err)
+	}
+	if !bytes.Equal(vkey, key.PublicKey()) {
+		t.Fatalf("did not get expected verification key: %x", vkey)
+	}
+	// Extended key types can't be used with a normal key
+	if _, err := ledger.NewSigningKeyTextEnvelope(ledger.TEXT_ENVELOPE_TYPE_PAYMENT_EXTENDED_SIGNING_KEY, key); err == nil {
+		t.Fatalf("did not get expected error for extended key type")
+	}
+	if _, err := vkeyEnvelope.SigningKey(); err == nil {
+		t.Fatalf("did not get expected error for verification key type")
+	}
+}
+
+func TestTextEnvelopeExtendedKey(t *testing.T) {
+	wallet, err := ledger.NewCip1852WalletFromMnemonic(testMnemonic, "")
+	if err != nil {
+		t.Fatalf("failed to create wallet: %s", err)
+	}
+	stakeKey, err := wallet.StakeKey(0)
+	if err != nil {
+		t.Fatalf("failed to derive key: %s", err)
+	}
+	envelope, err := ledger.NewSigningKeyTextEnvelope(ledger.TEXT_ENVELOPE_TYPE_STAKE_EXTENDED_SIGNING_KEY, stakeKey)
+	if err != nil {
+		t.Fatalf("failed to create text envelope: %s", err)
+	}
+	// 128 bytes containing the private key, public key and chain code
+	if len(envelope.CborHex) != (2+128)*2 {
+		t.Fatalf("did not get expected CBOR length: %d", len(envelope.CborHex)/2)
+	}
+	path := filepath.Join(t.TempDir(), "stake.skey")
+	if err := envelope.WriteFile(path); err != nil {
+		t.Fatalf("failed to write text envelope: %s", err)
+	}
+	if stat, err := os.Stat(path); err != nil || stat.Mode().Perm() != 0600 {
+		t.Fatalf("signing key file does not have expected permissions")
+	}
+	tmpEnvelope, err := ledger.ReadTextEnvelopeFile(path)
+	if err != nil {
+		t.Fatalf("failed to read text envelope: %s", err)
+	}
+	key, err := tmpEnvelope.SigningKey()
+	if err != nil {
+		t.Fatalf("failed to decode signing key: %s", err)
+	}
+	extendedKey, ok := key.(*ledger.ExtendedSigningKey)
+	if !ok {
+		t.Fatalf("did not get expected key type: %T", key)
+	}
+	if extendedKey.KeyHash() != stakeKey.KeyHash() || !bytes.Equal(extendedKey.ChainCode(), stakeKey.ChainCode()) {
+		t.Fatalf("signing key did not round-trip")
+	}
+	vkeyEnvelope, err := ledger.NewVerificationKeyTextEnvelope(ledger.TEXT_ENVELOPE_TYPE_STAKE_EXTENDED_VERIFICATION_KEY, stakeKey)
+	if err != nil {
+		t.Fatalf("failed to create text envelope: %s", err)
+	}
+	vkey, err := vkeyEnvelope.VerificationKey()
+	if err != nil {
+		t.Fatalf("failed to decode verification key: %s", err)
+	}
+	if !bytes.Equal(vkey, stakeKey.PublicKey()) {
+		t.Fatalf("did not get expected verification key: %x", vkey)
+	}
+}
+
+func TestTextEnvelopeTransaction(t *testing.T) {
+	key := testSigningKey(t)
+	keyHash := ledger.VkeyWitness{Vkey: key.PublicKey()}.KeyHash()
+	addr, err := ledger.NewAddressFromParts(ledger.ADDRESS_TYPE_KEY_NONE, ledger.ADDRESS_NETWORK_ID_MAINNET, keyHash[:], nil)
+	if err != nil {
+		t.Fatalf("failed to build address: %s", err)
+	}
+	tx, err := ledger.NewTxBuilder(testProtocolParams).
+		AddUtxos(testUtxo(addr, 0x01, 0, ledger.MaryValue{Coin: 10000000})).
+		AddOutputs(ledger.BabbageTransactionOutput{Address: addr, Amount: ledger.MaryValue{Coin: 5000000}}).
+		SetChangeAddress(addr).
+		Build()
+	if err != nil {
+		t.Fatalf("failed to build transaction: %s", err)
+	}
+	// The transaction body can be read from a transaction body file containing the full transaction
+	bodyEnvelope, err := ledger.NewTransactionBodyTextEnvelope("Babbage", tx.Cbor())
+	if err != nil {
+		t.Fatalf("failed to create text envelope: %s", err)
+	}
+	if bodyEnvelope.Type != "TxBodyBabbage" {
+		t.Fatalf("did not get expected type: %s", bodyEnvelope.Type)
+	}
+	tmpBody, err := bodyEnvelope.TransactionBody()
+	if err != nil {
+		t.Fatalf("failed to decode transaction body: %s", err)
+	}
+	body, ok := tmpBody.(*ledger.BabbageTransactionBody)
+	if !ok {
+		t.Fatalf("did not get expected transaction body type: %T", tmpBody)
+	}
+	if body.Hash() != tx.Body.Hash() {
+		t.Fatalf("did not get expected transaction body hash: %s", body.Hash())
+	}
+	// A witness file can be created from the body and added to the transaction
+	witness, err := ledger.SignTransactionBody(body, key)
+	if err != nil {
+		t.Fatalf("failed to sign transaction body: %s", err)
+	}
+	witnessEnvelope, err := ledger.NewWitnessTextEnvelope("Babbage", witness)
+	if err != nil {
+		t.Fatalf("failed to create text envelope: %s", err)
+	}
+	if witnessEnvelope.Type != "TxWitness BabbageEra" {
+		t.Fatalf("did not get expected type: %s", witnessEnvelope.Type)
+	}
+	tmpWitness, err := witnessEnvelope.Witness()
+	if err != nil {
+		t.Fatalf("failed to decode witness: %s", err)
+	}
+	signedTxCbor, err := ledger.AddVkeyWitnesses(tx.Cbor(), tmpWitness)
+	if err != nil {
+		t.Fatalf("failed to add witness: %s", err)
+	}
+	// The cardano-cli witnessed transaction type is also supported
+	for _, txType := range []string{"Tx BabbageEra", "Witnessed Tx BabbageEra"} {
+		txEnvelope := ledger.NewTextEnvelope(txType, ledger.TEXT_ENVELOPE_DESCRIPTION_TX, signedTxCbor)
+		tmpTx, err := txEnvelope.Transaction()
+		if err != nil {
+			t.Fatalf("%s: failed to decode transaction: %s", txType, err)
+		}
+		signedTx, ok := tmpTx.(*ledger.BabbageTransaction)
+		if !ok {
+			t.Fatalf("%s: did not get expected transaction type: %T", txType, tmpTx)
+		}
+		if len(signedTx.WitnessSet.VkeyWitnesses) != 1 || !bytes.Equal(signedTx.WitnessSet.VkeyWitnesses[0].Signature, witness.Signature) {
+			t.Fatalf("%s: transaction does not contain expected witness", txType)
+		}
+	}
+	if _, err := ledger.NewTextEnvelope("TxBodyUnknown", "", tx.Cbor()).TransactionBody(); err == nil {
+		t.Fatalf("did not get expected error for unknown era")
+	}
+}
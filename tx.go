@@ -27,6 +27,8 @@ func NewTransactionFromCbor(txType uint, data []byte) (interface{}, error) {
 		return NewAlonzoTransactionFromCbor(data)
 	case TX_TYPE_BABBAGE:
 		return NewBabbageTransactionFromCbor(data)
+	case TX_TYPE_CONWAY:
+		return NewConwayTransactionFromCbor(data)
 	}
 	return nil, fmt.Errorf("unknown transaction type: %d", txType)
 }
@@ -45,6 +47,8 @@ func NewTransactionBodyFromCbor(txType uint, data []byte) (interface{}, error) {
 		return NewAlonzoTransactionBodyFromCbor(data)
 	case TX_TYPE_BABBAGE:
 		return NewBabbageTransactionBodyFromCbor(data)
+	case TX_TYPE_CONWAY:
+		return NewConwayTransactionBodyFromCbor(data)
 	}
 	return nil, fmt.Errorf("unknown transaction type: %d", txType)
 }
@@ -0,0 +1,242 @@
+package ledger
+
+import (
+	"encoding/hex"
+	"fmt"
+
+	"github.com/cloudstruct/go-cardano-ledger/cbor"
+)
+
+// UTxOSet tracks the unspent transaction outputs as blocks are applied, keeping them in a
+// UTxOStore. A limited number of the most recently applied blocks can be rolled back
+type UTxOSet struct {
+	store       UTxOStore
+	history     []utxoSetBlockChanges
+	maxRollback int
+}
+
+// The changes made to a UTxOSet by applying a block, which are used to roll it back
+type utxoSetBlockChanges struct {
+	spent   []UnspentOutput
+	created []ShelleyTransactionInput
+}
+
+// NewUTxOSet returns an empty UTxOSet kept in memory that can roll back up to the specified number
+// of blocks
+func NewUTxOSet(maxRollback int) *UTxOSet {
+	return NewUTxOSetWithStore(NewMemoryUTxOStore(), maxRollback)
+}
+
+// NewUTxOSetWithStore returns a UTxOSet using the specified store that can roll back up to the
+// specified number of blocks. The store may already contain UTxOs
+func NewUTxOSetWithStore(store UTxOStore, maxRollback int) *UTxOSet {
+	return &UTxOSet{
+		store:       store,
+		maxRollback: maxRollback,
+	}
+}
+
+// Store returns the underlying UTxOStore, which can be used to look up UTxOs by address
+func (s *UTxOSet) Store() UTxOStore {
+	return s.store
+}
+
+// Add adds UTxOs to the set without applying a block, such as the outputs from the genesis
+// configuration. These changes cannot be rolled back
+func (s *UTxOSet) Add(utxos ...UnspentOutput) error {
+	return s.store.Put(utxos...)
+}
+
+// Get returns the output for the specified input, if it is unspent
+func (s *UTxOSet) Get(input ShelleyTransactionInput) (BabbageTransactionOutput, bool, error) {
+	return s.store.Get(input)
+}
+
+// Len returns the number of UTxOs in the set
+func (s *UTxOSet) Len() (int, error) {
+	return s.store.Len()
+}
+
+// UTxOs returns all of the UTxOs in the set, sorted by transaction ID and output index
+func (s *UTxOSet) UTxOs() ([]UnspentOutput, error) {
+	var ret []UnspentOutput
+	err := s.store.ForEach(func(utxo UnspentOutput) error {
+		ret = append(ret, utxo)
+		return nil
+	})
+	return ret, err
+}
+
+// ApplyBlock spends the inputs and adds the outputs of each transaction in the block. For
+// transactions that failed phase-2 validation, the collateral is spent and the collateral return
+// output is added instead. The set is left unchanged if any input spent by the block is not found.
+// The changes are written to the store in a single update
+func (s *UTxOSet) ApplyBlock(block Block) error {
+	invalidTxs := map[int]bool{}
+	var invalidTxIdxs []uint
+	switch b := block.(type) {
+	case *AlonzoBlock:
+		invalidTxIdxs = b.InvalidTransactions
+	case *BabbageBlock:
+		invalidTxIdxs = b.InvalidTransactions
+	case *ConwayBlock:
+		invalidTxIdxs = b.InvalidTransactions
+	}
+	for _, idx := range invalidTxIdxs {
+		invalidTxs[int(idx)] = true
+	}
+	// The changes are collected before updating the store. Outputs that are both created and spent
+	// within the block never reach the store
+	var changes utxoSetBlockChanges
+	spentInputs := map[ShelleyTransactionInput]bool{}
+	created := map[ShelleyTransactionInput]BabbageTransactionOutput{}
+	var createdOrder []ShelleyTransactionInput
+	for idx, tx := range block.Transactions() {
+		txSpent, txCreated, err := transactionUtxoChanges(tx, !invalidTxs[idx])
+		if err != nil {
+			return err
+		}
+		for _, input := range txSpent {
+			if _, ok := created[input]; ok {
+				delete(created, input)
+				continue
+			}
+			output, ok, err := s.store.Get(input)
+			if err != nil {
+				return err
+			}
+			if !ok || spentInputs[input] {
+				return fmt.Errorf("input not found in UTxO set: %s#%d", input.Id.String(), input.Index)
+			}
+			spentInputs[input] = true
+			changes.spent = append(changes.spent, UnspentOutput{Input: input, Output: output})
+		}
+		for _, utxo := range txCreated {
+			created[utxo.Input] = utxo.Output
+			createdOrder = append(createdOrder, utxo.Input)
+		}
+	}
+	createdUtxos := make([]UnspentOutput, 0, len(created))
+	for _, input := range createdOrder {
+		if output, ok := created[input]; ok {
+			createdUtxos = append(createdUtxos, UnspentOutput{Input: input, Output: output})
+			changes.created = append(changes.created, input)
+		}
+	}
+	spent := make([]ShelleyTransactionInput, 0, len(changes.spent))
+	for _, utxo := range changes.spent {
+		spent = append(spent, utxo.Input)
+	}
+	if err := s.store.Apply(spent, createdUtxos); err != nil {
+		return err
+	}
+	if s.maxRollback <= 0 {
+		return nil
+	}
+	s.history = append(s.history, changes)
+	if len(s.history) > s.maxRollback {
+		s.history = s.history[len(s.history)-s.maxRollback:]
+	}
+	return nil
+}
+
+// Rollback undoes the changes from the specified number of the most recently applied blocks
+func (s *UTxOSet) Rollback(count int) error {
+	if count > len(s.history) {
+		return fmt.Errorf("cannot roll back %d blocks, only %d available", count, len(s.history))
+	}
+	for i := 0; i < count; i++ {
+		changes := s.history[len(s.history)-1]
+		if err := s.store.Apply(changes.created, changes.spent); err != nil {
+			return err
+		}
+		s.history = s.history[:len(s.history)-1]
+	}
+	return nil
+}
+
+// Helper function to return the inputs spent and the outputs created by a transaction
+func transactionUtxoChanges(tx TransactionBody, isValid bool) ([]ShelleyTransactionInput, []UnspentOutput, error) {
+	var txId Blake2b256
+	txIdBytes, err := hex.DecodeString(tx.Hash())
+	if err != nil {
+		return nil, nil, err
+	}
+	copy(txId[:], txIdBytes)
+	var inputs, collateral []ShelleyTransactionInput
+	var outputs []BabbageTransactionOutput
+	var collateralReturn *BabbageTransactionOutput
+	switch t := tx.(type) {
+	case *ByronTransactionBody:
+		for _, input := range t.Inputs {
+			inputs = append(inputs, ShelleyTransactionInput{Id: input.Id, Index: input.Index})
+		}
+		for _, output := range t.Outputs {
+			addr, err := NewAddressFromBytes(output.Address.Bytes())
+			if err != nil {
+				return nil, nil, err
+			}
+			outputs = append(outputs, BabbageTransactionOutput{Address: addr, Amount: MaryValue{Coin: output.Amount}})
+		}
+	case *ShelleyTransactionBody:
+		inputs = t.Inputs
+		outputs, err = decodeTransactionBodyOutputs(t.Cbor())
+	case *AllegraTransactionBody:
+		inputs = t.Inputs
+		outputs, err = decodeTransactionBodyOutputs(t.Cbor())
+	case *MaryTransactionBody:
+		inputs = t.Inputs
+		outputs, err = decodeTransactionBodyOutputs(t.Cbor())
+	case *AlonzoTransactionBody:
+		inputs, collateral = t.Inputs, t.Collateral
+		outputs, err = decodeTransactionBodyOutputs(t.Cbor())
+	case *BabbageTransactionBody:
+		inputs, collateral = t.Inputs, t.Collateral
+		outputs, collateralReturn = t.Outputs, t.CollateralReturn
+	case *ConwayTransactionBody:
+		inputs, collateral = t.Inputs, t.Collateral
+		outputs, collateralReturn = t.Outputs, t.CollateralReturn
+	default:
+		return nil, nil, fmt.Errorf("unsupported transaction body type: %T", tx)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	if !isValid {
+		// The collateral return output follows the regular outputs
+		var created []UnspentOutput
+		if collateralReturn != nil {
+			created = append(
+				created,
+				UnspentOutput{
+					Input:  ShelleyTransactionInput{Id: txId, Index: uint32(len(outputs))},
+					Output: *collateralReturn,
+				},
+			)
+		}
+		return collateral, created, nil
+	}
+	created := make([]UnspentOutput, 0, len(outputs))
+	for idx, output := range outputs {
+		created = append(
+			created,
+			UnspentOutput{
+				Input:  ShelleyTransactionInput{Id: txId, Index: uint32(idx)},
+				Output: output,
+			},
+		)
+	}
+	return inputs, created, nil
+}
+
+// Helper function to decode the outputs from the CBOR of a transaction body. This is used for
+// eras where the transaction body does not decode its outputs
+func decodeTransactionBodyOutputs(bodyCbor []byte) ([]BabbageTransactionOutput, error) {
+	var tmpData struct {
+		Outputs []BabbageTransactionOutput `cbor:"1,keyasint"`
+	}
+	if _, err := cbor.Decode(bodyCbor, &tmpData); err != nil {
+		return nil, fmt.Errorf("decode error: %s", err)
+	}
+	return tmpData.Outputs, nil
+}
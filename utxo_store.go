@@ -0,0 +1,251 @@
+package ledger
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/cloudstruct/go-cardano-ledger/cbor"
+)
+
+// UTxOStore stores unspent transaction outputs keyed by the input that references them. UTxOs are
+// iterated in order of transaction ID and output index. The iteration callbacks must not modify
+// the store, and returning an error from a callback stops the iteration and returns that error
+type UTxOStore interface {
+	// Get returns the output for the specified input, if it exists
+	Get(input ShelleyTransactionInput) (BabbageTransactionOutput, bool, error)
+	// Put adds UTxOs to the store, replacing any existing outputs for the same inputs
+	Put(utxos ...UnspentOutput) error
+	// Delete removes the UTxOs for the specified inputs. Inputs that do not exist are ignored
+	Delete(inputs ...ShelleyTransactionInput) error
+	// Apply removes the UTxOs for the specified inputs and then adds the specified UTxOs as a
+	// single update, so that either all or none of the changes are made
+	Apply(deletes []ShelleyTransactionInput, puts []UnspentOutput) error
+	// Len returns the number of UTxOs in the store
+	Len() (int, error)
+	// ForEach calls the function for each UTxO in the store
+	ForEach(fn func(UnspentOutput) error) error
+	// ForEachByAddress calls the function for each UTxO at the specified address
+	ForEachByAddress(addr Address, fn func(UnspentOutput) error) error
+	// ForEachByPaymentCredential calls the function for each UTxO at an address with the
+	// specified payment credential, regardless of its staking part
+	ForEachByPaymentCredential(cred Credential, fn func(UnspentOutput) error) error
+	// Snapshot writes the contents of the store to the writer
+	Snapshot(w io.Writer) error
+	// Restore replaces the contents of the store with a snapshot read from the reader
+	Restore(r io.Reader) error
+}
+
+// MemoryUTxOStore is a UTxOStore that keeps all UTxOs in memory
+type MemoryUTxOStore struct {
+	utxos            map[ShelleyTransactionInput]BabbageTransactionOutput
+	addressIndex     map[string]map[ShelleyTransactionInput]bool
+	paymentCredIndex map[Credential]map[ShelleyTransactionInput]bool
+}
+
+// NewMemoryUTxOStore returns an empty MemoryUTxOStore
+func NewMemoryUTxOStore() *MemoryUTxOStore {
+	s := &MemoryUTxOStore{}
+	s.clear()
+	return s
+}
+
+func (s *MemoryUTxOStore) Get(input ShelleyTransactionInput) (BabbageTransactionOutput, bool, error) {
+	output, ok := s.utxos[input]
+	return output, ok, nil
+}
+
+func (s *MemoryUTxOStore) Put(utxos ...UnspentOutput) error {
+	for _, utxo := range utxos {
+		s.delete(utxo.Input)
+		s.utxos[utxo.Input] = utxo.Output
+		addrKey := string(utxo.Output.Address.Bytes())
+		if s.addressIndex[addrKey] == nil {
+			s.addressIndex[addrKey] = map[ShelleyTransactionInput]bool{}
+		}
+		s.addressIndex[addrKey][utxo.Input] = true
+		if cred := utxo.Output.Address.PaymentCredential(); cred != nil {
+			if s.paymentCredIndex[*cred] == nil {
+				s.paymentCredIndex[*cred] = map[ShelleyTransactionInput]bool{}
+			}
+			s.paymentCredIndex[*cred][utxo.Input] = true
+		}
+	}
+	return nil
+}
+
+func (s *MemoryUTxOStore) Delete(inputs ...ShelleyTransactionInput) error {
+	for _, input := range inputs {
+		s.delete(input)
+	}
+	return nil
+}
+
+func (s *MemoryUTxOStore) Apply(deletes []ShelleyTransactionInput, puts []UnspentOutput) error {
+	if err := s.Delete(deletes...); err != nil {
+		return err
+	}
+	return s.Put(puts...)
+}
+
+func (s *MemoryUTxOStore) Len() (int, error) {
+	return len(s.utxos), nil
+}
+
+func (s *MemoryUTxOStore) ForEach(fn func(UnspentOutput) error) error {
+	inputs := make([]ShelleyTransactionInput, 0, len(s.utxos))
+	for input := range s.utxos {
+		inputs = append(inputs, input)
+	}
+	return s.forEachInput(inputs, fn)
+}
+
+func (s *MemoryUTxOStore) ForEachByAddress(addr Address, fn func(UnspentOutput) error) error {
+	var inputs []ShelleyTransactionInput
+	for input := range s.addressIndex[string(addr.Bytes())] {
+		inputs = append(inputs, input)
+	}
+	return s.forEachInput(inputs, fn)
+}
+
+func (s *MemoryUTxOStore) ForEachByPaymentCredential(cred Credential, fn func(UnspentOutput) error) error {
+	var inputs []ShelleyTransactionInput
+	for input := range s.paymentCredIndex[cred] {
+		inputs = append(inputs, input)
+	}
+	return s.forEachInput(inputs, fn)
+}
+
+func (s *MemoryUTxOStore) Snapshot(w io.Writer) error {
+	return writeUtxoSnapshot(w, s.ForEach)
+}
+
+func (s *MemoryUTxOStore) Restore(r io.Reader) error {
+	var utxos []UnspentOutput
+	err := readUtxoSnapshot(r, func(utxo UnspentOutput) error {
+		utxos = append(utxos, utxo)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	s.clear()
+	return s.Put(utxos...)
+}
+
+// Helper function to remove all UTxOs
+func (s *MemoryUTxOStore) clear() {
+	s.utxos = map[ShelleyTransactionInput]BabbageTransactionOutput{}
+	s.addressIndex = map[string]map[ShelleyTransactionInput]bool{}
+	s.paymentCredIndex = map[Credential]map[ShelleyTransactionInput]bool{}
+}
+
+// Helper function to remove a UTxO and its index entries
+func (s *MemoryUTxOStore) delete(input ShelleyTransactionInput) {
+	output, ok := s.utxos[input]
+	if !ok {
+		return
+	}
+	delete(s.utxos, input)
+	addrKey := string(output.Address.Bytes())
+	delete(s.addressIndex[addrKey], input)
+	if len(s.addressIndex[addrKey]) == 0 {
+		delete(s.addressIndex, addrKey)
+	}
+	if cred := output.Address.PaymentCredential(); cred != nil {
+		delete(s.paymentCredIndex[*cred], input)
+		if len(s.paymentCredIndex[*cred]) == 0 {
+			delete(s.paymentCredIndex, *cred)
+		}
+	}
+}
+
+// Helper function to call the function for each of the inputs in sorted order
+func (s *MemoryUTxOStore) forEachInput(inputs []ShelleyTransactionInput, fn func(UnspentOutput) error) error {
+	sort.Slice(inputs, func(i, j int) bool {
+		return bytes.Compare(utxoStoreInputKey(inputs[i]), utxoStoreInputKey(inputs[j])) < 0
+	})
+	for _, input := range inputs {
+		if err := fn(UnspentOutput{Input: input, Output: s.utxos[input]}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Helper function to return the key for an input, which sorts by transaction ID and output index
+func utxoStoreInputKey(input ShelleyTransactionInput) []byte {
+	ret := make([]byte, len(input.Id)+4)
+	copy(ret, input.Id[:])
+	binary.BigEndian.PutUint32(ret[len(input.Id):], input.Index)
+	return ret
+}
+
+// Helper function to return the input for a key
+func utxoStoreInputFromKey(key []byte) (ShelleyTransactionInput, error) {
+	var ret ShelleyTransactionInput
+	if len(key) != len(ret.Id)+4 {
+		return ret, fmt.Errorf("invalid UTxO key length: %d", len(key))
+	}
+	copy(ret.Id[:], key)
+	ret.Index = binary.BigEndian.Uint32(key[len(ret.Id):])
+	return ret, nil
+}
+
+// The format of a UTxO in a snapshot
+type utxoSnapshotEntry struct {
+	cbor.StructAsArray
+	Input  ShelleyTransactionInput
+	Output BabbageTransactionOutput
+}
+
+// Helper function to write a snapshot. A snapshot consists of a CBOR-encoded [input, output] entry
+// for each UTxO, each preceded by its length as a 32-bit big-endian integer
+func writeUtxoSnapshot(w io.Writer, forEach func(func(UnspentOutput) error) error) error {
+	bufWriter := bufio.NewWriter(w)
+	err := forEach(func(utxo UnspentOutput) error {
+		entryCbor, err := cbor.Encode(&utxoSnapshotEntry{Input: utxo.Input, Output: utxo.Output})
+		if err != nil {
+			return err
+		}
+		var lenBytes [4]byte
+		binary.BigEndian.PutUint32(lenBytes[:], uint32(len(entryCbor)))
+		if _, err := bufWriter.Write(lenBytes[:]); err != nil {
+			return err
+		}
+		_, err = bufWriter.Write(entryCbor)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	return bufWriter.Flush()
+}
+
+// Helper function to read a snapshot and call the function for each UTxO
+func readUtxoSnapshot(r io.Reader, fn func(UnspentOutput) error) error {
+	bufReader := bufio.NewReader(r)
+	for {
+		var lenBytes [4]byte
+		if _, err := io.ReadFull(bufReader, lenBytes[:]); err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return fmt.Errorf("invalid snapshot: %s", err)
+		}
+		entryCbor := make([]byte, binary.BigEndian.Uint32(lenBytes[:]))
+		if _, err := io.ReadFull(bufReader, entryCbor); err != nil {
+			return fmt.Errorf("invalid snapshot: %s", err)
+		}
+		var entry utxoSnapshotEntry
+		if _, err := cbor.Decode(entryCbor, &entry); err != nil {
+			return fmt.Errorf("invalid snapshot: %s", err)
+		}
+		if err := fn(UnspentOutput{Input: entry.Input, Output: entry.Output}); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,272 @@
+package ledger
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+
+	"github.com/cloudstruct/go-cardano-ledger/cbor"
+	bolt "go.etcd.io/bbolt"
+)
+
+const (
+	// Number of UTxOs written per database transaction when restoring a snapshot
+	boltUtxoStoreRestoreBatchSize = 10000
+)
+
+var (
+	boltUtxoStoreUtxoBucket              = []byte("utxo")
+	boltUtxoStoreAddressBucket           = []byte("address")
+	boltUtxoStorePaymentCredentialBucket = []byte("payment_credential")
+)
+
+// BoltUTxOStore is a UTxOStore that keeps UTxOs on disk in a bbolt database. Outputs are stored as
+// CBOR keyed by input, along with indexes by address and payment credential
+type BoltUTxOStore struct {
+	db *bolt.DB
+}
+
+// NewBoltUTxOStore opens the bbolt database at the specified path, creating it if it does not
+// exist, and returns a BoltUTxOStore using it
+func NewBoltUTxOStore(path string) (*BoltUTxOStore, error) {
+	db, err := bolt.Open(path, 0600, nil)
+	if err != nil {
+		return nil, err
+	}
+	s := &BoltUTxOStore{db: db}
+	err = db.Update(func(tx *bolt.Tx) error {
+		return s.createBuckets(tx)
+	})
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+// Close closes the underlying database
+func (s *BoltUTxOStore) Close() error {
+	return s.db.Close()
+}
+
+func (s *BoltUTxOStore) Get(input ShelleyTransactionInput) (BabbageTransactionOutput, bool, error) {
+	var ret BabbageTransactionOutput
+	var found bool
+	err := s.db.View(func(tx *bolt.Tx) error {
+		outputCbor := tx.Bucket(boltUtxoStoreUtxoBucket).Get(utxoStoreInputKey(input))
+		if outputCbor == nil {
+			return nil
+		}
+		found = true
+		var err error
+		ret, err = boltUtxoStoreDecodeOutput(outputCbor)
+		return err
+	})
+	return ret, found, err
+}
+
+func (s *BoltUTxOStore) Put(utxos ...UnspentOutput) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return s.put(tx, utxos)
+	})
+}
+
+func (s *BoltUTxOStore) Delete(inputs ...ShelleyTransactionInput) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		for _, input := range inputs {
+			if err := s.delete(tx, input); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (s *BoltUTxOStore) Apply(deletes []ShelleyTransactionInput, puts []UnspentOutput) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		for _, input := range deletes {
+			if err := s.delete(tx, input); err != nil {
+				return err
+			}
+		}
+		return s.put(tx, puts)
+	})
+}
+
+func (s *BoltUTxOStore) Len() (int, error) {
+	var ret int
+	err := s.db.View(func(tx *bolt.Tx) error {
+		ret = tx.Bucket(boltUtxoStoreUtxoBucket).Stats().KeyN
+		return nil
+	})
+	return ret, err
+}
+
+func (s *BoltUTxOStore) ForEach(fn func(UnspentOutput) error) error {
+	return s.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(boltUtxoStoreUtxoBucket).ForEach(func(k, v []byte) error {
+			input, err := utxoStoreInputFromKey(k)
+			if err != nil {
+				return err
+			}
+			output, err := boltUtxoStoreDecodeOutput(v)
+			if err != nil {
+				return err
+			}
+			return fn(UnspentOutput{Input: input, Output: output})
+		})
+	})
+}
+
+func (s *BoltUTxOStore) ForEachByAddress(addr Address, fn func(UnspentOutput) error) error {
+	return s.forEachIndexed(boltUtxoStoreAddressBucket, addr.Bytes(), fn)
+}
+
+func (s *BoltUTxOStore) ForEachByPaymentCredential(cred Credential, fn func(UnspentOutput) error) error {
+	return s.forEachIndexed(boltUtxoStorePaymentCredentialBucket, boltUtxoStoreCredentialKey(cred), fn)
+}
+
+func (s *BoltUTxOStore) Snapshot(w io.Writer) error {
+	return writeUtxoSnapshot(w, s.ForEach)
+}
+
+// Restore replaces the contents of the store with a snapshot. The snapshot is written in batches
+// to limit memory usage, so a failed restore can leave the store partially restored
+func (s *BoltUTxOStore) Restore(r io.Reader) error {
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		for _, bucket := range [][]byte{boltUtxoStoreUtxoBucket, boltUtxoStoreAddressBucket, boltUtxoStorePaymentCredentialBucket} {
+			if err := tx.DeleteBucket(bucket); err != nil {
+				return err
+			}
+		}
+		return s.createBuckets(tx)
+	})
+	if err != nil {
+		return err
+	}
+	batch := make([]UnspentOutput, 0, boltUtxoStoreRestoreBatchSize)
+	err = readUtxoSnapshot(r, func(utxo UnspentOutput) error {
+		batch = append(batch, utxo)
+		if len(batch) < boltUtxoStoreRestoreBatchSize {
+			return nil
+		}
+		if err := s.Put(batch...); err != nil {
+			return err
+		}
+		batch = batch[:0]
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return s.Put(batch...)
+}
+
+// Helper function to create the buckets used by the store
+func (s *BoltUTxOStore) createBuckets(tx *bolt.Tx) error {
+	for _, bucket := range [][]byte{boltUtxoStoreUtxoBucket, boltUtxoStoreAddressBucket, boltUtxoStorePaymentCredentialBucket} {
+		if _, err := tx.CreateBucketIfNotExists(bucket); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Helper function to add UTxOs and their index entries within a database transaction
+func (s *BoltUTxOStore) put(tx *bolt.Tx, utxos []UnspentOutput) error {
+	for _, utxo := range utxos {
+		if err := s.delete(tx, utxo.Input); err != nil {
+			return err
+		}
+		outputCbor, err := cbor.Encode(utxo.Output)
+		if err != nil {
+			return err
+		}
+		inputKey := utxoStoreInputKey(utxo.Input)
+		if err := tx.Bucket(boltUtxoStoreUtxoBucket).Put(inputKey, outputCbor); err != nil {
+			return err
+		}
+		for bucket, indexKey := range boltUtxoStoreIndexKeys(utxo.Output.Address, inputKey) {
+			if err := tx.Bucket([]byte(bucket)).Put(indexKey, []byte{}); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Helper function to remove a UTxO and its index entries within a database transaction
+func (s *BoltUTxOStore) delete(tx *bolt.Tx, input ShelleyTransactionInput) error {
+	inputKey := utxoStoreInputKey(input)
+	utxoBucket := tx.Bucket(boltUtxoStoreUtxoBucket)
+	outputCbor := utxoBucket.Get(inputKey)
+	if outputCbor == nil {
+		return nil
+	}
+	output, err := boltUtxoStoreDecodeOutput(outputCbor)
+	if err != nil {
+		return err
+	}
+	for bucket, indexKey := range boltUtxoStoreIndexKeys(output.Address, inputKey) {
+		if err := tx.Bucket([]byte(bucket)).Delete(indexKey); err != nil {
+			return err
+		}
+	}
+	return utxoBucket.Delete(inputKey)
+}
+
+// Helper function to call the function for each UTxO in an index with the specified prefix
+func (s *BoltUTxOStore) forEachIndexed(bucket []byte, prefix []byte, fn func(UnspentOutput) error) error {
+	return s.db.View(func(tx *bolt.Tx) error {
+		utxoBucket := tx.Bucket(boltUtxoStoreUtxoBucket)
+		cursor := tx.Bucket(bucket).Cursor()
+		for k, _ := cursor.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, _ = cursor.Next() {
+			// Skip longer keys that share the prefix, such as pointer addresses
+			inputKey := k[len(prefix):]
+			input, err := utxoStoreInputFromKey(inputKey)
+			if err != nil {
+				continue
+			}
+			outputCbor := utxoBucket.Get(inputKey)
+			if outputCbor == nil {
+				return fmt.Errorf("UTxO index refers to missing UTxO: %s#%d", input.Id.String(), input.Index)
+			}
+			output, err := boltUtxoStoreDecodeOutput(outputCbor)
+			if err != nil {
+				return err
+			}
+			if err := fn(UnspentOutput{Input: input, Output: output}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Helper function to return the index entries for a UTxO, keyed by bucket name
+func boltUtxoStoreIndexKeys(addr Address, inputKey []byte) map[string][]byte {
+	ret := map[string][]byte{
+		string(boltUtxoStoreAddressBucket): append(addr.Bytes(), inputKey...),
+	}
+	if cred := addr.PaymentCredential(); cred != nil {
+		ret[string(boltUtxoStorePaymentCredentialBucket)] = append(boltUtxoStoreCredentialKey(*cred), inputKey...)
+	}
+	return ret
+}
+
+// Helper function to return the index key prefix for a credential
+func boltUtxoStoreCredentialKey(cred Credential) []byte {
+	return append([]byte{byte(cred.Type)}, cred.Hash[:]...)
+}
+
+// Helper function to decode an output from the database. The data is copied, since it is only
+// valid for the life of the database transaction
+func boltUtxoStoreDecodeOutput(data []byte) (BabbageTransactionOutput, error) {
+	var ret BabbageTransactionOutput
+	tmpData := make([]byte, len(data))
+	copy(tmpData, data)
+	if _, err := cbor.Decode(tmpData, &ret); err != nil {
+		return ret, fmt.Errorf("decode error: %s", err)
+	}
+	return ret, nil
+}
@@ -0,0 +1,201 @@
+package ledger_test
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"github.com/cloudstruct/go-cardano-ledger"
+)
+
+// Helper function to return the inputs for the UTxOs passed to an iteration function
+func collectUtxoInputs(t *testing.T, forEach func(func(ledger.UnspentOutput) error) error) []ledger.ShelleyTransactionInput {
+	var ret []ledger.ShelleyTransactionInput
+	err := forEach(func(utxo ledger.UnspentOutput) error {
+		ret = append(ret, utxo.Input)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	return ret
+}
+
+// Helper function to check that a list of inputs matches the expected inputs, in order
+func checkUtxoInputs(t *testing.T, inputs []ledger.ShelleyTransactionInput, expected []ledger.ShelleyTransactionInput) {
+	if len(inputs) != len(expected) {
+		t.Fatalf("did not get expected number of UTxOs: got %d, expected %d", len(inputs), len(expected))
+	}
+	for idx, input := range inputs {
+		if input != expected[idx] {
+			t.Fatalf("did not get expected UTxO at index %d: got %s#%d, expected %s#%d", idx, input.Id.String(), input.Index, expected[idx].Id.String(), expected[idx].Index)
+		}
+	}
+}
+
+// Helper function to run the common tests for a UTxOStore implementation. The second store is
+// used to check that snapshots can be restored into an empty store
+func testUtxoStore(t *testing.T, store ledger.UTxOStore, emptyStore ledger.UTxOStore) {
+	baseAddr := testAddress(t, "addr1qx2fxv2umyhttkxyxp8x0dlpdt3k6cwng5pxj3jhsydzer3jcu5d8ps7zex2k2xt3uqxgjqnnj83ws8lhrn648jjxtwqfjkjv7")
+	enterpriseAddr := testAddress(t, "addr1vx2fxv2umyhttkxyxp8x0dlpdt3k6cwng5pxj3jhsydzers66hrl8")
+	otherAddr, err := ledger.NewAddressFromParts(ledger.ADDRESS_TYPE_KEY_NONE, 1, bytes.Repeat([]byte{0x01}, 28), nil)
+	if err != nil {
+		t.Fatalf("failed to build address: %s", err)
+	}
+	utxos := []ledger.UnspentOutput{
+		testUtxo(baseAddr, 0x02, 1, ledger.MaryValue{Coin: 1000000}),
+		testUtxo(enterpriseAddr, 0x01, 0, ledger.MaryValue{Coin: 2000000}),
+		testUtxo(otherAddr, 0x01, 1, ledger.MaryValue{Coin: 3000000}),
+		testUtxo(baseAddr, 0x02, 0, ledger.MaryValue{Coin: 4000000}),
+	}
+	if err := store.Put(utxos...); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if count, err := store.Len(); err != nil || count != len(utxos) {
+		t.Fatalf("did not get expected number of UTxOs: got %d (%v), expected %d", count, err, len(utxos))
+	}
+	output, ok, err := store.Get(utxos[1].Input)
+	if err != nil || !ok {
+		t.Fatalf("did not find expected UTxO: %v", err)
+	}
+	if output.Amount.Coin != 2000000 || output.Address.String() != enterpriseAddr.String() {
+		t.Fatalf("did not get expected output: %s", output.String())
+	}
+	// UTxOs are iterated in order of transaction ID and output index
+	checkUtxoInputs(
+		t,
+		collectUtxoInputs(t, store.ForEach),
+		[]ledger.ShelleyTransactionInput{utxos[1].Input, utxos[2].Input, utxos[3].Input, utxos[0].Input},
+	)
+	checkUtxoInputs(
+		t,
+		collectUtxoInputs(t, func(fn func(ledger.UnspentOutput) error) error {
+			return store.ForEachByAddress(baseAddr, fn)
+		}),
+		[]ledger.ShelleyTransactionInput{utxos[3].Input, utxos[0].Input},
+	)
+	// The base and enterprise addresses share a payment credential
+	checkUtxoInputs(
+		t,
+		collectUtxoInputs(t, func(fn func(ledger.UnspentOutput) error) error {
+			return store.ForEachByPaymentCredential(*baseAddr.PaymentCredential(), fn)
+		}),
+		[]ledger.ShelleyTransactionInput{utxos[1].Input, utxos[3].Input, utxos[0].Input},
+	)
+	// Replacing an output updates the indexes
+	movedUtxo := testUtxo(otherAddr, 0x02, 0, ledger.MaryValue{Coin: 5000000})
+	if err := store.Put(movedUtxo); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	checkUtxoInputs(
+		t,
+		collectUtxoInputs(t, func(fn func(ledger.UnspentOutput) error) error {
+			return store.ForEachByAddress(baseAddr, fn)
+		}),
+		[]ledger.ShelleyTransactionInput{utxos[0].Input},
+	)
+	if err := store.Delete(utxos[0].Input, utxos[1].Input); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if _, ok, err := store.Get(utxos[0].Input); err != nil || ok {
+		t.Fatalf("found deleted UTxO: %v", err)
+	}
+	checkUtxoInputs(
+		t,
+		collectUtxoInputs(t, func(fn func(ledger.UnspentOutput) error) error {
+			return store.ForEachByPaymentCredential(*baseAddr.PaymentCredential(), fn)
+		}),
+		nil,
+	)
+	checkUtxoInputs(
+		t,
+		collectUtxoInputs(t, func(fn func(ledger.UnspentOutput) error) error {
+			return store.ForEachByAddress(otherAddr, fn)
+		}),
+		[]ledger.ShelleyTransactionInput{utxos[2].Input, movedUtxo.Input},
+	)
+	// A snapshot can be restored into an empty store
+	var snapshot bytes.Buffer
+	if err := store.Snapshot(&snapshot); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := emptyStore.Put(utxos[0]); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := emptyStore.Restore(bytes.NewReader(snapshot.Bytes())); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	checkUtxoInputs(
+		t,
+		collectUtxoInputs(t, emptyStore.ForEach),
+		[]ledger.ShelleyTransactionInput{utxos[2].Input, movedUtxo.Input},
+	)
+	checkUtxoInputs(
+		t,
+		collectUtxoInputs(t, func(fn func(ledger.UnspentOutput) error) error {
+			return emptyStore.ForEachByAddress(baseAddr, fn)
+		}),
+		nil,
+	)
+	if output, _, err := emptyStore.Get(movedUtxo.Input); err != nil || output.Amount.Coin != 5000000 {
+		t.Fatalf("did not get expected output after restore: %s (%v)", output.String(), err)
+	}
+	// Applying changes removes the deleted UTxOs before adding the new ones, so an input can be
+	// deleted and added again in the same update
+	replacedUtxo := testUtxo(enterpriseAddr, 0x01, 1, ledger.MaryValue{Coin: 6000000})
+	if err := store.Apply([]ledger.ShelleyTransactionInput{movedUtxo.Input, replacedUtxo.Input}, []ledger.UnspentOutput{utxos[0], replacedUtxo}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	checkUtxoInputs(
+		t,
+		collectUtxoInputs(t, store.ForEach),
+		[]ledger.ShelleyTransactionInput{replacedUtxo.Input, utxos[0].Input},
+	)
+	checkUtxoInputs(
+		t,
+		collectUtxoInputs(t, func(fn func(ledger.UnspentOutput) error) error {
+			return store.ForEachByAddress(otherAddr, fn)
+		}),
+		nil,
+	)
+	checkUtxoInputs(
+		t,
+		collectUtxoInputs(t, func(fn func(ledger.UnspentOutput) error) error {
+			return store.ForEachByPaymentCredential(*baseAddr.PaymentCredential(), fn)
+		}),
+		[]ledger.ShelleyTransactionInput{replacedUtxo.Input, utxos[0].Input},
+	)
+	if output, _, err := store.Get(replacedUtxo.Input); err != nil || output.Amount.Coin != 6000000 {
+		t.Fatalf("did not get expected output after applying changes: %s (%v)", output.String(), err)
+	}
+}
+
+func TestMemoryUTxOStore(t *testing.T) {
+	testUtxoStore(t, ledger.NewMemoryUTxOStore(), ledger.NewMemoryUTxOStore())
+}
+
+func TestBoltUTxOStore(t *testing.T) {
+	tmpDir := t.TempDir()
+	store, err := ledger.NewBoltUTxOStore(filepath.Join(tmpDir, "utxo.db"))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	defer store.Close()
+	emptyStore, err := ledger.NewBoltUTxOStore(filepath.Join(tmpDir, "restore.db"))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	testUtxoStore(t, store, emptyStore)
+	// The contents are kept when the database is reopened
+	if err := emptyStore.Close(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	emptyStore, err = ledger.NewBoltUTxOStore(filepath.Join(tmpDir, "restore.db"))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	defer emptyStore.Close()
+	if count, err := emptyStore.Len(); err != nil || count != 2 {
+		t.Fatalf("did not get expected number of UTxOs after reopening: got %d (%v), expected 2", count, err)
+	}
+}
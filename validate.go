@@ -0,0 +1,489 @@
+package ledger
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/cloudstruct/go-cardano-ledger/cbor"
+)
+
+const (
+	// Maximum serialized size of an output value in the Mary era, which predates the MaxValueSize
+	// protocol parameter
+	maryMaxValueSize = 4000
+)
+
+// ValidateTransaction runs the phase-1 UTXO rules against a transaction, using the resolved UTxOs
+// for the inputs, collateral and reference inputs it spends or references. It returns nil if the
+// transaction passes, or an ApplyTxError containing a failure for each rule that it breaks. The
+// failures have their type IDs set for the transaction's era and are wrapped in the UtxowFailure
+// and UtxoFailure layers in the same way as the node's error, so the error encodes to the CBOR the
+// node would return. Use errors.As to check for a specific failure.
+//
+// A MaxTxSize of 0 in the protocol parameters means that there is no size limit, as with
+// TxBuilder.
+//
+// The following rules are checked:
+//
+//   - the input set is not empty, and all inputs, collateral and reference inputs exist
+//   - the slot is within the validity interval
+//   - the transaction size and fee
+//   - the value consumed equals the value produced, including deposits and refunds
+//   - the minimum lovelace and maximum value size of outputs
+//   - the network ID of outputs, withdrawals and the transaction body
+//   - the collateral, for transactions with redeemers
+//
+// A withdrawal with a reward account that can't be parsed is reported as a GenericError in the
+// ApplyTxError, which is not wrapped like the other failures.
+//
+// Pool registrations are assumed to be for new pools when calculating deposits, and the witness
+// and script rules checked by the UTXOW rule are not covered
+func ValidateTransaction(tx interface{}, utxos []UnspentOutput, params BabbageProtocolParameters, slot uint64, networkId uint8) error {
+	var era uint8
+	var inputs, collateral, referenceInputs []ShelleyTransactionInput
+	var outputs []BabbageTransactionOutput
+	var collateralReturn *BabbageTransactionOutput
+	var fee, ttl, validityStart, totalCollateral, extraProduced uint64
+	var certificates []CertificateWrapper
+	var withdrawals map[cbor.ByteString]uint64
+	var mint MintAssets
+	var redeemers []Redeemer
+	var bodyNetworkId *uint8
+	var err error
+	switch t := tx.(type) {
+	case *ShelleyTransaction:
+		era = ERA_ID_SHELLEY
+		inputs, fee, ttl = t.Body.Inputs, t.Body.Fee, t.Body.Ttl
+		certificates, withdrawals = t.Body.Certificates, t.Body.Withdrawals
+		outputs, err = validationBodyOutputs(&t.Body)
+	case *AllegraTransaction:
+		era = ERA_ID_ALLEGRA
+		inputs, fee, ttl, validityStart = t.Body.Inputs, t.Body.Fee, t.Body.Ttl, t.Body.ValidityIntervalStart
+		certificates, withdrawals = t.Body.Certificates, t.Body.Withdrawals
+		outputs, err = validationBodyOutputs(&t.Body)
+	case *MaryTransaction:
+		era = ERA_ID_MARY
+		inputs, fee, ttl, validityStart = t.Body.Inputs, t.Body.Fee, t.Body.Ttl, t.Body.ValidityIntervalStart
+		certificates, withdrawals, mint = t.Body.Certificates, t.Body.Withdrawals, t.Body.Mint
+		outputs, err = validationBodyOutputs(&t.Body)
+	case *AlonzoTransaction:
+		era = ERA_ID_ALONZO
+		inputs, fee, ttl, validityStart = t.Body.Inputs, t.Body.Fee, t.Body.Ttl, t.Body.ValidityIntervalStart
+		certificates, withdrawals, mint = t.Body.Certificates, t.Body.Withdrawals, t.Body.Mint
+		collateral = t.Body.Collateral
+		redeemers = t.WitnessSet.Redeemers
+		outputs, err = validationBodyOutputs(&t.Body)
+		if err == nil {
+			bodyNetworkId, err = validationBodyNetworkId(&t.Body, t.Body.NetworkId)
+		}
+	case *BabbageTransaction:
+		era = ERA_ID_BABBAGE
+		inputs, fee, ttl, validityStart = t.Body.Inputs, t.Body.Fee, t.Body.Ttl, t.Body.ValidityIntervalStart
+		certificates, withdrawals, mint = t.Body.Certificates, t.Body.Withdrawals, t.Body.Mint
+		collateral, collateralReturn, totalCollateral = t.Body.Collateral, t.Body.CollateralReturn, t.Body.TotalCollateral
+		referenceInputs, outputs = t.Body.ReferenceInputs, t.Body.Outputs
+		redeemers = t.WitnessSet.Redeemers
+		bodyNetworkId, err = validationBodyNetworkId(&t.Body, t.Body.NetworkId)
+	case *ConwayTransaction:
+		era = ERA_ID_CONWAY
+		inputs, fee, ttl, validityStart = t.Body.Inputs, t.Body.Fee, t.Body.Ttl, t.Body.ValidityIntervalStart
+		certificates, withdrawals, mint = t.Body.Certificates, t.Body.Withdrawals, t.Body.Mint
+		collateral, collateralReturn, totalCollateral = t.Body.Collateral, t.Body.CollateralReturn, t.Body.TotalCollateral
+		referenceInputs, outputs = t.Body.ReferenceInputs, t.Body.Outputs
+		if t.WitnessSet.Redeemers != nil {
+			redeemers = t.WitnessSet.Redeemers.Redeemers
+		}
+		// Treasury donations and governance proposal deposits are part of the value produced
+		extraProduced = t.Body.Donation
+		for _, proposal := range t.Body.ProposalProcedures {
+			extraProduced += proposal.Deposit
+		}
+		bodyNetworkId, err = validationBodyNetworkId(&t.Body, t.Body.NetworkId)
+	default:
+		return fmt.Errorf("unsupported transaction type: %T", tx)
+	}
+	if err != nil {
+		return err
+	}
+	utxoMap := make(map[ShelleyTransactionInput]BabbageTransactionOutput, len(utxos))
+	for _, utxo := range utxos {
+		utxoMap[utxo.Input] = utxo.Output
+	}
+	var failures []error
+	if len(inputs) == 0 {
+		failures = append(failures, &InputSetEmptyUtxo{})
+	}
+	// Validity interval
+	if era == ERA_ID_SHELLEY {
+		if ttl < slot {
+			failures = append(failures, &ExpiredUtxo{Ttl: ttl, Slot: slot})
+		}
+	} else {
+		var interval ValidityInterval
+		if validityStart > 0 {
+			interval.InvalidBefore = &validityStart
+		}
+		if ttl > 0 {
+			interval.InvalidHereafter = &ttl
+		}
+		if (interval.InvalidBefore != nil && slot < validityStart) || (interval.InvalidHereafter != nil && slot >= ttl) {
+			failures = append(failures, &OutsideValidityIntervalUtxo{ValidityInterval: interval, Slot: slot})
+		}
+	}
+	// Transaction size
+	txSize, err := transactionSize(tx.(cbor.DecodeStoreCborInterface))
+	if err != nil {
+		return err
+	}
+	if params.MaxTxSize > 0 && txSize > uint64(params.MaxTxSize) {
+		failures = append(failures, &MaxTxSizeUtxo{ActualSize: int(txSize), MaxSize: int(params.MaxTxSize)})
+	}
+	// Inputs, collateral and reference inputs must all exist
+	var badInputs []TxIn
+	var resolvedInputs []UnspentOutput
+	for _, tmpInputs := range [][]ShelleyTransactionInput{inputs, collateral, referenceInputs} {
+		for _, input := range tmpInputs {
+			output, ok := utxoMap[input]
+			if !ok {
+				badInputs = append(badInputs, validationTxIn(input))
+				continue
+			}
+			resolvedInputs = append(resolvedInputs, UnspentOutput{Input: input, Output: output})
+		}
+	}
+	if len(badInputs) > 0 {
+		failures = append(failures, &BadInputsUtxo{Inputs: badInputs})
+	}
+	// Fee
+	minFee, err := CalculateMinFee(tx, params, resolvedInputs...)
+	if err != nil {
+		return err
+	}
+	if fee < minFee {
+		failures = append(failures, &FeeTooSmallUtxo{MinimumFee: minFee, SuppliedFee: fee})
+	}
+	// Value conservation. Only the inputs that exist are counted
+	var certs []Certificate
+	for _, cert := range certificates {
+		certs = append(certs, cert.Certificate)
+	}
+	deposit, refund := certificateDeposits(certs, params)
+	minted, burned := splitMint(mint)
+	consumed := MaryValue{Coin: refund, Assets: minted}
+	for _, input := range inputs {
+		if output, ok := utxoMap[input]; ok {
+			consumed = valueAdd(consumed, output.Amount)
+		}
+	}
+	for _, amount := range withdrawals {
+		consumed.Coin += amount
+	}
+	produced := MaryValue{Coin: fee + deposit + extraProduced, Assets: burned}
+	for _, output := range outputs {
+		produced = valueAdd(produced, output.Amount)
+	}
+	// The sums are normalized so that empty asset maps compare equal
+	consumed = valueAdd(consumed, MaryValue{})
+	produced = valueAdd(produced, MaryValue{})
+	if !valueCovers(consumed, produced) || !valueCovers(produced, consumed) {
+		failures = append(failures, &ValueNotConservedUtxo{Consumed: consumed, Produced: produced})
+	}
+	// Outputs
+	outputFailures, err := validateOutputs(era, outputs, params, networkId)
+	if err != nil {
+		return err
+	}
+	failures = append(failures, outputFailures...)
+	// Withdrawals must use reward accounts on the expected network
+	var badRewardAccounts []Address
+	for rewardAccount := range withdrawals {
+		addr, err := NewAddressFromBytes(rewardAccount.Bytes())
+		if err != nil {
+			failures = append(
+				failures,
+				&GenericError{
+					Value: fmt.Sprintf("invalid withdrawal reward account %x: %s", rewardAccount.Bytes(), err),
+				},
+			)
+			continue
+		}
+		if addr.NetworkId() != networkId {
+			badRewardAccounts = append(badRewardAccounts, addr)
+		}
+	}
+	if len(badRewardAccounts) > 0 {
+		failures = append(failures, &WrongNetworkWithdrawal{ExpectedNetworkId: int(networkId), RewardAccounts: badRewardAccounts})
+	}
+	if bodyNetworkId != nil && *bodyNetworkId != networkId {
+		failures = append(failures, &WrongNetworkInTxBody{ActualNetworkId: int(networkId), TransactionNetworkId: int(*bodyNetworkId)})
+	}
+	// Collateral is only checked for transactions that run scripts
+	if len(redeemers) > 0 {
+		failures = append(failures, validateCollateral(era, collateral, collateralReturn, totalCollateral, fee, utxoMap, params)...)
+	}
+	if len(failures) == 0 {
+		return nil
+	}
+	wrappedFailures := make([]error, 0, len(failures))
+	for _, failure := range failures {
+		// Failures that don't come from a ledger rule are not wrapped
+		if _, ok := failure.(*GenericError); ok {
+			wrappedFailures = append(wrappedFailures, failure)
+			continue
+		}
+		if tmpFailure, ok := failure.(interface{ setUtxoFailureType(uint8) }); ok {
+			tmpFailure.setUtxoFailureType(validationFailureType(era, failure))
+		}
+		wrappedFailures = append(wrappedFailures, validationWrapFailure(era, failure))
+	}
+	return &ApplyTxError{Era: era, Failures: wrappedFailures}
+}
+
+// Helper function to check the minimum lovelace, maximum value size and network ID of the outputs
+func validateOutputs(era uint8, outputs []BabbageTransactionOutput, params BabbageProtocolParameters, networkId uint8) ([]error, error) {
+	var failures []error
+	var smallOutputs []TxOut
+	babbageSmallOutputs := &BabbageOutputTooSmallUtxo{}
+	bigOutputs := &OutputTooBigUtxo{}
+	allegraBigOutputs := &AllegraOutputTooBigUtxo{}
+	var badAddrs []Address
+	for _, output := range outputs {
+		minCoin, err := MinUTxO(output, params)
+		if err != nil {
+			return nil, err
+		}
+		if output.Amount.Coin < minCoin {
+			if era >= ERA_ID_BABBAGE {
+				babbageSmallOutputs.Outputs = append(
+					babbageSmallOutputs.Outputs,
+					struct {
+						cbor.StructAsArray
+						Output    TxOut
+						MinAmount uint64
+					}{Output: output, MinAmount: minCoin},
+				)
+			} else {
+				smallOutputs = append(smallOutputs, output)
+			}
+		}
+		// The value size limit applies from the Mary era, when outputs can contain native assets
+		maxValueSize := uint64(params.MaxValueSize)
+		if era == ERA_ID_MARY {
+			maxValueSize = maryMaxValueSize
+		}
+		if era >= ERA_ID_MARY && maxValueSize > 0 {
+			valueCbor, err := cbor.Encode(output.Amount)
+			if err != nil {
+				return nil, err
+			}
+			if uint64(len(valueCbor)) > maxValueSize {
+				if era == ERA_ID_MARY {
+					allegraBigOutputs.Outputs = append(allegraBigOutputs.Outputs, output)
+				} else {
+					bigOutputs.Outputs = append(
+						bigOutputs.Outputs,
+						struct {
+							ActualSize int
+							MaxSize    int
+							Output     TxOut
+						}{ActualSize: len(valueCbor), MaxSize: int(maxValueSize), Output: output},
+					)
+				}
+			}
+		}
+		if output.Address.NetworkId() != networkId {
+			badAddrs = append(badAddrs, output.Address)
+		}
+	}
+	if len(smallOutputs) > 0 {
+		failures = append(failures, &OutputTooSmallUtxo{Outputs: smallOutputs})
+	}
+	if len(babbageSmallOutputs.Outputs) > 0 {
+		failures = append(failures, babbageSmallOutputs)
+	}
+	if len(allegraBigOutputs.Outputs) > 0 {
+		failures = append(failures, allegraBigOutputs)
+	}
+	if len(bigOutputs.Outputs) > 0 {
+		failures = append(failures, bigOutputs)
+	}
+	if len(badAddrs) > 0 {
+		failures = append(failures, &WrongNetwork{ExpectedNetworkId: int(networkId), Addresses: badAddrs})
+	}
+	return failures, nil
+}
+
+// Helper function to check the collateral for a transaction with redeemers. Collateral inputs that
+// do not exist have already been reported, and are not counted
+func validateCollateral(era uint8, collateral []ShelleyTransactionInput, collateralReturn *BabbageTransactionOutput, totalCollateral uint64, fee uint64, utxoMap map[ShelleyTransactionInput]BabbageTransactionOutput, params BabbageProtocolParameters) []error {
+	var failures []error
+	if len(collateral) == 0 {
+		failures = append(failures, &NoCollateralInputs{})
+	}
+	if len(collateral) > int(params.MaxCollateralInputs) {
+		failures = append(failures, &TooManyCollateralInputs{MaxAllowed: int(params.MaxCollateralInputs), Supplied: len(collateral)})
+	}
+	scriptUtxos := Utxo{}
+	var collateralValue MaryValue
+	for _, input := range collateral {
+		output, ok := utxoMap[input]
+		if !ok {
+			continue
+		}
+		if cred := output.Address.PaymentCredential(); cred != nil && cred.Type == CREDENTIAL_TYPE_SCRIPT_HASH {
+			scriptUtxos[validationTxIn(input)] = output
+		}
+		collateralValue = valueAdd(collateralValue, output.Amount)
+	}
+	if len(scriptUtxos) > 0 {
+		failures = append(failures, &ScriptsNotPaidUtxo{Utxo: scriptUtxos})
+	}
+	// The collateral balance is the collateral value less the collateral return. Assets are only
+	// allowed if they are all returned
+	balance := int64(collateralValue.Coin)
+	balanceAssets := collateralValue.Assets
+	if collateralReturn != nil {
+		balance -= int64(collateralReturn.Amount.Coin)
+		if valueCovers(collateralValue, MaryValue{Assets: collateralReturn.Amount.Assets}) {
+			balanceAssets = valueSubtract(MaryValue{Assets: collateralValue.Assets}, MaryValue{Assets: collateralReturn.Amount.Assets}).Assets
+		}
+	}
+	if len(balanceAssets) > 0 {
+		failures = append(failures, &CollateralContainsNonADA{Value: MaryValue{Coin: uint64(balance), Assets: balanceAssets}})
+	}
+	// The balance must be at least the collateral percentage of the fee, rounded up
+	requiredCollateral := (fee*uint64(params.CollateralPercentage) + 99) / 100
+	if balance < 0 || uint64(balance)*100 < fee*uint64(params.CollateralPercentage) {
+		failures = append(failures, &InsufficientCollateral{BalanceComputed: balance, RequiredCollateral: requiredCollateral})
+	}
+	if era >= ERA_ID_BABBAGE && totalCollateral > 0 && (balance < 0 || uint64(balance) != totalCollateral) {
+		failures = append(failures, &IncorrectTotalCollateralField{ProvidedCollateral: balance, TotalCollateral: totalCollateral})
+	}
+	return failures
+}
+
+// Helper function to return the outputs of a transaction body from before the Babbage era, which
+// are decoded from its CBOR. A body that was not decoded from CBOR is encoded first
+func validationBodyOutputs(body cbor.DecodeStoreCborInterface) ([]BabbageTransactionOutput, error) {
+	bodyCbor := body.Cbor()
+	if bodyCbor == nil {
+		var err error
+		bodyCbor, err = cbor.Encode(body)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return decodeTransactionBodyOutputs(bodyCbor)
+}
+
+// Helper function to return the network ID of a transaction body, if set. The network ID field is
+// read from the original CBOR when available, since a network ID of 0 cannot be distinguished
+// from an unset field once decoded
+func validationBodyNetworkId(body cbor.DecodeStoreCborInterface, networkId uint8) (*uint8, error) {
+	if bodyCbor := body.Cbor(); bodyCbor != nil {
+		var tmpData struct {
+			NetworkId *uint8 `cbor:"15,keyasint,omitempty"`
+		}
+		if _, err := cbor.Decode(bodyCbor, &tmpData); err != nil {
+			return nil, fmt.Errorf("decode error: %s", err)
+		}
+		return tmpData.NetworkId, nil
+	}
+	if networkId == 0 {
+		return nil, nil
+	}
+	return &networkId, nil
+}
+
+// Helper function to convert a transaction input to the type used in failures
+func validationTxIn(input ShelleyTransactionInput) TxIn {
+	return TxIn{Utxo: cbor.NewByteString(input.Id[:]), TxIx: input.Index}
+}
+
+// Helper function to wrap a UTXO rule failure in the UTXOW and UTXO rule failures, in the same way
+// as the node does for the specified era
+func validationWrapFailure(era uint8, failure error) error {
+	switch era {
+	case ERA_ID_SHELLEY, ERA_ID_ALLEGRA, ERA_ID_MARY:
+		return &UtxowFailure{
+			PredicateFailureBase: PredicateFailureBase{Type: APPLY_TX_ERROR_UTXOW_FAILURE},
+			Era:                  era,
+			Err: &UtxoFailure{
+				PredicateFailureBase: PredicateFailureBase{Type: SHELLEY_UTXOW_FAILURE_UTXO_FAILURE},
+				Era:                  era,
+				Err:                  failure,
+			},
+		}
+	case ERA_ID_ALONZO:
+		// The Alonzo UTXOW rule wraps the Shelley UTXOW rule, which wraps the UTXO rule
+		return &UtxowFailure{
+			PredicateFailureBase: PredicateFailureBase{Type: APPLY_TX_ERROR_UTXOW_FAILURE},
+			Era:                  era,
+			Err: &ShelleyInAlonzoUtxowPredFailure{
+				PredicateFailureBase: PredicateFailureBase{Type: ALONZO_UTXOW_FAILURE_SHELLEY_IN_ALONZO},
+				Era:                  era,
+				Err: &UtxoFailure{
+					PredicateFailureBase: PredicateFailureBase{Type: SHELLEY_UTXOW_FAILURE_UTXO_FAILURE},
+					Era:                  era,
+					Err:                  failure,
+				},
+			},
+		}
+	case ERA_ID_BABBAGE:
+		// Failures inherited from the Alonzo UTXO rule are wrapped in FromAlonzoUtxoFail
+		switch failure.(type) {
+		case *IncorrectTotalCollateralField, *BabbageOutputTooSmallUtxo, *BabbageNonDisjointRefInputs:
+		default:
+			failure = &FromAlonzoUtxoFail{
+				PredicateFailureBase: PredicateFailureBase{Type: UTXO_FAILURE_FROM_ALONZO},
+				Era:                  era,
+				Err:                  failure,
+			}
+		}
+		return &UtxowFailure{
+			PredicateFailureBase: PredicateFailureBase{Type: APPLY_TX_ERROR_UTXOW_FAILURE},
+			Era:                  era,
+			Err: &UtxoFailure{
+				PredicateFailureBase: PredicateFailureBase{Type: UTXOW_FAILURE_UTXO_FAILURE},
+				Era:                  era,
+				Err:                  failure,
+			},
+		}
+	case ERA_ID_CONWAY:
+		return &ConwayUtxowFailure{
+			PredicateFailureBase: PredicateFailureBase{Type: CONWAY_LEDGER_FAILURE_UTXOW},
+			Err: &UtxoFailure{
+				PredicateFailureBase: PredicateFailureBase{Type: CONWAY_UTXOW_FAILURE_UTXO_FAILURE},
+				Era:                  era,
+				Err:                  failure,
+			},
+		}
+	}
+	return failure
+}
+
+// Helper function to return the type ID of a failure in the specified era. Babbage failures that
+// are inherited from Alonzo use their Alonzo type ID
+func validationFailureType(era uint8, failure error) uint8 {
+	var idMaps []map[int]interface{}
+	switch era {
+	case ERA_ID_SHELLEY:
+		idMaps = append(idMaps, shelleyUtxoFailureIdMap())
+	case ERA_ID_ALLEGRA, ERA_ID_MARY:
+		idMaps = append(idMaps, allegraUtxoFailureIdMap())
+	case ERA_ID_ALONZO:
+		idMaps = append(idMaps, alonzoUtxoFailureIdMap(era))
+	case ERA_ID_BABBAGE:
+		idMaps = append(idMaps, babbageUtxoFailureIdMap(era), alonzoUtxoFailureIdMap(era))
+	case ERA_ID_CONWAY:
+		idMaps = append(idMaps, conwayUtxoFailureIdMap())
+	}
+	for _, idMap := range idMaps {
+		for id, tmpFailure := range idMap {
+			if reflect.TypeOf(tmpFailure) == reflect.TypeOf(failure) {
+				return uint8(id)
+			}
+		}
+	}
+	return 0
+}
@@ -0,0 +1,395 @@
+package ledger_test
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/cloudstruct/go-cardano-ledger"
+	"github.com/cloudstruct/go-cardano-ledger/cbor"
+)
+
+// Helper function to build a Babbage transaction from a transaction body and witness set
+func testValidateTx(t *testing.T, body map[uint]interface{}, witnessSet map[uint]interface{}) *ledger.BabbageTransaction {
+	txCbor, err := cbor.Encode([]interface{}{body, witnessSet, true, nil})
+	if err != nil {
+		t.Fatalf("failed to encode transaction: %s", err)
+	}
+	tx, err := ledger.NewBabbageTransactionFromCbor(txCbor)
+	if err != nil {
+		t.Fatalf("failed to decode transaction: %s", err)
+	}
+	return tx
+}
+
+// Helper function to check whether an error contains a failure of the same type as the expected one
+func testHasFailure(err error, expected error) bool {
+	target := reflect.New(reflect.TypeOf(expected))
+	return errors.As(err, target.Interface())
+}
+
+func TestValidateTransaction(t *testing.T) {
+	addr := testAddress(t, "addr1vx2fxv2umyhttkxyxp8x0dlpdt3k6cwng5pxj3jhsydzers66hrl8")
+	testnetAddr, err := ledger.NewAddressFromParts(ledger.ADDRESS_TYPE_KEY_NONE, 0, bytes.Repeat([]byte{0x01}, 28), nil)
+	if err != nil {
+		t.Fatalf("failed to build address: %s", err)
+	}
+	utxos := []ledger.UnspentOutput{
+		testUtxo(addr, 0x01, 0, ledger.MaryValue{Coin: 10000000}),
+		testUtxo(addr, 0x02, 0, ledger.MaryValue{Coin: 5000000}),
+		testUtxo(addr, 0x02, 1, ledger.MaryValue{Coin: 1000000}),
+	}
+	encodeInput := func(utxo ledger.UnspentOutput) []interface{} {
+		return []interface{}{utxo.Input.Id[:], utxo.Input.Index}
+	}
+	params := testProtocolParams
+	params.CollateralPercentage = 150
+	params.MaxCollateralInputs = 3
+	redeemers := map[uint]interface{}{
+		5: []interface{}{[]interface{}{0, 0, 0, []interface{}{1000, 1000}}},
+	}
+	testDefs := []struct {
+		name        string
+		modifyFunc  func(body map[uint]interface{}, witnessSet map[uint]interface{})
+		slot        uint64
+		expectedErr error
+	}{
+		{
+			name:       "valid",
+			modifyFunc: func(body map[uint]interface{}, witnessSet map[uint]interface{}) {},
+		},
+		{
+			name: "valid with collateral",
+			modifyFunc: func(body map[uint]interface{}, witnessSet map[uint]interface{}) {
+				body[13] = []interface{}{encodeInput(utxos[1])}
+				witnessSet[5] = redeemers[5]
+			},
+		},
+		{
+			name: "input set empty",
+			modifyFunc: func(body map[uint]interface{}, witnessSet map[uint]interface{}) {
+				body[0] = []interface{}{}
+			},
+			expectedErr: &ledger.InputSetEmptyUtxo{},
+		},
+		{
+			name:        "outside validity interval",
+			modifyFunc:  func(body map[uint]interface{}, witnessSet map[uint]interface{}) {},
+			slot:        2000,
+			expectedErr: &ledger.OutsideValidityIntervalUtxo{},
+		},
+		{
+			name: "bad inputs",
+			modifyFunc: func(body map[uint]interface{}, witnessSet map[uint]interface{}) {
+				body[0] = []interface{}{encodeInput(testUtxo(addr, 0x03, 0, ledger.MaryValue{}))}
+			},
+			expectedErr: &ledger.BadInputsUtxo{},
+		},
+		{
+			name: "fee too small",
+			modifyFunc: func(body map[uint]interface{}, witnessSet map[uint]interface{}) {
+				body[1] = []interface{}{[]interface{}{addr.Bytes(), 9999000}}
+				body[2] = 1000
+			},
+			expectedErr: &ledger.FeeTooSmallUtxo{},
+		},
+		{
+			name: "value not conserved",
+			modifyFunc: func(body map[uint]interface{}, witnessSet map[uint]interface{}) {
+				body[1] = []interface{}{[]interface{}{addr.Bytes(), 8000000}}
+			},
+			expectedErr: &ledger.ValueNotConservedUtxo{},
+		},
+		{
+			name: "output too small",
+			modifyFunc: func(body map[uint]interface{}, witnessSet map[uint]interface{}) {
+				body[1] = []interface{}{
+					[]interface{}{addr.Bytes(), 8800000},
+					[]interface{}{addr.Bytes(), 200000},
+				}
+			},
+			expectedErr: &ledger.BabbageOutputTooSmallUtxo{},
+		},
+		{
+			name: "wrong network",
+			modifyFunc: func(body map[uint]interface{}, witnessSet map[uint]interface{}) {
+				body[1] = []interface{}{[]interface{}{testnetAddr.Bytes(), 9000000}}
+			},
+			expectedErr: &ledger.WrongNetwork{},
+		},
+		{
+			name: "wrong network in body",
+			modifyFunc: func(body map[uint]interface{}, witnessSet map[uint]interface{}) {
+				body[15] = 0
+			},
+			expectedErr: &ledger.WrongNetworkInTxBody{},
+		},
+		{
+			name: "no collateral inputs",
+			modifyFunc: func(body map[uint]interface{}, witnessSet map[uint]interface{}) {
+				witnessSet[5] = redeemers[5]
+			},
+			expectedErr: &ledger.NoCollateralInputs{},
+		},
+		{
+			name: "insufficient collateral",
+			modifyFunc: func(body map[uint]interface{}, witnessSet map[uint]interface{}) {
+				body[13] = []interface{}{encodeInput(utxos[2])}
+				witnessSet[5] = redeemers[5]
+			},
+			expectedErr: &ledger.InsufficientCollateral{},
+		},
+		{
+			name: "incorrect total collateral",
+			modifyFunc: func(body map[uint]interface{}, witnessSet map[uint]interface{}) {
+				body[13] = []interface{}{encodeInput(utxos[1])}
+				body[17] = 4000000
+				witnessSet[5] = redeemers[5]
+			},
+			expectedErr: &ledger.IncorrectTotalCollateralField{},
+		},
+	}
+	for _, testDef := range testDefs {
+		body := map[uint]interface{}{
+			0: []interface{}{encodeInput(utxos[0])},
+			1: []interface{}{[]interface{}{addr.Bytes(), 9000000}},
+			2: 1000000,
+			3: 1000,
+		}
+		witnessSet := map[uint]interface{}{}
+		testDef.modifyFunc(body, witnessSet)
+		tx := testValidateTx(t, body, witnessSet)
+		err := ledger.ValidateTransaction(tx, utxos, params, testDef.slot, ledger.ADDRESS_NETWORK_ID_MAINNET)
+		if testDef.expectedErr == nil {
+			if err != nil {
+				t.Fatalf("%s: unexpected error: %s", testDef.name, err)
+			}
+			continue
+		}
+		if err == nil {
+			t.Fatalf("%s: did not get expected error", testDef.name)
+		}
+		if !testHasFailure(err, testDef.expectedErr) {
+			t.Fatalf("%s: did not get expected error type %T: %s", testDef.name, testDef.expectedErr, err)
+		}
+		var applyTxErr *ledger.ApplyTxError
+		if !errors.As(err, &applyTxErr) || applyTxErr.Era != ledger.ERA_ID_BABBAGE {
+			t.Fatalf("%s: did not get expected ApplyTxError: %s", testDef.name, err)
+		}
+		// The failures are wrapped so that they decode from the encoded error
+		errCbor, err := cbor.Encode(&ledger.ShelleyTxValidationError{Era: ledger.ERA_ID_BABBAGE, Err: *applyTxErr})
+		if err != nil {
+			t.Fatalf("%s: failed to encode error: %s", testDef.name, err)
+		}
+		decodedErr, err := ledger.NewTxSubmitErrorFromCbor(errCbor)
+		if err != nil {
+			t.Fatalf("%s: failed to decode error: %s", testDef.name, err)
+		}
+		if !testHasFailure(decodedErr, testDef.expectedErr) {
+			t.Fatalf("%s: did not get expected error type %T from decoded error: %s", testDef.name, testDef.expectedErr, decodedErr)
+		}
+	}
+}
+
+func TestValidateTransactionFailureDetails(t *testing.T) {
+	addr := testAddress(t, "addr1vx2fxv2umyhttkxyxp8x0dlpdt3k6cwng5pxj3jhsydzers66hrl8")
+	utxo := testUtxo(addr, 0x01, 0, ledger.MaryValue{Coin: 10000000})
+	params := testProtocolParams
+	params.MaxTxSize = 50
+	tx := testValidateTx(
+		t,
+		map[uint]interface{}{
+			0: []interface{}{[]interface{}{utxo.Input.Id[:], utxo.Input.Index}},
+			1: []interface{}{[]interface{}{addr.Bytes(), 9999000}},
+			2: 1000,
+		},
+		map[uint]interface{}{},
+	)
+	err := ledger.ValidateTransaction(tx, []ledger.UnspentOutput{utxo}, params, 0, ledger.ADDRESS_NETWORK_ID_MAINNET)
+	var feeErr *ledger.FeeTooSmallUtxo
+	if !errors.As(err, &feeErr) {
+		t.Fatalf("did not get expected FeeTooSmallUtxo: %v", err)
+	}
+	minFee, feeCalcErr := ledger.CalculateMinFee(tx, params, utxo)
+	if feeCalcErr != nil {
+		t.Fatalf("unexpected error: %s", feeCalcErr)
+	}
+	if feeErr.MinimumFee != minFee || feeErr.SuppliedFee != 1000 {
+		t.Fatalf("did not get expected fees: got minimum %d, supplied %d, expected minimum %d, supplied %d", feeErr.MinimumFee, feeErr.SuppliedFee, minFee, 1000)
+	}
+	// The failure type ID matches the one the node uses in this era
+	if feeErr.Type != ledger.UTXO_FAILURE_FEE_TOO_SMALL_UTXO {
+		t.Fatalf("did not get expected failure type: got %d, expected %d", feeErr.Type, ledger.UTXO_FAILURE_FEE_TOO_SMALL_UTXO)
+	}
+	var sizeErr *ledger.MaxTxSizeUtxo
+	if !errors.As(err, &sizeErr) {
+		t.Fatalf("did not get expected MaxTxSizeUtxo: %v", err)
+	}
+	if sizeErr.ActualSize != len(tx.Cbor()) || sizeErr.MaxSize != 50 {
+		t.Fatalf("did not get expected sizes: got actual %d, max %d, expected actual %d, max %d", sizeErr.ActualSize, sizeErr.MaxSize, len(tx.Cbor()), 50)
+	}
+	// Failures can be encoded in the same format the node uses
+	if _, err := cbor.Encode(feeErr); err != nil {
+		t.Fatalf("failed to encode failure: %s", err)
+	}
+}
+
+func TestValidateTransactionFailureWrapping(t *testing.T) {
+	addr := testAddress(t, "addr1vx2fxv2umyhttkxyxp8x0dlpdt3k6cwng5pxj3jhsydzers66hrl8")
+	testnetAddr, err := ledger.NewAddressFromParts(ledger.ADDRESS_TYPE_KEY_NONE, 0, bytes.Repeat([]byte{0x01}, 28), nil)
+	if err != nil {
+		t.Fatalf("failed to build address: %s", err)
+	}
+	utxo := testUtxo(addr, 0x01, 0, ledger.MaryValue{Coin: 10000000})
+	body := map[uint]interface{}{
+		0: []interface{}{[]interface{}{utxo.Input.Id[:], utxo.Input.Index}},
+		1: []interface{}{[]interface{}{testnetAddr.Bytes(), 9000000}},
+		2: 1000000,
+		3: 1000,
+	}
+	testDefs := []struct {
+		name         string
+		era          uint8
+		txCbor       []interface{}
+		newTxFunc    func([]byte) (interface{}, error)
+		failureType  uint8
+		wrapperTypes []error
+	}{
+		{
+			name:   "Shelley",
+			era:    ledger.ERA_ID_SHELLEY,
+			txCbor: []interface{}{body, map[uint]interface{}{}, nil},
+			newTxFunc: func(data []byte) (interface{}, error) {
+				return ledger.NewShelleyTransactionFromCbor(data)
+			},
+			// Shelley uses a different failure type ID for WrongNetwork than later eras
+			failureType:  ledger.SHELLEY_UTXO_FAILURE_WRONG_NETWORK,
+			wrapperTypes: []error{&ledger.UtxowFailure{}, &ledger.UtxoFailure{}},
+		},
+		{
+			name:   "Alonzo",
+			era:    ledger.ERA_ID_ALONZO,
+			txCbor: []interface{}{body, map[uint]interface{}{}, true, nil},
+			newTxFunc: func(data []byte) (interface{}, error) {
+				return ledger.NewAlonzoTransactionFromCbor(data)
+			},
+			failureType:  ledger.UTXO_FAILURE_WRONG_NETWORK,
+			wrapperTypes: []error{&ledger.UtxowFailure{}, &ledger.ShelleyInAlonzoUtxowPredFailure{}, &ledger.UtxoFailure{}},
+		},
+		{
+			name:   "Babbage",
+			era:    ledger.ERA_ID_BABBAGE,
+			txCbor: []interface{}{body, map[uint]interface{}{}, true, nil},
+			newTxFunc: func(data []byte) (interface{}, error) {
+				return ledger.NewBabbageTransactionFromCbor(data)
+			},
+			failureType:  ledger.UTXO_FAILURE_WRONG_NETWORK,
+			wrapperTypes: []error{&ledger.UtxowFailure{}, &ledger.UtxoFailure{}, &ledger.FromAlonzoUtxoFail{}},
+		},
+		{
+			name:   "Conway",
+			era:    ledger.ERA_ID_CONWAY,
+			txCbor: []interface{}{body, map[uint]interface{}{}, true, nil},
+			newTxFunc: func(data []byte) (interface{}, error) {
+				return ledger.NewConwayTransactionFromCbor(data)
+			},
+			failureType:  ledger.CONWAY_UTXO_FAILURE_WRONG_NETWORK,
+			wrapperTypes: []error{&ledger.ConwayUtxowFailure{}, &ledger.UtxoFailure{}},
+		},
+	}
+	for _, testDef := range testDefs {
+		txCbor, err := cbor.Encode(testDef.txCbor)
+		if err != nil {
+			t.Fatalf("%s: failed to encode transaction: %s", testDef.name, err)
+		}
+		tx, err := testDef.newTxFunc(txCbor)
+		if err != nil {
+			t.Fatalf("%s: failed to decode transaction: %s", testDef.name, err)
+		}
+		err = ledger.ValidateTransaction(tx, []ledger.UnspentOutput{utxo}, testProtocolParams, 0, ledger.ADDRESS_NETWORK_ID_MAINNET)
+		var applyTxErr *ledger.ApplyTxError
+		if !errors.As(err, &applyTxErr) || applyTxErr.Era != testDef.era || len(applyTxErr.Failures) != 1 {
+			t.Fatalf("%s: did not get expected ApplyTxError: %v", testDef.name, err)
+		}
+		// The failure is wrapped in the same layers as the node's error for the era
+		failure := applyTxErr.Failures[0]
+		for _, wrapperType := range testDef.wrapperTypes {
+			if reflect.TypeOf(failure) != reflect.TypeOf(wrapperType) {
+				t.Fatalf("%s: did not get expected wrapper type %T: %s", testDef.name, wrapperType, failure)
+			}
+			failure = errors.Unwrap(failure)
+		}
+		wrongNetworkErr, ok := failure.(*ledger.WrongNetwork)
+		if !ok {
+			t.Fatalf("%s: did not get expected WrongNetwork: %s", testDef.name, failure)
+		}
+		if wrongNetworkErr.Type != testDef.failureType {
+			t.Fatalf("%s: did not get expected failure type: got %d, expected %d", testDef.name, wrongNetworkErr.Type, testDef.failureType)
+		}
+		// The error encodes to CBOR that decodes to the same failure
+		errCbor, err := cbor.Encode(&ledger.ShelleyTxValidationError{Era: testDef.era, Err: *applyTxErr})
+		if err != nil {
+			t.Fatalf("%s: failed to encode error: %s", testDef.name, err)
+		}
+		decodedErr, err := ledger.NewTxSubmitErrorFromCbor(errCbor)
+		if err != nil {
+			t.Fatalf("%s: failed to decode error: %s", testDef.name, err)
+		}
+		var decodedWrongNetworkErr *ledger.WrongNetwork
+		if !errors.As(decodedErr, &decodedWrongNetworkErr) || decodedWrongNetworkErr.Type != testDef.failureType {
+			t.Fatalf("%s: did not get expected WrongNetwork from decoded error: %s", testDef.name, decodedErr)
+		}
+		if decodedErr.Error() != (&ledger.ShelleyTxValidationError{Era: testDef.era, Err: *applyTxErr}).Error() {
+			t.Fatalf("%s: decoded error does not match\n  got:    %s\n  wanted: %s", testDef.name, decodedErr, applyTxErr)
+		}
+	}
+}
+
+func TestValidateTransactionNoMaxTxSize(t *testing.T) {
+	addr := testAddress(t, "addr1vx2fxv2umyhttkxyxp8x0dlpdt3k6cwng5pxj3jhsydzers66hrl8")
+	utxo := testUtxo(addr, 0x01, 0, ledger.MaryValue{Coin: 10000000})
+	params := testProtocolParams
+	params.MaxTxSize = 0
+	tx := testValidateTx(
+		t,
+		map[uint]interface{}{
+			0: []interface{}{[]interface{}{utxo.Input.Id[:], utxo.Input.Index}},
+			1: []interface{}{[]interface{}{addr.Bytes(), 9000000}},
+			2: 1000000,
+		},
+		map[uint]interface{}{},
+	)
+	// A maximum transaction size of 0 means that there is no limit, as with TxBuilder
+	if err := ledger.ValidateTransaction(tx, []ledger.UnspentOutput{utxo}, params, 0, ledger.ADDRESS_NETWORK_ID_MAINNET); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+}
+
+func TestValidateTransactionInvalidRewardAccount(t *testing.T) {
+	addr := testAddress(t, "addr1vx2fxv2umyhttkxyxp8x0dlpdt3k6cwng5pxj3jhsydzers66hrl8")
+	utxo := testUtxo(addr, 0x01, 0, ledger.MaryValue{Coin: 10000000})
+	tx := testValidateTx(
+		t,
+		map[uint]interface{}{
+			0: []interface{}{[]interface{}{utxo.Input.Id[:], utxo.Input.Index}},
+			1: []interface{}{[]interface{}{addr.Bytes(), 9000000}},
+			2: 1000000,
+			// Truncated reward account
+			5: map[cbor.ByteString]uint64{cbor.NewByteString([]byte{0xe1, 0x01, 0x02}): 0},
+		},
+		map[uint]interface{}{},
+	)
+	err := ledger.ValidateTransaction(tx, []ledger.UnspentOutput{utxo}, testProtocolParams, 0, ledger.ADDRESS_NETWORK_ID_MAINNET)
+	var applyTxErr *ledger.ApplyTxError
+	if !errors.As(err, &applyTxErr) {
+		t.Fatalf("did not get expected ApplyTxError: %v", err)
+	}
+	// The failure is not wrapped in the UtxowFailure and UtxoFailure layers
+	if len(applyTxErr.Failures) != 1 {
+		t.Fatalf("did not get expected failures: %s", err)
+	}
+	if _, ok := applyTxErr.Failures[0].(*ledger.GenericError); !ok {
+		t.Fatalf("did not get expected GenericError: %s", err)
+	}
+}
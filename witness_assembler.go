@@ -0,0 +1,108 @@
+package ledger
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"golang.org/x/crypto/blake2b"
+)
+
+// ErrInvalidWitness is returned when a witness signature is not valid for the transaction body
+var ErrInvalidWitness = errors.New("invalid witness signature")
+
+// WitnessAssembler collects detached witnesses for a transaction from multiple signers and merges
+// them into the transaction. Each witness is validated against the transaction body hash as it is
+// added, and witnesses for keys that have already signed are ignored
+type WitnessAssembler struct {
+	txCbor    []byte
+	bodyHash  Blake2b256
+	witnesses []VkeyWitness
+}
+
+// NewWitnessAssembler returns a WitnessAssembler for the CBOR of a transaction from any Shelley or
+// later era. Any witnesses already in the transaction are kept
+func NewWitnessAssembler(txCbor []byte) (*WitnessAssembler, error) {
+	txItems, witnessSet, err := decodeTransactionWitnessSet(txCbor)
+	if err != nil {
+		return nil, err
+	}
+	existingWitnesses, _, err := decodeVkeyWitnesses(witnessSet)
+	if err != nil {
+		return nil, err
+	}
+	ret := &WitnessAssembler{
+		txCbor:   make([]byte, len(txCbor)),
+		bodyHash: blake2b.Sum256(txItems[0]),
+	}
+	copy(ret.txCbor, txCbor)
+	// Track the existing witnesses so that duplicates are detected
+	for _, witness := range existingWitnesses {
+		if ret.hasWitness(witness.Vkey) {
+			continue
+		}
+		ret.witnesses = append(ret.witnesses, witness)
+	}
+	return ret, nil
+}
+
+// BodyHash returns the hash of the transaction body, which is the message signed by each witness
+func (a *WitnessAssembler) BodyHash() Blake2b256 {
+	return a.bodyHash
+}
+
+// AddWitnesses validates the witnesses and adds them to the transaction. Witnesses for keys that
+// already have a witness are ignored. No witnesses are added if any of them is invalid
+func (a *WitnessAssembler) AddWitnesses(witnesses ...VkeyWitness) error {
+	for _, witness := range witnesses {
+		if !witness.Verify(a.bodyHash[:]) {
+			return fmt.Errorf("%w: key hash %s", ErrInvalidWitness, witness.KeyHash().String())
+		}
+	}
+	for _, witness := range witnesses {
+		if a.hasWitness(witness.Vkey) {
+			continue
+		}
+		a.witnesses = append(a.witnesses, witness)
+	}
+	return nil
+}
+
+// Witnesses returns the witnesses collected so far, including any that were already in the
+// transaction
+func (a *WitnessAssembler) Witnesses() []VkeyWitness {
+	ret := make([]VkeyWitness, len(a.witnesses))
+	copy(ret, a.witnesses)
+	return ret
+}
+
+// MissingSigners returns the key hashes from the specified list that do not have a witness yet
+func (a *WitnessAssembler) MissingSigners(keyHashes []Blake2b224) []Blake2b224 {
+	signed := map[Blake2b224]bool{}
+	for _, witness := range a.witnesses {
+		signed[witness.KeyHash()] = true
+	}
+	var ret []Blake2b224
+	for _, keyHash := range keyHashes {
+		if !signed[keyHash] {
+			ret = append(ret, keyHash)
+		}
+	}
+	return ret
+}
+
+// Assemble returns the CBOR for the transaction with all of the collected witnesses. The original
+// CBOR for the transaction body is kept as-is
+func (a *WitnessAssembler) Assemble() ([]byte, error) {
+	return AddVkeyWitnesses(a.txCbor, a.witnesses...)
+}
+
+// Helper function to check whether a verification key already has a witness
+func (a *WitnessAssembler) hasWitness(vkey []byte) bool {
+	for _, witness := range a.witnesses {
+		if bytes.Equal(witness.Vkey, vkey) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,106 @@
+package ledger_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/cloudstruct/go-cardano-ledger"
+)
+
+func TestWitnessAssembler(t *testing.T) {
+	wallet, err := ledger.NewCip1852WalletFromMnemonic(testMnemonic, "")
+	if err != nil {
+		t.Fatalf("failed to create wallet: %s", err)
+	}
+	var keys []*ledger.ExtendedSigningKey
+	var keyHashes []ledger.Blake2b224
+	for i := uint32(0); i < 3; i++ {
+		key, err := wallet.PaymentKey(0, i)
+		if err != nil {
+			t.Fatalf("failed to derive key: %s", err)
+		}
+		keys = append(keys, key)
+		keyHashes = append(keyHashes, key.KeyHash())
+	}
+	addr, err := wallet.EnterpriseAddress(ledger.ADDRESS_NETWORK_ID_MAINNET, 0, 0)
+	if err != nil {
+		t.Fatalf("failed to derive address: %s", err)
+	}
+	tx, err := ledger.NewTxBuilder(testProtocolParams).
+		AddUtxos(testUtxo(addr, 0x01, 0, ledger.MaryValue{Coin: 10000000})).
+		AddOutputs(ledger.BabbageTransactionOutput{Address: addr, Amount: ledger.MaryValue{Coin: 5000000}}).
+		SetChangeAddress(addr).
+		SetExtraWitnessCount(2).
+		Build()
+	if err != nil {
+		t.Fatalf("failed to build transaction: %s", err)
+	}
+	// The first signer signs the transaction directly
+	txCbor, err := ledger.SignTransaction(tx.Cbor(), keys[0])
+	if err != nil {
+		t.Fatalf("failed to sign transaction: %s", err)
+	}
+	assembler, err := ledger.NewWitnessAssembler(txCbor)
+	if err != nil {
+		t.Fatalf("failed to create witness assembler: %s", err)
+	}
+	if missing := assembler.MissingSigners(keyHashes); len(missing) != 2 || missing[0] != keyHashes[1] {
+		t.Fatalf("did not get expected missing signers: %v", missing)
+	}
+	// The other signers provide detached witnesses
+	var witnesses []ledger.VkeyWitness
+	for _, key := range keys[1:] {
+		witness, err := ledger.SignTransactionBody(&tx.Body, key)
+		if err != nil {
+			t.Fatalf("failed to sign transaction body: %s", err)
+		}
+		witnesses = append(witnesses, witness)
+	}
+	// A witness for a different transaction is rejected
+	badWitness := ledger.VkeyWitness{Vkey: keys[2].PublicKey(), Signature: keys[2].Sign([]byte("other"))}
+	if err := assembler.AddWitnesses(witnesses[0], badWitness); !errors.Is(err, ledger.ErrInvalidWitness) {
+		t.Fatalf("did not get expected invalid witness error, got: %v", err)
+	}
+	if len(assembler.Witnesses()) != 1 {
+		t.Fatalf("witnesses were added despite an invalid witness")
+	}
+	// Duplicate witnesses are ignored
+	if err := assembler.AddWitnesses(witnesses...); err != nil {
+		t.Fatalf("failed to add witnesses: %s", err)
+	}
+	if err := assembler.AddWitnesses(witnesses[0]); err != nil {
+		t.Fatalf("failed to add witnesses: %s", err)
+	}
+	if missing := assembler.MissingSigners(keyHashes); len(missing) != 0 {
+		t.Fatalf("did not get expected missing signers: %v", missing)
+	}
+	signedTxCbor, err := assembler.Assemble()
+	if err != nil {
+		t.Fatalf("failed to assemble transaction: %s", err)
+	}
+	signedTx, err := ledger.NewBabbageTransactionFromCbor(signedTxCbor)
+	if err != nil {
+		t.Fatalf("failed to decode transaction: %s", err)
+	}
+	if !bytes.Equal(signedTx.Body.Cbor(), tx.Body.Cbor()) {
+		t.Fatalf("transaction body changed when adding witnesses")
+	}
+	if len(signedTx.WitnessSet.VkeyWitnesses) != 3 {
+		t.Fatalf("expected 3 witnesses, got %d", len(signedTx.WitnessSet.VkeyWitnesses))
+	}
+	bodyHash := assembler.BodyHash()
+	for _, witness := range signedTx.WitnessSet.VkeyWitnesses {
+		if !witness.Verify(bodyHash[:]) {
+			t.Fatalf("witness for key hash %s is not valid", witness.KeyHash().String())
+		}
+	}
+	// The fee covers the size of the fully signed transaction
+	minFee, err := ledger.CalculateMinFee(signedTx, testProtocolParams)
+	if err != nil {
+		t.Fatalf("failed to calculate fee: %s", err)
+	}
+	if signedTx.Body.Fee < minFee {
+		t.Fatalf("fee %d is less than minimum fee %d for signed transaction", signedTx.Body.Fee, minFee)
+	}
+}